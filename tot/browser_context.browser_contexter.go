@@ -0,0 +1,145 @@
+package chrome
+
+import (
+	"sync"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/target"
+	"github.com/pkg/errors"
+)
+
+/*
+NewBrowserContext implements Chromium.
+*/
+func (chrome *Chrome) NewBrowserContext() (*BrowserContext, error) {
+	browser, err := chrome.BrowserSocket()
+	if nil != err {
+		return nil, err
+	}
+
+	result := <-browser.Target().CreateBrowserContext()
+	if nil != result.Err {
+		return nil, errors.Wrap(result.Err, "could not create browser context")
+	}
+
+	return &BrowserContext{
+		chrome: chrome,
+		id:     result.BrowserContextID,
+		mux:    &sync.Mutex{},
+		tabs:   make([]*Tab, 0),
+	}, nil
+}
+
+/*
+BrowserContext is a struct representing an isolated Chromium browser context.
+*/
+type BrowserContext struct {
+	chrome *Chrome
+	closed bool
+	id     target.BrowserContextID
+	mux    *sync.Mutex
+	tabs   []*Tab
+}
+
+/*
+Chromium implements BrowserContexter.
+*/
+func (browserContext *BrowserContext) Chromium() *Chrome {
+	return browserContext.chrome
+}
+
+/*
+Close implements BrowserContexter.
+
+Tabs are closed before the browser context is disposed. The browser context is
+disposed even if closing a tab fails, the first error is returned.
+*/
+func (browserContext *BrowserContext) Close() error {
+	browserContext.mux.Lock()
+	if browserContext.closed {
+		browserContext.mux.Unlock()
+		return nil
+	}
+	browserContext.closed = true
+	browserContext.mux.Unlock()
+
+	var err error
+	for _, tab := range browserContext.Tabs() {
+		if _, closeErr := tab.Close(); nil != closeErr && nil == err {
+			err = closeErr
+		}
+	}
+
+	browser, socketErr := browserContext.chrome.BrowserSocket()
+	if nil != socketErr {
+		if nil == err {
+			err = socketErr
+		}
+		return err
+	}
+	result := <-browser.Target().DisposeBrowserContext(&target.DisposeBrowserContextParams{
+		BrowserContextID: browserContext.id,
+	})
+	if nil != result.Err && nil == err {
+		err = errors.Wrap(result.Err, "could not dispose browser context")
+	}
+	return err
+}
+
+/*
+ID implements BrowserContexter.
+*/
+func (browserContext *BrowserContext) ID() target.BrowserContextID {
+	return browserContext.id
+}
+
+/*
+NewTab implements BrowserContexter.
+*/
+func (browserContext *BrowserContext) NewTab(uri string) (*Tab, error) {
+	browserContext.mux.Lock()
+	closed := browserContext.closed
+	browserContext.mux.Unlock()
+	if closed {
+		return nil, errors.New("browser context is closed")
+	}
+
+	tab, err := browserContext.chrome.newContextTab(uri, browserContext.id)
+	if nil != err {
+		return nil, err
+	}
+
+	browserContext.mux.Lock()
+	defer browserContext.mux.Unlock()
+	tab.browserContext = browserContext
+	for _, existing := range browserContext.tabs {
+		if existing == tab {
+			return tab, nil
+		}
+	}
+	browserContext.tabs = append(browserContext.tabs, tab)
+	return tab, nil
+}
+
+/*
+Tabs implements BrowserContexter.
+*/
+func (browserContext *BrowserContext) Tabs() []*Tab {
+	browserContext.mux.Lock()
+	defer browserContext.mux.Unlock()
+	return browserContext.tabs
+}
+
+/*
+removeTab removes a tab from the list of open tabs in the browser context.
+*/
+func (browserContext *BrowserContext) removeTab(tab *Tab) {
+	browserContext.mux.Lock()
+	defer browserContext.mux.Unlock()
+	tabs := make([]*Tab, 0, len(browserContext.tabs))
+	for _, t := range browserContext.tabs {
+		if t != tab {
+			tabs = append(tabs, t)
+		}
+	}
+	browserContext.tabs = tabs
+}
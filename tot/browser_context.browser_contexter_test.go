@@ -0,0 +1,89 @@
+package chrome
+
+import (
+	"os"
+	"testing"
+)
+
+/*
+launchPipeChrome launches the test binary as a fake pipe Chromium process.
+*/
+func launchPipeChrome(t *testing.T) *Chrome {
+	binary, err := os.Executable()
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	t.Setenv(fakePipeChromeEnv, "1")
+	chrome := New(
+		&Flags{
+			"remote-debugging-pipe": nil,
+			"user-data-dir":         t.TempDir(),
+		},
+		binary,
+		t.TempDir(),
+		"",
+		"",
+	)
+	if err := chrome.Launch(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	t.Cleanup(func() { chrome.Close() })
+	return chrome
+}
+
+func TestBrowserContext(t *testing.T) {
+	chrome := launchPipeChrome(t)
+
+	browserContext, err := chrome.NewBrowserContext()
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if "context-1" != browserContext.ID() {
+		t.Errorf("Expected 'context-1', received '%s'", browserContext.ID())
+	}
+	if chrome != browserContext.Chromium() {
+		t.Errorf("Expected the browser context to belong to the Chromium instance")
+	}
+
+	tab1, err := browserContext.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if browserContext != tab1.BrowserContext() {
+		t.Errorf("Expected the tab to be in the browser context")
+	}
+	if _, err := browserContext.NewTab("about:blank"); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if 2 != len(browserContext.Tabs()) {
+		t.Errorf("Expected 2 tabs, received %d", len(browserContext.Tabs()))
+	}
+
+	if _, err := tab1.Close(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if 1 != len(browserContext.Tabs()) {
+		t.Errorf("Expected 1 tab, received %d", len(browserContext.Tabs()))
+	}
+
+	tab, err := chrome.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if nil != tab.BrowserContext() {
+		t.Errorf("Expected the tab to be in the default browser context")
+	}
+
+	if err := browserContext.Close(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if 0 != len(browserContext.Tabs()) {
+		t.Errorf("Expected 0 tabs, received %d", len(browserContext.Tabs()))
+	}
+	if 1 != len(chrome.Tabs()) {
+		t.Errorf("Expected 1 tab, received %d", len(chrome.Tabs()))
+	}
+	if _, err := browserContext.NewTab("about:blank"); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
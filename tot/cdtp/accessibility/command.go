@@ -2,6 +2,7 @@ package accessibility
 
 import (
 	"github.com/mkenney/go-chrome/tot/cdtp/dom"
+	"github.com/mkenney/go-chrome/tot/cdtp/runtime"
 )
 
 /*
@@ -10,8 +11,16 @@ PartialAXTreeParams represents Accessibility.partialAXTree parameters.
 https://chromedevtools.github.io/devtools-protocol/tot/Accessibility/#method-getPartialAXTree
 */
 type PartialAXTreeParams struct {
-	// ID of the node to get the partial accessibility tree for.
-	NodeID dom.NodeID `json:"nodeId"`
+	// Optional. ID of the node to get the partial accessibility tree for.
+	NodeID dom.NodeID `json:"nodeId,omitempty"`
+
+	// Optional. ID of the backend node to get the partial accessibility tree
+	// for.
+	BackendNodeID dom.BackendNodeID `json:"backendNodeId,omitempty"`
+
+	// Optional. JavaScript object ID of the node wrapper to get the partial
+	// accessibility tree for.
+	ObjectID runtime.RemoteObjectID `json:"objectId,omitempty"`
 
 	// Optional. Whether to fetch this nodes ancestors, siblings and children.
 	// Defaults to true.
@@ -31,3 +40,40 @@ type PartialAXTreeResult struct {
 	// Error information related to executing this method
 	Err error `json:"-"`
 }
+
+/*
+QueryAXTreeParams represents Accessibility.queryAXTree parameters.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Accessibility/#method-queryAXTree
+*/
+type QueryAXTreeParams struct {
+	// Optional. Identifier of the node for the root to query.
+	NodeID dom.NodeID `json:"nodeId,omitempty"`
+
+	// Optional. Identifier of the backend node for the root to query.
+	BackendNodeID dom.BackendNodeID `json:"backendNodeId,omitempty"`
+
+	// Optional. JavaScript object id of the node wrapper for the root to
+	// query.
+	ObjectID runtime.RemoteObjectID `json:"objectId,omitempty"`
+
+	// Optional. Find nodes with this computed name.
+	AccessibleName string `json:"accessibleName,omitempty"`
+
+	// Optional. Find nodes with this computed role.
+	Role string `json:"role,omitempty"`
+}
+
+/*
+QueryAXTreeResult represents the result of calls to Accessibility.queryAXTree.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Accessibility/#method-queryAXTree
+*/
+type QueryAXTreeResult struct {
+	// A list of `Accessibility.AXNode` matching the specified attributes,
+	// including nodes that are ignored for accessibility.
+	Nodes []*AXNode `json:"nodes"`
+
+	// Error information related to executing this method
+	Err error `json:"-"`
+}
@@ -92,6 +92,9 @@ type DescribeNodeResult struct {
 	// ID of the new cloned node.
 	NodeID NodeID `json:"nodeId"`
 
+	// Node description.
+	Node *Node `json:"node"`
+
 	// Error information related to executing this method
 	Err error `json:"-"`
 }
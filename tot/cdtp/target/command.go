@@ -28,6 +28,10 @@ https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-attachToTa
 type AttachToTargetParams struct {
 	// Target ID.
 	ID ID `json:"targetId"`
+
+	// Optional. Enables "flat" access to the session via specifying sessionId
+	// attribute in the commands.
+	Flatten bool `json:"flatten,omitempty"`
 }
 
 /*
@@ -145,8 +149,11 @@ DisposeBrowserContextParams represents Target.disposeBrowserContext parameters.
 https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-disposeBrowserContext
 */
 type DisposeBrowserContextParams struct {
-	// Target ID.
-	ID ID `json:"targetId"`
+	// Browser context ID.
+	BrowserContextID BrowserContextID `json:"browserContextId,omitempty"`
+
+	// Deprecated. Use BrowserContextID.
+	ID ID `json:"targetId,omitempty"`
 }
 
 /*
@@ -200,6 +207,9 @@ GetTargetsResult represents the result of calls to Target.getTargets.
 https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-getTargets
 */
 type GetTargetsResult struct {
+	// The list of targets.
+	Infos []*Info `json:"targetInfos"`
+
 	// Error information related to executing this method
 	Err error `json:"-"`
 }
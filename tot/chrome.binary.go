@@ -0,0 +1,219 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*
+BinaryEnv is the name of the environment variable that can be used to specify
+the path to the Chromium binary. If it is set, discovery is skipped and the
+specified binary must be valid.
+*/
+const BinaryEnv = "CHROME_PATH"
+
+/*
+BinaryNames is the list of executable names searched for in PATH, in order of
+preference.
+*/
+var BinaryNames = []string{
+	"google-chrome",
+	"google-chrome-stable",
+	"chromium",
+	"chromium-browser",
+	"chrome-headless-shell",
+	"headless_shell",
+}
+
+/*
+BinaryPaths is the list of well-known Chromium binary locations searched when
+no binary is found in PATH, in order of preference.
+*/
+var BinaryPaths = []string{
+	"/usr/bin/google-chrome",
+	"/usr/bin/google-chrome-stable",
+	"/usr/bin/chromium",
+	"/usr/bin/chromium-browser",
+	"/snap/bin/chromium",
+	"/usr/lib/chromium/chromium",
+	"/usr/lib/chromium-browser/chromium-browser",
+	"/opt/google/chrome/chrome",
+	"/opt/google/chrome/google-chrome",
+	"/opt/chromium.org/chromium/chromium",
+	"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+	"/Applications/Chromium.app/Contents/MacOS/Chromium",
+}
+
+/*
+BinaryVersionTimeout is the maximum amount of time to wait for a binary to
+report its version.
+*/
+var BinaryVersionTimeout = 10 * time.Second
+
+/*
+BinaryInfo describes a validated Chromium binary.
+*/
+type BinaryInfo struct {
+	// Features lists the protocol features supported by this version.
+	Features *BinaryFeatures
+
+	// Major is the major version number, e.g. 66.
+	Major int
+
+	// Path is the path to the binary.
+	Path string
+
+	// Product is the product name reported by the binary, e.g. 'Google
+	// Chrome' or 'Chromium'.
+	Product string
+
+	// Version is the full version number, e.g. '66.0.3359.117'.
+	Version string
+}
+
+/*
+BinaryFeatures reports which protocol and launch features a Chromium version
+supports.
+*/
+type BinaryFeatures struct {
+	// DevToolsActivePort is true if the browser writes the DevToolsActivePort
+	// file to the user data directory, allowing --remote-debugging-port=0.
+	DevToolsActivePort bool
+
+	// FlattenedSessions is true if Target.attachToTarget supports flattened
+	// sessions.
+	FlattenedSessions bool
+
+	// NewHeadless is true if the browser supports --headless=new.
+	NewHeadless bool
+
+	// RemoteDebuggingPipe is true if the browser supports
+	// --remote-debugging-pipe.
+	RemoteDebuggingPipe bool
+}
+
+/*
+String implements Stringer.
+*/
+func (features *BinaryFeatures) String() string {
+	names := []string{}
+	if features.DevToolsActivePort {
+		names = append(names, "DevToolsActivePort")
+	}
+	if features.FlattenedSessions {
+		names = append(names, "FlattenedSessions")
+	}
+	if features.NewHeadless {
+		names = append(names, "NewHeadless")
+	}
+	if features.RemoteDebuggingPipe {
+		names = append(names, "RemoteDebuggingPipe")
+	}
+	return strings.Join(names, ", ")
+}
+
+/*
+featuresForVersion returns the features supported by a major version.
+*/
+func featuresForVersion(major int) *BinaryFeatures {
+	return &BinaryFeatures{
+		DevToolsActivePort:  major >= 65,
+		FlattenedSessions:   major >= 74,
+		NewHeadless:         major >= 112,
+		RemoteDebuggingPipe: major >= 67,
+	}
+}
+
+var binaryVersionRegexp = regexp.MustCompile(`^(.*?)\s*((\d+)\.\d+\.\d+\.\d+)`)
+
+/*
+ParseBinaryVersion parses the output of `chrome --version`, e.g.
+'Google Chrome 66.0.3359.117' or 'Chromium 120.0.6099.224 snap'.
+*/
+func ParseBinaryVersion(output string) (*BinaryInfo, error) {
+	matches := binaryVersionRegexp.FindStringSubmatch(strings.TrimSpace(output))
+	if nil == matches {
+		return nil, fmt.Errorf("unrecognized version string '%s'", strings.TrimSpace(output))
+	}
+	major, err := strconv.Atoi(matches[3])
+	if nil != err {
+		return nil, errors.Wrap(err, fmt.Sprintf("invalid major version '%s'", matches[3]))
+	}
+	return &BinaryInfo{
+		Features: featuresForVersion(major),
+		Major:    major,
+		Product:  matches[1],
+		Version:  matches[2],
+	}, nil
+}
+
+/*
+ValidateBinary runs `<binary> --version` and returns the parsed version
+information, or an error if the binary cannot be executed or does not report a
+Chromium version.
+*/
+func ValidateBinary(binary string) (*BinaryInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), BinaryVersionTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, binary, "--version").Output()
+	if nil != err {
+		return nil, errors.Wrap(err, fmt.Sprintf("'%s --version' failed", binary))
+	}
+
+	info, err := ParseBinaryVersion(string(output))
+	if nil != err {
+		return nil, errors.Wrap(err, fmt.Sprintf("'%s' is not a Chromium binary", binary))
+	}
+	info.Path = binary
+	return info, nil
+}
+
+/*
+FindBinary locates and validates a Chromium binary. If the BinaryEnv
+environment variable is set that binary is used, otherwise the BinaryNames are
+searched for in PATH followed by the well-known BinaryPaths. The first binary
+that reports a valid version is returned.
+*/
+func FindBinary() (*BinaryInfo, error) {
+	if binary := os.Getenv(BinaryEnv); "" != binary {
+		info, err := ValidateBinary(binary)
+		if nil != err {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid %s", BinaryEnv))
+		}
+		return info, nil
+	}
+
+	candidates := []string{}
+	for _, name := range BinaryNames {
+		if path, err := exec.LookPath(name); nil == err {
+			candidates = append(candidates, path)
+		}
+	}
+	for _, path := range BinaryPaths {
+		if stat, err := os.Stat(path); nil == err && !stat.IsDir() {
+			candidates = append(candidates, path)
+		}
+	}
+
+	var lastErr error
+	for _, path := range candidates {
+		info, err := ValidateBinary(path)
+		if nil == err {
+			return info, nil
+		}
+		lastErr = err
+	}
+	if nil != lastErr {
+		return nil, errors.Wrap(lastErr, "no valid chrome binary found")
+	}
+	return nil, fmt.Errorf("no chrome binary found in PATH or well-known locations")
+}
@@ -0,0 +1,188 @@
+package chrome
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+/*
+writeFakeBinary writes an executable script that prints the specified version
+string and returns its path.
+*/
+func writeFakeBinary(t *testing.T, dir, name, version string) string {
+	path := filepath.Join(dir, name)
+	script := "#!/bin/sh\necho '" + version + "'\n"
+	if err := os.WriteFile(path, []byte(script), 0700); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	return path
+}
+
+func TestParseBinaryVersion(t *testing.T) {
+	tests := []struct {
+		output  string
+		product string
+		version string
+		major   int
+	}{
+		{"Google Chrome 66.0.3359.117 \n", "Google Chrome", "66.0.3359.117", 66},
+		{"Chromium 120.0.6099.224 snap", "Chromium", "120.0.6099.224", 120},
+		{"Google Chrome for Testing 121.0.6167.85", "Google Chrome for Testing", "121.0.6167.85", 121},
+		{"Chrome-headless-shell 120.0.6099.109", "Chrome-headless-shell", "120.0.6099.109", 120},
+	}
+	for _, test := range tests {
+		info, err := ParseBinaryVersion(test.output)
+		if nil != err {
+			t.Errorf("Expected nil, received error: '%s'", err.Error())
+			continue
+		}
+		if test.product != info.Product {
+			t.Errorf("Expected '%s', received '%s'", test.product, info.Product)
+		}
+		if test.version != info.Version {
+			t.Errorf("Expected '%s', received '%s'", test.version, info.Version)
+		}
+		if test.major != info.Major {
+			t.Errorf("Expected %d, received %d", test.major, info.Major)
+		}
+	}
+
+	if _, err := ParseBinaryVersion("not a browser"); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
+
+func TestBinaryFeatures(t *testing.T) {
+	features := featuresForVersion(66)
+	if !features.DevToolsActivePort {
+		t.Errorf("Expected DevToolsActivePort to be supported")
+	}
+	if features.FlattenedSessions || features.NewHeadless || features.RemoteDebuggingPipe {
+		t.Errorf("Expected only DevToolsActivePort, received '%s'", features)
+	}
+
+	features = featuresForVersion(120)
+	if "DevToolsActivePort, FlattenedSessions, NewHeadless, RemoteDebuggingPipe" != features.String() {
+		t.Errorf("Expected all features, received '%s'", features)
+	}
+}
+
+func TestFindBinaryEnv(t *testing.T) {
+	dir := t.TempDir()
+	binary := writeFakeBinary(t, dir, "my-chrome", "Chromium 120.0.6099.224 snap")
+	t.Setenv(BinaryEnv, binary)
+
+	info, err := FindBinary()
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if binary != info.Path {
+		t.Errorf("Expected '%s', received '%s'", binary, info.Path)
+	}
+
+	t.Setenv(BinaryEnv, writeFakeBinary(t, dir, "not-chrome", "not a browser"))
+	if _, err := FindBinary(); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
+
+func TestFindBinaryPath(t *testing.T) {
+	dir := t.TempDir()
+	writeFakeBinary(t, dir, "google-chrome", "not a browser")
+	binary := writeFakeBinary(t, dir, "chromium", "Chromium 120.0.6099.224")
+	t.Setenv(BinaryEnv, "")
+	t.Setenv("PATH", dir)
+
+	binaryPaths := BinaryPaths
+	BinaryPaths = []string{}
+	defer func() { BinaryPaths = binaryPaths }()
+
+	info, err := FindBinary()
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if binary != info.Path {
+		t.Errorf("Expected '%s', received '%s'", binary, info.Path)
+	}
+	if 120 != info.Major {
+		t.Errorf("Expected 120, received %d", info.Major)
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	if _, err := FindBinary(); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
+
+func TestChromiumBinaryInfo(t *testing.T) {
+	binary := writeFakeBinary(t, t.TempDir(), "chrome", "Google Chrome 66.0.3359.117")
+	chrome := New(
+		&Flags{},
+		binary,
+		"", //"path/to/stderr",
+		"", //"path/to/stdout",
+		"", //"path/to/workdir",
+	)
+	info, err := chrome.BinaryInfo()
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if "66.0.3359.117" != info.Version {
+		t.Errorf("Expected '66.0.3359.117', received '%s'", info.Version)
+	}
+}
+
+func TestChromiumDiscoverBinary(t *testing.T) {
+	dir := t.TempDir()
+	binary := writeFakeBinary(t, dir, "chromium", "Chromium 120.0.6099.224")
+	t.Setenv(BinaryEnv, "")
+	t.Setenv("PATH", dir)
+
+	chrome := New(
+		&Flags{},
+		"", //"path/to/chrome",
+		"", //"path/to/stderr",
+		"", //"path/to/stdout",
+		"", //"path/to/workdir",
+	)
+	if "/usr/bin/google-chrome" != chrome.Binary() {
+		t.Errorf("Expected '/usr/bin/google-chrome', received '%s'", chrome.Binary())
+	}
+	if nil != chrome.binaryInfo {
+		t.Errorf("Expected no binary discovery, received %v", chrome.binaryInfo)
+	}
+
+	if err := chrome.discoverBinary(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if binary != chrome.Binary() {
+		t.Errorf("Expected '%s', received '%s'", binary, chrome.Binary())
+	}
+	if nil == chrome.binaryInfo || 120 != chrome.binaryInfo.Major {
+		t.Errorf("Expected the discovered binary info, received %v", chrome.binaryInfo)
+	}
+}
+
+func TestChromiumLaunchInvalidBinaryEnv(t *testing.T) {
+	t.Setenv(BinaryEnv, filepath.Join(t.TempDir(), "missing-chrome"))
+
+	chrome := New(
+		&Flags{},
+		"", //"path/to/chrome",
+		"", //"path/to/stderr",
+		"", //"path/to/stdout",
+		"", //"path/to/workdir",
+	)
+	err := chrome.Launch()
+	if nil == err {
+		t.Fatalf("Expected error, received nil")
+	}
+	if !strings.Contains(err.Error(), BinaryEnv) {
+		t.Errorf("Expected a %s error, received '%s'", BinaryEnv, err.Error())
+	}
+	if "/usr/bin/google-chrome" != chrome.Binary() {
+		t.Errorf("Expected the binary to be unchanged, received '%s'", chrome.Binary())
+	}
+}
@@ -3,13 +3,16 @@ package chrome
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
+	"github.com/mkenney/go-chrome/tot/socket"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
@@ -25,14 +28,51 @@ func New(
 	stderr string,
 ) *Chrome {
 	return &Chrome{
-		flags:   flags,
-		binary:  binary,
-		stderr:  stderr,
-		stdout:  stdout,
-		workdir: workdir,
+		flags:            flags,
+		binary:           binary,
+		destroyedTargets: make(map[string]bool),
+		env:              make(map[string]string),
+		outputMux:        &sync.Mutex{},
+		processMux:       &sync.Mutex{},
+		processOptions:   &ProcessOptions{},
+		socketMux:        &sync.Mutex{},
+		stderr:           stderr,
+		stdout:           stdout,
+		tabMux:           &sync.Mutex{},
+		versionMux:       &sync.Mutex{},
+		workdir:          workdir,
 	}
 }
 
+/*
+Connect returns a pointer to a Chromium instance attached to an already running
+Chromium process listening on addr:port. The currently open targets are
+enumerated using the /json/list endpoint and each is wrapped in a Tab with a
+live socket connection.
+*/
+func Connect(addr string, port int) (*Chrome, error) {
+	chrome := New(
+		&Flags{
+			"addr": addr,
+			"port": port,
+		},
+		"",
+		"",
+		"",
+		"",
+	)
+
+	if _, err := chrome.Version(); nil != err {
+		return nil, errors.Wrap(err, fmt.Sprintf("could not connect to chrome at %s:%d", addr, port))
+	}
+
+	if err := chrome.RefreshTabs(); nil != err {
+		return nil, errors.Wrap(err, "could not enumerate open tabs")
+	}
+
+	return chrome, nil
+}
+
 /*
 Chrome implements Chromium.
 */
@@ -40,10 +80,29 @@ type Chrome struct {
 	// flags stores CLI arguments for the Chromium binary.
 	flags ChromiumFlags
 
-	// Optional. binary is the path to the Chromium binary. Defaults to
-	// '/usr/bin/google-chrome'.
+	// destroyedTargets records the IDs of targets that have recently been
+	// reported as destroyed.
+	destroyedTargets map[string]bool
+
+	// Optional. binary is the path to the Chromium binary. Launch defaults it
+	// to the binary located by FindBinary(), or '/usr/bin/google-chrome'.
 	binary string
 
+	// binaryInfo contains the validated binary version information.
+	binaryInfo *BinaryInfo
+
+	// browserSocket is the browser-level websocket connection, if any.
+	browserSocket *socket.Socket
+
+	// socketMux protects the browser socket.
+	socketMux *sync.Mutex
+
+	// env contains environment variables set for the Chromium process.
+	env map[string]string
+
+	// envMode selects how the process environment is built.
+	envMode EnvMode
+
 	// Optional. port is the port number the developer tools endpoints will
 	// listen on. Defaults to 9222.
 	//port int
@@ -51,9 +110,21 @@ type Chrome struct {
 	// tabs is a list of the currently open tabs.
 	tabs []*Tab
 
+	// tabHandlers is a list of callbacks for tab lifecycle events.
+	tabHandlers []func(event *TabEvent)
+
+	// tabMux protects the tabs list and tab event state.
+	tabMux *sync.Mutex
+
 	// version contains Chromium version information.
 	version *Version
 
+	// versionMux protects the cached version information.
+	versionMux *sync.Mutex
+
+	// watchingTabs is true once target discovery has been enabled.
+	watchingTabs bool
+
 	// Optional. workdir is the path to the Chromium working directory. Defaults
 	// to '/tmp/headless-chrome'.
 	workdir string
@@ -74,8 +145,62 @@ type Chrome struct {
 	// output.
 	stdOUTFile *os.File
 
+	// listening receives the browser endpoint URL announced on STDERR.
+	listening chan *url.URL
+
+	// configuredPort is the "port" flag value that was replaced by the
+	// debugging port chosen by Chromium, nil if the flag was not set.
+	configuredPort interface{}
+
+	// portResolved is true while the "port" flag holds the debugging port
+	// chosen by Chromium.
+	portResolved bool
+
+	// profileDir is the temporary profile directory created by Launch, if any.
+	profileDir string
+
+	// Optional. profileTemplate is a profile directory copied into new
+	// temporary profiles.
+	profileTemplate string
+
 	// process is a pointer to the os.Process struct containing the process PID.
 	process *os.Process
+
+	// processOptions contains the attributes used to start the process.
+	processOptions *ProcessOptions
+
+	// processMux protects the closing flag and the process exit state.
+	processMux *sync.Mutex
+
+	// closing is true once Close has been called.
+	closing bool
+
+	// exited is closed when the Chromium process exits.
+	exited chan struct{}
+
+	// exitState is the exit status of the Chromium process once it exits.
+	exitState *os.ProcessState
+
+	// outputDone is closed when the output watchers return.
+	outputDone chan struct{}
+
+	// outputHandlers is a list of callbacks for output lines.
+	outputHandlers []func(line *OutputLine)
+
+	// outputMux protects the output handlers, writers and startup state.
+	outputMux *sync.Mutex
+
+	// starting is true while Launch waits for Chromium to start.
+	starting bool
+
+	// startupMessages contains the errors Chromium logged during startup.
+	startupMessages []string
+
+	// Optional. stderrWriter receives a copy of the STDERR output.
+	stderrWriter io.Writer
+
+	// Optional. stdoutWriter receives a copy of the STDOUT output.
+	stdoutWriter io.Writer
 }
 
 /*
@@ -101,34 +226,149 @@ func (chrome *Chrome) Flags() ChromiumFlags {
 /*
 Binary implements Chromium.
 
-Default value is '/usr/bin/google-chrome' for use with the mkenney/chromium-headless
-Docker image.
+If no binary was specified the binary located by FindBinary() is used once
+Launch has been called. Otherwise the default value is '/usr/bin/google-chrome'
+for use with the mkenney/chromium-headless Docker image.
 */
 func (chrome *Chrome) Binary() string {
 	if "" == chrome.binary {
-		chrome.binary = "/usr/bin/google-chrome"
+		return "/usr/bin/google-chrome"
 	}
 	return chrome.binary
 }
 
+/*
+discoverBinary locates a Chromium binary with FindBinary() if none was
+specified. An invalid BinaryEnv binary is an error, otherwise the default binary
+is kept if none can be found.
+*/
+func (chrome *Chrome) discoverBinary() error {
+	if "" != chrome.binary {
+		return nil
+	}
+	info, err := FindBinary()
+	if nil != err {
+		if "" != os.Getenv(BinaryEnv) {
+			return err
+		}
+		log.Debugf("chrome binary discovery failed: %s", err)
+		return nil
+	}
+	chrome.binary = info.Path
+	chrome.binaryInfo = info
+	return nil
+}
+
+/*
+BinaryInfo implements Chromium.
+*/
+func (chrome *Chrome) BinaryInfo() (*BinaryInfo, error) {
+	if nil == chrome.binaryInfo {
+		info, err := ValidateBinary(chrome.Binary())
+		if nil != err {
+			return nil, err
+		}
+		chrome.binaryInfo = info
+	}
+	return chrome.binaryInfo, nil
+}
+
+/*
+BrowserSocket implements Chromium.
+
+The connection is made to the webSocketDebuggerUrl reported by /json/version
+and is reused by subsequent calls.
+*/
+func (chrome *Chrome) BrowserSocket() (*socket.Socket, error) {
+	if browserSocket := chrome.currentBrowserSocket(); nil != browserSocket {
+		return browserSocket, nil
+	}
+
+	version, err := chrome.Version()
+	if nil != err {
+		return nil, errors.Wrap(err, "browser websocket URL unavailable")
+	}
+	if "" == version.WebSocketDebuggerURL {
+		return nil, fmt.Errorf("browser websocket URL unavailable: /json/version did not report webSocketDebuggerUrl")
+	}
+
+	socketURL, err := url.Parse(version.WebSocketDebuggerURL)
+	if nil != err {
+		return nil, errors.Wrap(err, fmt.Sprintf("invalid websocket URL '%s'", version.WebSocketDebuggerURL))
+	}
+
+	// Another caller may have connected while the version was retrieved.
+	chrome.socketMux.Lock()
+	defer chrome.socketMux.Unlock()
+	if nil == chrome.browserSocket {
+		chrome.browserSocket = socket.New(socketURL)
+	}
+	return chrome.browserSocket, nil
+}
+
+/*
+currentBrowserSocket returns the browser socket if it is connected.
+*/
+func (chrome *Chrome) currentBrowserSocket() *socket.Socket {
+	chrome.socketMux.Lock()
+	defer chrome.socketMux.Unlock()
+	return chrome.browserSocket
+}
+
 /*
 Close implements Chromium.
+
+All tab sockets are stopped and a launched Chromium process is shut down, see
+CloseGracePeriod and TermGracePeriod. Output files and the temporary profile
+directory are then cleaned up and the endpoint state is reset, so the instance
+can be launched again.
 */
 func (chrome *Chrome) Close() error {
-	if chrome.process != nil {
-		if err := chrome.process.Signal(os.Interrupt); err != nil {
-			return errors.Wrap(err, "chrome process interrupt failed")
+	chrome.processMux.Lock()
+	chrome.closing = true
+	chrome.processMux.Unlock()
+
+	for _, tab := range chrome.Tabs() {
+		if nil != tab.Socket() {
+			tab.Socket().Stop()
 		}
-		ps, err := chrome.process.Wait()
-		if err != nil {
-			return errors.Wrap(err, "error waiting for process exit, result unknown")
+	}
+	chrome.tabMux.Lock()
+	chrome.tabs = nil
+	chrome.tabMux.Unlock()
+
+	err := chrome.stopProcess()
+
+	chrome.socketMux.Lock()
+	if nil != chrome.browserSocket {
+		chrome.browserSocket.Stop()
+		chrome.browserSocket = nil
+	}
+	chrome.socketMux.Unlock()
+	chrome.resetEndpoint()
+	chrome.closeOutput()
+	chrome.removeProfile()
+	return err
+}
+
+/*
+closeOutput closes the STDOUT and STDERR capture files unless they are the
+system STDOUT and STDERR.
+*/
+func (chrome *Chrome) closeOutput() {
+	// Let the output watchers finish writing before closing the files.
+	if nil != chrome.outputDone {
+		select {
+		case <-chrome.outputDone:
+		case <-time.After(time.Second):
 		}
-		log.Infof("Chromium exited: %s", ps.String())
 	}
-	if chrome.stdOUTFile != nil {
+	if nil != chrome.stdOUTFile && os.Stdout != chrome.stdOUTFile {
 		chrome.stdOUTFile.Close()
 	}
-	return nil
+	if nil != chrome.stdERRFile && os.Stderr != chrome.stdERRFile {
+		chrome.stdERRFile.Close()
+	}
 }
 
 /*
@@ -159,6 +399,8 @@ func (chrome *Chrome) DebuggingPort() int {
 GetTab implements Chromium.
 */
 func (chrome *Chrome) GetTab(tabID string) (tab Tabber, err error) {
+	chrome.tabMux.Lock()
+	defer chrome.tabMux.Unlock()
 	for _, tab = range chrome.tabs {
 		if tab.Data().ID == tabID {
 			return tab, nil
@@ -171,6 +413,14 @@ func (chrome *Chrome) GetTab(tabID string) (tab Tabber, err error) {
 /*
 Launch implements Chromium.
 
+Launch returns as soon as the developer tools endpoint responds, or with an error
+after LaunchTimeout. If the remote-debugging-pipe flag is set no debugging port
+is opened and the browser socket communicates over pipes instead, see
+PipeMode(). If remote-debugging-port is set to 0 Chromium chooses a free
+port, which is read from the DevToolsActivePort file in the user data directory
+or from STDERR and stored in the "port" flag. If Chromium fails to start, the
+errors it wrote to STDERR are included in the returned error.
+
 This implementation makes it's best effort to set a few sane default values if
 they aren't included in the Flags definition:
 
@@ -178,21 +428,42 @@ they aren't included in the Flags definition:
 	remote-debugging-address = "0.0.0.0"
 	remote-debugging-port = 9222
 	port = 9222
-	user-data-dir = os.TempDir() + chrome.Workdir()
+	user-data-dir = a new temporary profile directory in chrome.Workdir()
 	chrome.workdir = "headless-chrome"
 	chrome.output = "/dev/stdout"
 */
 func (chrome *Chrome) Launch() error {
 	var err error
 
-	// Default values for required parameters
-	chrome.Address()
-	chrome.DebuggingAddress()
-	chrome.DebuggingPort()
-	chrome.Port()
-	if !chrome.Flags().Has("user-data-dir") {
-		chrome.Flags().Set("user-data-dir", os.TempDir())
+	// A relaunched process that exits is unexpected until Close is called
+	// again.
+	chrome.processMux.Lock()
+	chrome.closing = false
+	chrome.processMux.Unlock()
+
+	// Default values for required parameters. No debugging port is opened
+	// when using the pipe transport.
+	if !chrome.PipeMode() {
+		chrome.Address()
+		chrome.DebuggingAddress()
+		chrome.DebuggingPort()
+		chrome.Port()
+	}
+
+	if err := chrome.discoverBinary(); nil != err {
+		return errors.Wrap(err, "chrome binary discovery failed")
+	}
+	binaryInfo, err := chrome.BinaryInfo()
+	if nil != err {
+		return errors.Wrap(err, "chrome binary validation failed")
 	}
+	log.Infof(
+		"Found %s %s at %s, supported features: %s",
+		binaryInfo.Product,
+		binaryInfo.Version,
+		binaryInfo.Path,
+		binaryInfo.Features,
+	)
 
 	if err := os.MkdirAll(chrome.Workdir(), 0700); err != nil {
 		return errors.Wrap(err, fmt.Sprintf("cannot create working directory '%s'", chrome.Workdir()))
@@ -224,32 +495,93 @@ func (chrome *Chrome) Launch() error {
 		}
 	}
 
-	log.Infof("Starting process: %s %s", chrome.Binary(), chrome.Flags())
+	// Each instance gets its own temporary profile, removed by Close(),
+	// unless a profile directory was specified.
+	if !chrome.Flags().Has("user-data-dir") {
+		if err := chrome.createProfile(); nil != err {
+			chrome.closeOutput()
+			return err
+		}
+	}
+
+	if !chrome.PipeMode() && 0 == chrome.DebuggingPort() {
+		chrome.removeDevToolsActivePort()
+	}
+
+	output, err := newOutputPipes()
+	if nil != err {
+		chrome.closeOutput()
+		chrome.removeProfile()
+		return err
+	}
+	chrome.outputMux.Lock()
+	chrome.listening = make(chan *url.URL, 1)
+	chrome.outputMux.Unlock()
+
 	var procAttributes os.ProcAttr
 	procAttributes.Dir = chrome.Workdir()
-	procAttributes.Files = []*os.File{nil, chrome.stdOUTFile, chrome.stdERRFile}
+	procAttributes.Env = chrome.environment()
+	procAttributes.Files = []*os.File{nil, output.stdoutWriter, output.stderrWriter}
+	procAttributes.Sys = processAttributes(chrome.ProcessOptions())
+
+	var pipe *pipeFiles
+	if chrome.PipeMode() {
+		if pipe, err = newPipeFiles(); nil != err {
+			output.close()
+			chrome.closeOutput()
+			chrome.removeProfile()
+			return err
+		}
+		procAttributes.Files = append(procAttributes.Files, pipe.childFiles()...)
+	}
+
+	chrome.setStarting(true)
+	defer chrome.setStarting(false)
+
+	log.Infof("Starting process: %s %s", chrome.Binary(), chrome.Flags())
 	chrome.process, err = os.StartProcess(
 		chrome.Binary(),
-		chrome.Flags().List(),
+		append([]string{chrome.Binary()}, chrome.Flags().List()...),
 		&procAttributes,
 	)
+	output.closeWriters()
+	if nil != pipe {
+		pipe.closeChildFiles()
+	}
 	if nil != err {
-		chrome.stdOUTFile.Close()
+		output.close()
+		if nil != pipe {
+			pipe.close()
+		}
+		chrome.closeOutput()
+		chrome.removeProfile()
 		return errors.Wrap(err, "error starting chrome")
 	}
+	exited := make(chan struct{})
+	chrome.processMux.Lock()
+	chrome.exited = exited
+	chrome.exitState = nil
+	chrome.processMux.Unlock()
+	go chrome.watchProcess(chrome.process, exited)
+	chrome.outputDone = chrome.watchOutputPipes(output)
+
+	if nil != pipe {
+		chrome.socketMux.Lock()
+		chrome.browserSocket = socket.NewPipe(pipe.responses, pipe.commands)
+		chrome.socketMux.Unlock()
+	}
 
-	// Wait up to 10 seconds for Chromium to start
-	for i := 0; i < 10; i++ {
-		time.Sleep(time.Second)
-		if _, err = chrome.Version(); nil == err {
-			break
-		}
+	// Wait for the developer tools endpoint to become available
+	if chrome.PipeMode() {
+		err = chrome.waitForPipe()
+	} else {
+		err = chrome.waitForEndpoint()
 	}
-	if err != nil {
+	if nil != err {
 		log.Errorf("Chromium took too long to start")
-		log.Debugf(err.Error())
+		log.Debug(err.Error())
 		chrome.Close()
-		return errors.Wrap(err, "chrome took too long to start")
+		return chrome.startupError(errors.Wrap(err, "chrome took too long to start"))
 	}
 
 	return nil
@@ -321,6 +653,8 @@ func (chrome *Chrome) STDOUT() string {
 Tabs implements Chromium.
 */
 func (chrome *Chrome) Tabs() []*Tab {
+	chrome.tabMux.Lock()
+	defer chrome.tabMux.Unlock()
 	return chrome.tabs
 }
 
@@ -328,18 +662,37 @@ func (chrome *Chrome) Tabs() []*Tab {
 Version implements Chromium.
 */
 func (chrome *Chrome) Version() (*Version, error) {
+	chrome.versionMux.Lock()
+	defer chrome.versionMux.Unlock()
 	if nil == chrome.version {
-		if _, err := chrome.Query(
-			"/json/version",
-			url.Values{},
-			&chrome.version,
-		); err != nil {
-			return nil, errors.Wrap(err, "version query failed")
+		version, err := chrome.queryVersion()
+		if nil != err {
+			return nil, err
 		}
+		chrome.version = version
 	}
 	return chrome.version, nil
 }
 
+/*
+queryVersion retrieves the browser version information without using the
+cached value.
+*/
+func (chrome *Chrome) queryVersion() (*Version, error) {
+	if chrome.PipeMode() {
+		return chrome.pipeVersion()
+	}
+	version := &Version{}
+	if _, err := chrome.Query(
+		"/json/version",
+		url.Values{},
+		version,
+	); err != nil {
+		return nil, errors.Wrap(err, "version query failed")
+	}
+	return version, nil
+}
+
 /*
 Workdir implements Chromium.
 
@@ -13,6 +13,13 @@ Flags contains CLI arguments to the Chromium executable.
 */
 type Flags map[string]interface{}
 
+/*
+Delete implements ChromiumFlags
+*/
+func (flags Flags) Delete(arg string) {
+	delete(flags, arg)
+}
+
 /*
 Get implements ChromiumFlags
 */
@@ -55,6 +62,8 @@ func (flags Flags) List() []string {
 			arg = fmt.Sprintf("--%s=%d", arg, val.(int))
 		case string:
 			arg = fmt.Sprintf("--%s=%s", arg, val.(string))
+		case []string:
+			arg = fmt.Sprintf("--%s=%s", arg, strings.Join(val.([]string), ","))
 		default:
 			arg = fmt.Sprintf("--%s", arg)
 		}
@@ -80,6 +89,8 @@ func (flags Flags) Set(arg string, value interface{}) (err error) {
 			flags[arg] = value
 		case string:
 			flags[arg] = value
+		case []string:
+			flags[arg] = value
 		default:
 			return fmt.Errorf("Invalid data type '%T' for argument %s: %+v", value, arg, value)
 		}
@@ -4,6 +4,18 @@ import (
 	"testing"
 )
 
+func TestChromiumFlagsDelete(t *testing.T) {
+	flags := &Flags{
+		"test-arg": "value",
+	}
+
+	flags.Delete("test-arg")
+	if flags.Has("test-arg") {
+		t.Errorf("Expected false, received true")
+	}
+	flags.Delete("test-arg")
+}
+
 func TestChromiumFlagsGet(t *testing.T) {
 	flags := &Flags{}
 
@@ -104,3 +116,13 @@ func TestChromiumFlagsString(t *testing.T) {
 		t.Errorf("Expected '--test-1 --test-2=string --test-3=1', received '%s'", args)
 	}
 }
+
+func TestChromiumFlagsStringList(t *testing.T) {
+	flags := &Flags{}
+	if err := flags.Set("disable-features", []string{"Translate", "MediaRouter"}); nil != err {
+		t.Errorf("Expected nil, received error '%s'", err.Error())
+	}
+	if "--disable-features=Translate,MediaRouter" != flags.String() {
+		t.Errorf("Expected '--disable-features=Translate,MediaRouter', received '%s'", flags.String())
+	}
+}
@@ -1,12 +1,59 @@
 package chrome
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 )
 
+/*
+newTestServer returns a test server that responds to developer tools endpoint
+requests with the JSON in routes, keyed by path, and a Chrome instance
+configured to query it.
+*/
+func newTestServer(routes map[string]*string) (*httptest.Server, *Chrome) {
+	mux := &sync.Mutex{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.Lock()
+		defer mux.Unlock()
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, *body)
+	}))
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+	chrome := New(
+		&Flags{
+			"addr": serverURL.Hostname(),
+			"port": port,
+		},
+		"", //"path/to/chrome",
+		"", //"path/to/stderr",
+		"", //"path/to/stdout",
+		"", //"path/to/workdir",
+	)
+	return server, chrome
+}
+
+/*
+newVersionServer returns a test server that responds to /json/version requests
+and a Chrome instance configured to query it.
+*/
+func newVersionServer(webSocketDebuggerURL string) (*httptest.Server, *Chrome) {
+	version := fmt.Sprintf(`{"Browser": "HeadlessChrome/66.0.3359.117", "webSocketDebuggerUrl": "%s"}`, webSocketDebuggerURL)
+	return newTestServer(map[string]*string{"/json/version": &version})
+}
+
 func TestChromiumNew(t *testing.T) {
 	chrome := New(
 		&Flags{},
@@ -41,6 +88,53 @@ func TestChromiumNew(t *testing.T) {
 	}
 }
 
+func TestChromiumBrowserSocket(t *testing.T) {
+	server, chrome := newVersionServer("ws://localhost:9222/devtools/browser/browser-id")
+	defer server.Close()
+	defer chrome.Close()
+
+	browserSocket, err := chrome.BrowserSocket()
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if "ws://localhost:9222/devtools/browser/browser-id" != browserSocket.URL().String() {
+		t.Errorf("Expected 'ws://localhost:9222/devtools/browser/browser-id', received '%s'", browserSocket.URL().String())
+	}
+	if sock, _ := chrome.BrowserSocket(); sock != browserSocket {
+		t.Errorf("Expected the browser socket to be reused")
+	}
+}
+
+func TestChromiumBrowserSocketConcurrent(t *testing.T) {
+	server, chrome := newVersionServer("ws://localhost:9222/devtools/browser/browser-id")
+	defer server.Close()
+	defer chrome.Close()
+
+	sockets := make(chan interface{}, 10)
+	for a := 0; a < 10; a++ {
+		go func() {
+			browserSocket, _ := chrome.BrowserSocket()
+			sockets <- browserSocket
+		}()
+	}
+	first := <-sockets
+	for a := 1; a < 10; a++ {
+		if sock := <-sockets; sock != first {
+			t.Errorf("Expected a single browser socket, received %v and %v", first, sock)
+		}
+	}
+}
+
+func TestChromiumBrowserSocketError(t *testing.T) {
+	server, chrome := newVersionServer("")
+	defer server.Close()
+
+	_, err := chrome.BrowserSocket()
+	if nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
+
 func TestChromiumClose(t *testing.T) {
 	chrome := New(
 		&Flags{},
@@ -52,6 +146,102 @@ func TestChromiumClose(t *testing.T) {
 	chrome.Close()
 }
 
+func TestChromiumConnect(t *testing.T) {
+	version := `{"Browser": "HeadlessChrome/66.0.3359.117"}`
+	list := `[
+		{"id": "tab-1", "type": "page", "url": "about:blank", "webSocketDebuggerUrl": "ws://127.0.0.1:1/devtools/page/tab-1"},
+		{"id": "tab-2", "type": "page", "url": "https://example.com/", "webSocketDebuggerUrl": "ws://127.0.0.1:1/devtools/page/tab-2"},
+		{"id": "tab-3", "type": "page", "url": "https://example.com/attached"}
+	]`
+	server, _ := newTestServer(map[string]*string{
+		"/json/version": &version,
+		"/json/list":    &list,
+	})
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+
+	chrome, err := Connect(serverURL.Hostname(), port)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if 2 != len(chrome.Tabs()) {
+		t.Fatalf("Expected 2 tabs, received %d", len(chrome.Tabs()))
+	}
+	tab, err := chrome.GetTab("tab-2")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if "https://example.com/" != tab.URL().String() {
+		t.Errorf("Expected 'https://example.com/', received '%s'", tab.URL().String())
+	}
+	if "ws://127.0.0.1:1/devtools/page/tab-2" != tab.Socket().URL().String() {
+		t.Errorf("Expected 'ws://127.0.0.1:1/devtools/page/tab-2', received '%s'", tab.Socket().URL().String())
+	}
+	if _, err := chrome.GetTab("tab-3"); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
+
+func TestChromiumConnectError(t *testing.T) {
+	_, err := Connect("devnul", 9222)
+	if nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
+
+func TestChromiumRefreshTabs(t *testing.T) {
+	list := `[
+		{"id": "tab-1", "type": "page", "title": "one", "webSocketDebuggerUrl": "ws://127.0.0.1:1/devtools/page/tab-1"},
+		{"id": "tab-2", "type": "page", "title": "two", "webSocketDebuggerUrl": "ws://127.0.0.1:1/devtools/page/tab-2"},
+		{"id": "worker-1", "type": "service_worker", "title": "worker", "webSocketDebuggerUrl": "ws://127.0.0.1:1/devtools/page/worker-1"}
+	]`
+	closed := "Target is closing"
+	server, chrome := newTestServer(map[string]*string{
+		"/json/list":        &list,
+		"/json/close/tab-1": &closed,
+	})
+	defer server.Close()
+
+	if err := chrome.RefreshTabs(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	tab1, _ := chrome.GetTab("tab-1")
+	if 2 != len(chrome.Tabs()) {
+		t.Fatalf("Expected 2 tabs, received %d", len(chrome.Tabs()))
+	}
+	if _, err := chrome.GetTab("worker-1"); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+	data := tab1.Data()
+
+	list = `[
+		{"id": "tab-1", "type": "page", "title": "updated", "webSocketDebuggerUrl": "ws://127.0.0.1:1/devtools/page/tab-1"}
+	]`
+	if err := chrome.RefreshTabs(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if 1 != len(chrome.Tabs()) {
+		t.Fatalf("Expected 1 tab, received %d", len(chrome.Tabs()))
+	}
+	if tab, _ := chrome.GetTab("tab-1"); tab != tab1 {
+		t.Errorf("Expected the existing tab to be kept")
+	}
+	if "updated" != tab1.Data().Title {
+		t.Errorf("Expected 'updated', received '%s'", tab1.Data().Title)
+	}
+	if "one" != data.Title {
+		t.Errorf("Expected the previous data to be unchanged, received '%s'", data.Title)
+	}
+
+	if _, err := tab1.Close(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if 0 != len(chrome.Tabs()) {
+		t.Errorf("Expected 0 tabs, received %d", len(chrome.Tabs()))
+	}
+}
+
 func TestChromiumGetTab(t *testing.T) {
 	chrome := New(
 		&Flags{},
@@ -139,3 +329,28 @@ func TestChromiumVersion(t *testing.T) {
 		t.Errorf("Expected nil, received %v", version)
 	}
 }
+
+func TestChromiumLaunchArguments(t *testing.T) {
+	chrome, _ := newFakeChrome(t, `printf '%%s\n' "$@" > "$dir/arguments"; printf '%d\n/devtools/browser/browser-id\n' > "$dir/DevToolsActivePort"`)
+	chrome.Flags().Set("headless", nil)
+	if err := chrome.Launch(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	defer chrome.Close()
+
+	content, err := os.ReadFile(filepath.Join(chrome.userDataDir(), "arguments"))
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	arguments := "\n" + string(content)
+	for _, flag := range []string{
+		fmt.Sprintf("--addr=%s", chrome.Address()),
+		"--headless",
+		"--remote-debugging-port=0",
+		fmt.Sprintf("--user-data-dir=%s", chrome.userDataDir()),
+	} {
+		if !strings.Contains(arguments, "\n"+flag+"\n") {
+			t.Errorf("Expected the %s flag, received '%s'", flag, content)
+		}
+	}
+}
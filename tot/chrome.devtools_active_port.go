@@ -0,0 +1,183 @@
+package chrome
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+/*
+LaunchTimeout is the maximum amount of time Launch waits for the developer
+tools endpoint to become available.
+*/
+var LaunchTimeout = 10 * time.Second
+
+/*
+endpointPollInterval is the amount of time between endpoint readiness checks.
+*/
+var endpointPollInterval = 50 * time.Millisecond
+
+/*
+devToolsListeningPrefix prefixes the browser endpoint URL that Chromium writes
+to STDERR once the remote debugging server is listening.
+*/
+const devToolsListeningPrefix = "DevTools listening on "
+
+/*
+readDevToolsActivePort reads the DevToolsActivePort file Chromium writes to the
+user data directory. The first line contains the port number and the second
+line the path of the browser websocket endpoint.
+*/
+func readDevToolsActivePort(userDataDir string) (port int, path string, err error) {
+	content, err := ioutil.ReadFile(filepath.Join(userDataDir, "DevToolsActivePort"))
+	if nil != err {
+		return 0, "", err
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	port, err = strconv.Atoi(strings.TrimSpace(lines[0]))
+	if nil != err || port <= 0 {
+		return 0, "", fmt.Errorf("invalid DevToolsActivePort content '%s'", string(content))
+	}
+	if len(lines) > 1 {
+		path = strings.TrimSpace(lines[1])
+	}
+	return port, path, nil
+}
+
+/*
+parseDevToolsListening returns the browser websocket endpoint URL from a
+"DevTools listening on ws://..." STDERR line.
+*/
+func parseDevToolsListening(line string) (*url.URL, bool) {
+	index := strings.Index(line, devToolsListeningPrefix)
+	if -1 == index {
+		return nil, false
+	}
+	endpoint, err := url.Parse(strings.TrimSpace(line[index+len(devToolsListeningPrefix):]))
+	if nil != err || "" == endpoint.Port() {
+		return nil, false
+	}
+	return endpoint, true
+}
+
+/*
+userDataDir returns the value of the user-data-dir flag.
+*/
+func (chrome *Chrome) userDataDir() string {
+	value, err := chrome.Flags().Get("user-data-dir")
+	if nil != err {
+		return ""
+	}
+	dir, _ := value.(string)
+	return dir
+}
+
+/*
+waitForEndpoint waits for the developer tools endpoint of a launched Chromium
+process to respond and returns as soon as it does. If the remote debugging port
+is 0 the port chosen by Chromium is read from the DevToolsActivePort file or
+from STDERR and stored in the "port" flag.
+*/
+func (chrome *Chrome) waitForEndpoint() error {
+	err := errors.New("debugging port unknown")
+	portKnown := 0 != chrome.DebuggingPort()
+	deadline := time.Now().Add(LaunchTimeout)
+
+	chrome.outputMux.Lock()
+	listening := chrome.listening
+	chrome.outputMux.Unlock()
+
+	for {
+		if !portKnown {
+			select {
+			case endpoint := <-listening:
+				if port, convErr := strconv.Atoi(endpoint.Port()); nil == convErr {
+					chrome.setResolvedPort(port)
+					portKnown = true
+				}
+			default:
+				if port, _, readErr := readDevToolsActivePort(chrome.userDataDir()); nil == readErr {
+					chrome.setResolvedPort(port)
+					portKnown = true
+				} else {
+					err = errors.Wrap(readErr, "debugging port unknown")
+				}
+			}
+			if portKnown {
+				log.Infof("Chromium is listening on port %d", chrome.Port())
+			}
+		}
+
+		// The endpoint of this process must respond, so a version cached for
+		// a previous process is not used.
+		if portKnown {
+			if _, err = chrome.queryVersion(); nil == err {
+				return nil
+			}
+		}
+
+		if chrome.hasExited() {
+			return errors.Wrap(err, fmt.Sprintf("chrome exited during startup: %s", chrome.ExitState()))
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(endpointPollInterval)
+	}
+}
+
+/*
+setResolvedPort stores the debugging port chosen by Chromium in the "port" flag.
+The configured value is restored by resetEndpoint().
+*/
+func (chrome *Chrome) setResolvedPort(port int) {
+	if !chrome.portResolved {
+		chrome.configuredPort, _ = chrome.Flags().Get("port")
+		chrome.portResolved = true
+	}
+	chrome.Flags().Set("port", port)
+}
+
+/*
+resetEndpoint forgets the endpoint state of a closed Chromium process so that a
+relaunched process is not mistaken for it: the cached version, a debugging port
+chosen by Chromium and the announced endpoint.
+*/
+func (chrome *Chrome) resetEndpoint() {
+	chrome.versionMux.Lock()
+	chrome.version = nil
+	chrome.versionMux.Unlock()
+
+	if chrome.portResolved {
+		if nil == chrome.configuredPort {
+			chrome.Flags().Delete("port")
+		} else {
+			chrome.Flags().Set("port", chrome.configuredPort)
+		}
+		chrome.configuredPort = nil
+		chrome.portResolved = false
+	}
+
+	chrome.outputMux.Lock()
+	chrome.listening = nil
+	chrome.outputMux.Unlock()
+}
+
+/*
+removeDevToolsActivePort removes a DevToolsActivePort file left behind by a
+previous Chromium process so that it isn't mistaken for the current one.
+*/
+func (chrome *Chrome) removeDevToolsActivePort() {
+	if dir := chrome.userDataDir(); "" != dir {
+		os.Remove(filepath.Join(dir, "DevToolsActivePort"))
+	}
+}
@@ -0,0 +1,151 @@
+package chrome
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+/*
+writeFakeChrome writes an executable script that emulates a Chromium binary
+started with --remote-debugging-port=0. The script runs the specified shell
+command, with $dir set to the user data directory, and then waits to be
+terminated.
+*/
+func writeFakeChrome(t *testing.T, command string) string {
+	path := filepath.Join(t.TempDir(), "chrome")
+	script := `#!/bin/sh
+if [ "$1" = "--version" ]; then
+	echo "Chromium 120.0.6099.224"
+	exit 0
+fi
+for arg in "$@"; do
+	case "$arg" in
+		--user-data-dir=*) dir="${arg#--user-data-dir=}" ;;
+	esac
+done
+` + command + `
+exec sleep 30
+`
+	if err := os.WriteFile(path, []byte(script), 0700); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	return path
+}
+
+/*
+newFakeChrome returns a Chromium instance for a fake Chromium binary with an
+ephemeral debugging port, and the test server port the binary will announce.
+*/
+func newFakeChrome(t *testing.T, command string) (*Chrome, int) {
+	server, _ := newVersionServer("ws://localhost/devtools/browser/browser-id")
+	t.Cleanup(server.Close)
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+
+	chrome := New(
+		&Flags{
+			"addr":                  serverURL.Hostname(),
+			"remote-debugging-port": 0,
+			"user-data-dir":         t.TempDir(),
+		},
+		writeFakeChrome(t, fmt.Sprintf(command, port)),
+		t.TempDir(),
+		"",
+		"",
+	)
+	return chrome, port
+}
+
+/*
+launchFakeChrome launches a fake Chromium binary with an ephemeral debugging
+port and returns the test server port it announced.
+*/
+func launchFakeChrome(t *testing.T, command string) (*Chrome, int) {
+	chrome, port := newFakeChrome(t, command)
+	if err := chrome.Launch(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	t.Cleanup(func() { chrome.Close() })
+	return chrome, port
+}
+
+func TestReadDevToolsActivePort(t *testing.T) {
+	dir := t.TempDir()
+	if _, _, err := readDevToolsActivePort(dir); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+
+	os.WriteFile(filepath.Join(dir, "DevToolsActivePort"), []byte("41235\n/devtools/browser/browser-id\n"), 0600)
+	port, path, err := readDevToolsActivePort(dir)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if 41235 != port {
+		t.Errorf("Expected 41235, received %d", port)
+	}
+	if "/devtools/browser/browser-id" != path {
+		t.Errorf("Expected '/devtools/browser/browser-id', received '%s'", path)
+	}
+
+	os.WriteFile(filepath.Join(dir, "DevToolsActivePort"), []byte("\n"), 0600)
+	if _, _, err := readDevToolsActivePort(dir); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
+
+func TestParseDevToolsListening(t *testing.T) {
+	endpoint, ok := parseDevToolsListening("\nDevTools listening on ws://127.0.0.1:41235/devtools/browser/browser-id\n")
+	if !ok {
+		t.Fatalf("Expected the endpoint to be parsed")
+	}
+	if "41235" != endpoint.Port() {
+		t.Errorf("Expected '41235', received '%s'", endpoint.Port())
+	}
+
+	if _, ok := parseDevToolsListening("[0101/000000.000000:ERROR:gpu_init.cc] Passthrough is not supported"); ok {
+		t.Errorf("Expected no endpoint")
+	}
+}
+
+func TestChromiumLaunchDevToolsActivePort(t *testing.T) {
+	chrome, port := launchFakeChrome(t, `printf '%d\n/devtools/browser/browser-id\n' > "$dir/DevToolsActivePort"`)
+	if port != chrome.Port() {
+		t.Errorf("Expected %d, received %d", port, chrome.Port())
+	}
+}
+
+func TestChromiumLaunchDevToolsListening(t *testing.T) {
+	chrome, port := launchFakeChrome(t, `echo "DevTools listening on ws://127.0.0.1:%d/devtools/browser/browser-id" >&2`)
+	if port != chrome.Port() {
+		t.Errorf("Expected %d, received %d", port, chrome.Port())
+	}
+}
+
+func TestChromiumRelaunchEphemeralPort(t *testing.T) {
+	chrome, port := launchFakeChrome(t, `echo "DevTools listening on ws://127.0.0.1:%d/devtools/browser/browser-id" >&2`)
+	if _, err := chrome.Version(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+
+	chrome.Close()
+	if 9222 != chrome.Port() {
+		t.Errorf("Expected the resolved port to be reset to 9222, received %d", chrome.Port())
+	}
+	if nil != chrome.version {
+		t.Errorf("Expected the cached version to be reset, received %v", chrome.version)
+	}
+	if nil != chrome.listening {
+		t.Errorf("Expected the listening state to be reset")
+	}
+
+	if err := chrome.Launch(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if port != chrome.Port() {
+		t.Errorf("Expected %d, received %d", port, chrome.Port())
+	}
+}
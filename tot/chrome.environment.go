@@ -0,0 +1,118 @@
+package chrome
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+/*
+EnvMode selects how the environment of the Chromium process is built.
+*/
+type EnvMode int
+
+const (
+	// EnvMerge passes the environment of the current process with the
+	// configured variables added or overridden. It is the default.
+	EnvMerge EnvMode = iota
+
+	// EnvInherit passes the environment of the current process unchanged.
+	// Configured variables are ignored.
+	EnvInherit
+
+	// EnvReplace passes only the configured variables.
+	EnvReplace
+)
+
+/*
+String implements Stringer.
+*/
+func (mode EnvMode) String() string {
+	switch mode {
+	case EnvMerge:
+		return "merge"
+	case EnvInherit:
+		return "inherit"
+	case EnvReplace:
+		return "replace"
+	}
+	return fmt.Sprintf("EnvMode(%d)", int(mode))
+}
+
+/*
+Env implements Chromium.
+*/
+func (chrome *Chrome) Env() map[string]string {
+	return chrome.env
+}
+
+/*
+EnvMode implements Chromium.
+*/
+func (chrome *Chrome) EnvMode() EnvMode {
+	return chrome.envMode
+}
+
+/*
+SetEnv implements Chromium.
+*/
+func (chrome *Chrome) SetEnv(key, value string) {
+	chrome.env[key] = value
+}
+
+/*
+SetEnvMode implements Chromium.
+*/
+func (chrome *Chrome) SetEnvMode(mode EnvMode) {
+	chrome.envMode = mode
+}
+
+/*
+environment returns the environment of the Chromium process according to the
+environment mode.
+*/
+func (chrome *Chrome) environment() []string {
+	switch chrome.EnvMode() {
+	case EnvInherit:
+		return os.Environ()
+	case EnvReplace:
+		return mergeEnv([]string{}, chrome.env)
+	}
+	return mergeEnv(os.Environ(), chrome.env)
+}
+
+/*
+mergeEnv overrides variables in a "key=value" list. Variables not in the list
+are appended in key order.
+*/
+func mergeEnv(environ []string, overrides map[string]string) []string {
+	merged := make([]string, 0, len(environ)+len(overrides))
+	seen := make(map[string]bool)
+	for _, variable := range environ {
+		key := variable
+		if index := strings.Index(variable, "="); -1 != index {
+			key = variable[:index]
+		}
+		if value, ok := overrides[key]; ok {
+			if !seen[key] {
+				merged = append(merged, key+"="+value)
+			}
+			seen[key] = true
+			continue
+		}
+		merged = append(merged, variable)
+	}
+
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		if !seen[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		merged = append(merged, key+"="+overrides[key])
+	}
+	return merged
+}
@@ -0,0 +1,75 @@
+package chrome
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeEnv(t *testing.T) {
+	merged := mergeEnv(
+		[]string{"HOME=/root", "TZ=America/Denver", "PATH=/bin"},
+		map[string]string{"TZ": "UTC", "LANG": "en_US.UTF-8", "DISPLAY": ":99"},
+	)
+	expected := "HOME=/root TZ=UTC PATH=/bin DISPLAY=:99 LANG=en_US.UTF-8"
+	if expected != strings.Join(merged, " ") {
+		t.Errorf("Expected '%s', received '%s'", expected, strings.Join(merged, " "))
+	}
+}
+
+func TestChromiumEnvironment(t *testing.T) {
+	t.Setenv("GO_CHROME_INHERITED", "inherited")
+	chrome := New(
+		&Flags{},
+		"", //"path/to/chrome",
+		"", //"path/to/stderr",
+		"", //"path/to/stdout",
+		"", //"path/to/workdir",
+	)
+	chrome.SetEnv("TZ", "UTC")
+
+	tests := []struct {
+		mode      EnvMode
+		inherited bool
+		tz        bool
+	}{
+		{EnvMerge, true, true},
+		{EnvInherit, true, false},
+		{EnvReplace, false, true},
+	}
+	for _, test := range tests {
+		chrome.SetEnvMode(test.mode)
+		environ := " " + strings.Join(chrome.environment(), " ") + " "
+		if test.inherited != strings.Contains(environ, " GO_CHROME_INHERITED=inherited ") {
+			t.Errorf("%s: expected inherited variables %v, received '%s'", test.mode, test.inherited, environ)
+		}
+		if test.tz != strings.Contains(environ, " TZ=UTC ") {
+			t.Errorf("%s: expected TZ=UTC %v, received '%s'", test.mode, test.tz, environ)
+		}
+	}
+}
+
+func TestChromiumLaunchEnvironment(t *testing.T) {
+	t.Setenv("GO_CHROME_INHERITED", "inherited")
+	chrome, _ := newFakeChrome(t, `env > "$dir/environment"; printf '%d\n/devtools/browser/browser-id\n' > "$dir/DevToolsActivePort"`)
+	chrome.SetEnvMode(EnvReplace)
+	chrome.SetEnv("PATH", os.Getenv("PATH"))
+	chrome.SetEnv("TZ", "UTC")
+	if err := chrome.Launch(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	defer chrome.Close()
+
+	content, err := os.ReadFile(filepath.Join(chrome.userDataDir(), "environment"))
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	environ := "\n" + string(content)
+	if !strings.Contains(environ, "\nTZ=UTC\n") {
+		t.Errorf("Expected TZ=UTC, received '%s'", content)
+	}
+	if strings.Contains(environ, "\nGO_CHROME_INHERITED=") {
+		t.Errorf("Expected the environment to be replaced, received '%s'", content)
+	}
+}
@@ -0,0 +1,482 @@
+package chrome
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+/*
+HeadlessMode selects how Chromium runs headless.
+*/
+type HeadlessMode int
+
+const (
+	// HeadlessNew runs the new headless mode, which shares the implementation
+	// of headed Chromium. It is the default.
+	HeadlessNew HeadlessMode = iota
+
+	// HeadlessOld runs the legacy headless shell, available up to Chromium
+	// 131.
+	HeadlessOld
+
+	// HeadlessOff runs Chromium with a visible window.
+	HeadlessOff
+)
+
+/*
+String implements Stringer.
+*/
+func (mode HeadlessMode) String() string {
+	switch mode {
+	case HeadlessNew:
+		return "new"
+	case HeadlessOld:
+		return "old"
+	case HeadlessOff:
+		return "off"
+	}
+	return fmt.Sprintf("HeadlessMode(%d)", int(mode))
+}
+
+/*
+DefaultLaunchFlags are the flags rendered by LaunchOptions unless
+DisableDefaults is set. They turn off background activity, prompts and
+services that get in the way of automation.
+*/
+var DefaultLaunchFlags = Flags{
+	"disable-background-networking":          nil,
+	"disable-background-timer-throttling":    nil,
+	"disable-backgrounding-occluded-windows": nil,
+	"disable-breakpad":                       nil,
+	"disable-client-side-phishing-detection": nil,
+	"disable-default-apps":                   nil,
+	"disable-dev-shm-usage":                  nil,
+	"disable-extensions":                     nil,
+	"disable-hang-monitor":                   nil,
+	"disable-popup-blocking":                 nil,
+	"disable-prompt-on-repost":               nil,
+	"disable-renderer-backgrounding":         nil,
+	"disable-sync":                           nil,
+	"metrics-recording-only":                 nil,
+	"no-first-run":                           nil,
+	"password-store":                         "basic",
+	"use-mock-keychain":                      nil,
+}
+
+/*
+defaultHeadlessFlags are added to the default flags in headless modes.
+*/
+var defaultHeadlessFlags = Flags{
+	"hide-scrollbars": nil,
+	"mute-audio":      nil,
+}
+
+/*
+launchOptionFlags maps the flags rendered from typed LaunchOptions fields to
+the name of the field. These flags can't be set with ExtraFlags.
+*/
+var launchOptionFlags = map[string]string{
+	"headless":              "Headless",
+	"lang":                  "Lang",
+	"no-sandbox":            "NoSandbox",
+	"proxy-bypass-list":     "ProxyBypass",
+	"proxy-server":          "Proxy",
+	"remote-debugging-pipe": "Pipe",
+	"remote-debugging-port": "DebuggingPort",
+	"user-agent":            "UserAgent",
+	"user-data-dir":         "UserDataDir",
+	"window-size":           "WindowWidth and WindowHeight",
+}
+
+var (
+	flagNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+	langPattern     = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+	proxySchemes    = map[string]bool{"http": true, "https": true, "socks4": true, "socks5": true}
+)
+
+/*
+LaunchOptions is a typed set of Chromium launch settings that renders to
+ChromiumFlags.
+*/
+type LaunchOptions struct {
+	// Optional. DebuggingPort is the remote debugging port. Defaults to 0,
+	// which lets Chromium choose a free port. Can't be combined with Pipe.
+	DebuggingPort int
+
+	// Optional. DisableDefaults omits DefaultLaunchFlags.
+	DisableDefaults bool
+
+	// Optional. Env contains environment variables set for the Chromium
+	// process.
+	Env map[string]string
+
+	// Optional. EnvMode selects how Env is combined with the inherited
+	// environment. Defaults to EnvMerge.
+	EnvMode EnvMode
+
+	// Optional. ExtraFlags contains flags that have no typed option. Flags
+	// rendered from typed options can't be set here.
+	ExtraFlags Flags
+
+	// Optional. Headless is the headless mode. Defaults to HeadlessNew.
+	Headless HeadlessMode
+
+	// Optional. Lang is the UI language, e.g. 'en-US'.
+	Lang string
+
+	// Optional. NoSandbox disables the Chromium sandbox, which is required
+	// when running as root in most containers.
+	NoSandbox bool
+
+	// Optional. Pipe uses the remote debugging pipe transport instead of a
+	// debugging port.
+	Pipe bool
+
+	// Optional. Process contains operating system attributes used to start
+	// the Chromium process.
+	Process *ProcessOptions
+
+	// Optional. Proxy is the proxy server, e.g. 'socks5://localhost:1080' or
+	// 'localhost:3128'.
+	Proxy string
+
+	// Optional. ProxyBypass lists hosts that are not proxied. Requires Proxy.
+	ProxyBypass []string
+
+	// Optional. UserAgent overrides the user agent string.
+	UserAgent string
+
+	// Optional. UserDataDir is the profile directory. Defaults to a temporary
+	// profile created by Launch.
+	UserDataDir string
+
+	// Optional. WindowHeight is the window height in pixels. Requires
+	// WindowWidth.
+	WindowHeight int
+
+	// Optional. WindowWidth is the window width in pixels. Requires
+	// WindowHeight.
+	WindowWidth int
+}
+
+/*
+NewLaunchOptions returns a pointer to LaunchOptions with headless defaults: the
+new headless mode, a 1280x720 window and an ephemeral debugging port.
+*/
+func NewLaunchOptions() *LaunchOptions {
+	return &LaunchOptions{
+		Env:          make(map[string]string),
+		ExtraFlags:   Flags{},
+		Headless:     HeadlessNew,
+		WindowHeight: 720,
+		WindowWidth:  1280,
+	}
+}
+
+/*
+NewWithOptions returns a pointer to a Chromium instance configured with the
+specified launch options, or an error if the options are invalid.
+*/
+func NewWithOptions(
+	options *LaunchOptions,
+	binary string,
+	workdir string,
+	stdout string,
+	stderr string,
+) (*Chrome, error) {
+	flags, err := options.Flags()
+	if nil != err {
+		return nil, err
+	}
+	chrome := New(flags, binary, workdir, stdout, stderr)
+	for key, value := range options.Env {
+		chrome.SetEnv(key, value)
+	}
+	chrome.SetEnvMode(options.EnvMode)
+	if nil != options.Process {
+		chrome.SetProcessOptions(options.Process)
+	}
+	return chrome, nil
+}
+
+/*
+WithDebuggingPort sets the remote debugging port.
+*/
+func (options *LaunchOptions) WithDebuggingPort(port int) *LaunchOptions {
+	options.DebuggingPort = port
+	return options
+}
+
+/*
+WithEnv sets an environment variable for the Chromium process.
+*/
+func (options *LaunchOptions) WithEnv(key, value string) *LaunchOptions {
+	if nil == options.Env {
+		options.Env = make(map[string]string)
+	}
+	options.Env[key] = value
+	return options
+}
+
+/*
+WithEnvMode sets how the environment variables are combined with the inherited
+environment.
+*/
+func (options *LaunchOptions) WithEnvMode(mode EnvMode) *LaunchOptions {
+	options.EnvMode = mode
+	return options
+}
+
+/*
+WithFlag sets an extra flag. The value may be an int, a string, a list of
+strings or nil, invalid values are reported by Validate.
+*/
+func (options *LaunchOptions) WithFlag(flag string, value interface{}) *LaunchOptions {
+	if nil == options.ExtraFlags {
+		options.ExtraFlags = Flags{}
+	}
+	options.ExtraFlags[flag] = value
+	return options
+}
+
+/*
+WithHeadless sets the headless mode.
+*/
+func (options *LaunchOptions) WithHeadless(mode HeadlessMode) *LaunchOptions {
+	options.Headless = mode
+	return options
+}
+
+/*
+WithLang sets the UI language.
+*/
+func (options *LaunchOptions) WithLang(lang string) *LaunchOptions {
+	options.Lang = lang
+	return options
+}
+
+/*
+WithNoSandbox disables the Chromium sandbox.
+*/
+func (options *LaunchOptions) WithNoSandbox() *LaunchOptions {
+	options.NoSandbox = true
+	return options
+}
+
+/*
+WithPipe selects the remote debugging pipe transport.
+*/
+func (options *LaunchOptions) WithPipe() *LaunchOptions {
+	options.Pipe = true
+	return options
+}
+
+/*
+WithProcess sets the operating system attributes used to start the Chromium
+process.
+*/
+func (options *LaunchOptions) WithProcess(process *ProcessOptions) *LaunchOptions {
+	options.Process = process
+	return options
+}
+
+/*
+WithProxy sets the proxy server and the hosts that bypass it.
+*/
+func (options *LaunchOptions) WithProxy(server string, bypass ...string) *LaunchOptions {
+	options.Proxy = server
+	options.ProxyBypass = bypass
+	return options
+}
+
+/*
+WithUserAgent sets the user agent string.
+*/
+func (options *LaunchOptions) WithUserAgent(userAgent string) *LaunchOptions {
+	options.UserAgent = userAgent
+	return options
+}
+
+/*
+WithUserDataDir sets the profile directory.
+*/
+func (options *LaunchOptions) WithUserDataDir(dir string) *LaunchOptions {
+	options.UserDataDir = dir
+	return options
+}
+
+/*
+WithWindowSize sets the window size in pixels.
+*/
+func (options *LaunchOptions) WithWindowSize(width, height int) *LaunchOptions {
+	options.WindowWidth = width
+	options.WindowHeight = height
+	return options
+}
+
+/*
+Validate returns an error describing every invalid or conflicting setting, or
+nil if the options are valid.
+*/
+func (options *LaunchOptions) Validate() error {
+	problems := []string{}
+	problem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if options.Headless < HeadlessNew || options.Headless > HeadlessOff {
+		problem("invalid headless mode %s", options.Headless)
+	}
+
+	if options.WindowWidth < 0 || options.WindowHeight < 0 {
+		problem("window size %dx%d can't be negative", options.WindowWidth, options.WindowHeight)
+	} else if (0 == options.WindowWidth) != (0 == options.WindowHeight) {
+		problem("window width and height must be set together")
+	}
+
+	if options.DebuggingPort < 0 || options.DebuggingPort > 65535 {
+		problem("invalid debugging port %d", options.DebuggingPort)
+	}
+	if options.Pipe && 0 != options.DebuggingPort {
+		problem("a debugging port can't be used with the remote debugging pipe")
+	}
+
+	if "" == options.Proxy {
+		if len(options.ProxyBypass) > 0 {
+			problem("a proxy bypass list requires a proxy server")
+		}
+	} else if err := validateProxy(options.Proxy); nil != err {
+		problem("%s", err)
+	}
+
+	if "" != options.Lang && !langPattern.MatchString(options.Lang) {
+		problem("invalid language '%s'", options.Lang)
+	}
+	if strings.ContainsAny(options.UserAgent, "\r\n") {
+		problem("the user agent can't contain line breaks")
+	}
+
+	for key := range options.Env {
+		if "" == key || strings.ContainsAny(key, "=\x00") {
+			problem("invalid environment variable name '%s'", key)
+		}
+	}
+	if options.EnvMode < EnvMerge || options.EnvMode > EnvReplace {
+		problem("invalid environment mode %s", options.EnvMode)
+	} else if EnvInherit == options.EnvMode && len(options.Env) > 0 {
+		problem("environment variables are ignored in the inherit environment mode")
+	}
+	if nil != options.Process && options.Process.NewSession && options.Process.SharedProcessGroup {
+		problem("a new session can't share the process group")
+	}
+
+	for flag, value := range options.ExtraFlags {
+		if !flagNamePattern.MatchString(flag) {
+			problem("invalid flag name '%s', flags are specified without leading dashes or values", flag)
+			continue
+		}
+		if field, ok := launchOptionFlags[flag]; ok {
+			problem("flag '%s' conflicts with the %s option", flag, field)
+			continue
+		}
+		switch value.(type) {
+		case nil, int, string, []string:
+		default:
+			problem("invalid data type '%T' for flag '%s'", value, flag)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid launch options: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+/*
+validateProxy checks that a proxy server is a host:port pair or a URL with a
+supported scheme.
+*/
+func validateProxy(proxy string) error {
+	if !strings.Contains(proxy, "://") {
+		if _, _, err := net.SplitHostPort(proxy); nil != err {
+			return fmt.Errorf("invalid proxy server '%s': %s", proxy, err)
+		}
+		return nil
+	}
+	proxyURL, err := url.Parse(proxy)
+	if nil != err {
+		return fmt.Errorf("invalid proxy server '%s': %s", proxy, err)
+	}
+	if !proxySchemes[proxyURL.Scheme] {
+		return fmt.Errorf("unsupported proxy scheme '%s'", proxyURL.Scheme)
+	}
+	if "" == proxyURL.Host {
+		return fmt.Errorf("invalid proxy server '%s': missing host", proxy)
+	}
+	return nil
+}
+
+/*
+Flags validates the options and renders them to ChromiumFlags.
+*/
+func (options *LaunchOptions) Flags() (ChromiumFlags, error) {
+	if err := options.Validate(); nil != err {
+		return nil, err
+	}
+
+	flags := Flags{}
+	if !options.DisableDefaults {
+		for flag, value := range DefaultLaunchFlags {
+			flags[flag] = value
+		}
+		if HeadlessOff != options.Headless {
+			for flag, value := range defaultHeadlessFlags {
+				flags[flag] = value
+			}
+		}
+	}
+
+	switch options.Headless {
+	case HeadlessNew:
+		flags["headless"] = "new"
+	case HeadlessOld:
+		flags["headless"] = "old"
+	}
+
+	if options.Pipe {
+		flags["remote-debugging-pipe"] = nil
+	} else {
+		flags["remote-debugging-port"] = options.DebuggingPort
+	}
+
+	if 0 != options.WindowWidth {
+		flags["window-size"] = fmt.Sprintf("%d,%d", options.WindowWidth, options.WindowHeight)
+	}
+	if "" != options.Proxy {
+		flags["proxy-server"] = options.Proxy
+	}
+	if len(options.ProxyBypass) > 0 {
+		flags["proxy-bypass-list"] = strings.Join(options.ProxyBypass, ";")
+	}
+	if "" != options.UserAgent {
+		flags["user-agent"] = options.UserAgent
+	}
+	if "" != options.Lang {
+		flags["lang"] = options.Lang
+	}
+	if "" != options.UserDataDir {
+		flags["user-data-dir"] = options.UserDataDir
+	}
+	if options.NoSandbox {
+		flags["no-sandbox"] = nil
+	}
+
+	for flag, value := range options.ExtraFlags {
+		flags[flag] = value
+	}
+
+	return &flags, nil
+}
@@ -0,0 +1,129 @@
+package chrome
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLaunchOptionsDefaults(t *testing.T) {
+	flags, err := NewLaunchOptions().Flags()
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+
+	expected := map[string]interface{}{
+		"headless":              "new",
+		"hide-scrollbars":       nil,
+		"no-first-run":          nil,
+		"remote-debugging-port": 0,
+		"window-size":           "1280,720",
+	}
+	for flag, value := range expected {
+		received, err := flags.Get(flag)
+		if nil != err {
+			t.Errorf("Expected flag '%s', received error: '%s'", flag, err.Error())
+			continue
+		}
+		if value != received {
+			t.Errorf("Expected %s=%v, received %v", flag, value, received)
+		}
+	}
+	if flags.Has("remote-debugging-pipe") {
+		t.Errorf("Expected no remote-debugging-pipe flag")
+	}
+}
+
+func TestLaunchOptionsBuilder(t *testing.T) {
+	options := NewLaunchOptions().
+		WithHeadless(HeadlessOff).
+		WithWindowSize(800, 600).
+		WithProxy("socks5://localhost:1080", "localhost", "*.internal").
+		WithUserAgent("test-agent").
+		WithLang("en-US").
+		WithPipe().
+		WithNoSandbox().
+		WithFlag("disable-features", []string{"Translate", "MediaRouter"}).
+		WithFlag("no-first-run", nil).
+		WithEnv("TZ", "UTC").
+		WithEnvMode(EnvReplace).
+		WithProcess(&ProcessOptions{NewSession: true})
+	options.DisableDefaults = true
+
+	flags, err := options.Flags()
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	expected := strings.Join([]string{
+		"--disable-features=Translate,MediaRouter",
+		"--lang=en-US",
+		"--no-first-run",
+		"--no-sandbox",
+		"--proxy-bypass-list=localhost;*.internal",
+		"--proxy-server=socks5://localhost:1080",
+		"--remote-debugging-pipe",
+		"--user-agent=test-agent",
+		"--window-size=800,600",
+	}, " ")
+	if expected != flags.String() {
+		t.Errorf("Expected '%s', received '%s'", expected, flags.String())
+	}
+
+	chrome, err := NewWithOptions(options, "", "", "", "")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if !chrome.PipeMode() {
+		t.Errorf("Expected pipe mode")
+	}
+	if "UTC" != chrome.Env()["TZ"] {
+		t.Errorf("Expected 'UTC', received '%s'", chrome.Env()["TZ"])
+	}
+	if EnvReplace != chrome.EnvMode() {
+		t.Errorf("Expected replace, received %s", chrome.EnvMode())
+	}
+	if !chrome.ProcessOptions().NewSession {
+		t.Errorf("Expected a new session")
+	}
+}
+
+func TestLaunchOptionsValidate(t *testing.T) {
+	tests := []struct {
+		options *LaunchOptions
+		problem string
+	}{
+		{NewLaunchOptions().WithPipe().WithDebuggingPort(9222), "a debugging port can't be used with the remote debugging pipe"},
+		{NewLaunchOptions().WithDebuggingPort(70000), "invalid debugging port 70000"},
+		{NewLaunchOptions().WithWindowSize(800, 0), "window width and height must be set together"},
+		{NewLaunchOptions().WithWindowSize(-1, 600), "can't be negative"},
+		{NewLaunchOptions().WithHeadless(HeadlessMode(7)), "invalid headless mode HeadlessMode(7)"},
+		{NewLaunchOptions().WithProxy("", "localhost"), "a proxy bypass list requires a proxy server"},
+		{NewLaunchOptions().WithProxy("ftp://localhost:21"), "unsupported proxy scheme 'ftp'"},
+		{NewLaunchOptions().WithProxy("localhost"), "invalid proxy server 'localhost'"},
+		{NewLaunchOptions().WithLang("english please"), "invalid language 'english please'"},
+		{NewLaunchOptions().WithUserAgent("agent\nX-Injected: 1"), "the user agent can't contain line breaks"},
+		{NewLaunchOptions().WithEnv("A=B", "C"), "invalid environment variable name 'A=B'"},
+		{NewLaunchOptions().WithEnvMode(EnvInherit).WithEnv("TZ", "UTC"), "environment variables are ignored in the inherit environment mode"},
+		{NewLaunchOptions().WithEnvMode(EnvMode(7)), "invalid environment mode EnvMode(7)"},
+		{NewLaunchOptions().WithProcess(&ProcessOptions{NewSession: true, SharedProcessGroup: true}), "a new session can't share the process group"},
+		{NewLaunchOptions().WithFlag("--no-first-run", nil), "invalid flag name '--no-first-run'"},
+		{NewLaunchOptions().WithFlag("window-size", "1,1"), "flag 'window-size' conflicts with the WindowWidth and WindowHeight option"},
+		{NewLaunchOptions().WithFlag("enable-logging", true), "invalid data type 'bool' for flag 'enable-logging'"},
+	}
+	for _, test := range tests {
+		err := test.options.Validate()
+		if nil == err {
+			t.Errorf("Expected error '%s', received nil", test.problem)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.problem) {
+			t.Errorf("Expected error '%s', received '%s'", test.problem, err.Error())
+		}
+		if _, err := NewWithOptions(test.options, "", "", "", ""); nil == err {
+			t.Errorf("Expected error, received nil")
+		}
+	}
+
+	if err := (&LaunchOptions{}).Validate(); nil != err {
+		t.Errorf("Expected nil, received error: '%s'", err.Error())
+	}
+}
@@ -0,0 +1,410 @@
+package chrome
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*
+OutputStream identifies the output stream of the Chromium process a line was
+written to.
+*/
+type OutputStream string
+
+const (
+	// Stdout is the standard output stream.
+	Stdout OutputStream = "stdout"
+
+	// Stderr is the standard error stream.
+	Stderr OutputStream = "stderr"
+)
+
+/*
+LogSeverity is the severity of a Chromium log message. Verbose messages have
+negative severities, see LogRecord.Verbosity.
+*/
+type LogSeverity int
+
+const (
+	// LogVerbose messages are logged with --v=1 or higher.
+	LogVerbose LogSeverity = -1
+
+	// LogInfo messages.
+	LogInfo LogSeverity = 0
+
+	// LogWarning messages.
+	LogWarning LogSeverity = 1
+
+	// LogError messages.
+	LogError LogSeverity = 2
+
+	// LogFatal messages are logged immediately before Chromium aborts.
+	LogFatal LogSeverity = 3
+)
+
+/*
+String implements Stringer.
+*/
+func (severity LogSeverity) String() string {
+	switch severity {
+	case LogVerbose:
+		return "VERBOSE"
+	case LogInfo:
+		return "INFO"
+	case LogWarning:
+		return "WARNING"
+	case LogError:
+		return "ERROR"
+	case LogFatal:
+		return "FATAL"
+	}
+	return fmt.Sprintf("LogSeverity(%d)", int(severity))
+}
+
+/*
+maxStartupMessages is the number of startup error messages kept for the error
+returned by Launch.
+*/
+const maxStartupMessages = 10
+
+/*
+logLocationPattern matches the "file(line)" source location of a log message.
+*/
+var logLocationPattern = regexp.MustCompile(`^(.+)\((\d+)\)$`)
+
+/*
+LogRecord is a Chromium log message, written with --enable-logging.
+*/
+type LogRecord struct {
+	// File is the source file that logged the message.
+	File string
+
+	// Line is the line number in the source file.
+	Line int
+
+	// Message is the log message.
+	Message string
+
+	// PID is the ID of the logging process, or 0 if it isn't logged.
+	PID int
+
+	// Severity is the message severity.
+	Severity LogSeverity
+
+	// TID is the ID of the logging thread, or 0 if it isn't logged.
+	TID int
+
+	// Time is the time the message was logged, or the zero time if it isn't
+	// logged. Chromium doesn't log the year, the current year is assumed.
+	Time time.Time
+
+	// Verbosity is the verbose logging level of LogVerbose messages.
+	Verbosity int
+}
+
+/*
+OutputLine is a line of output written by the Chromium process.
+*/
+type OutputLine struct {
+	// Log is the parsed log message, or nil if the line isn't a Chromium log
+	// message.
+	Log *LogRecord
+
+	// Stream is the stream the line was written to.
+	Stream OutputStream
+
+	// Text is the line without the trailing line break.
+	Text string
+}
+
+/*
+ParseLogLine parses a Chromium log line of the form
+
+	[pid:tid:MMDD/HHMMSS.uuuuuu:SEVERITY:file.cc(123)] message
+
+The process ID, thread ID and timestamp prefixes are optional. It returns false
+if the line isn't a log message.
+*/
+func ParseLogLine(line string) (*LogRecord, bool) {
+	if !strings.HasPrefix(line, "[") {
+		return nil, false
+	}
+	end := strings.Index(line, "] ")
+	message := ""
+	if -1 == end {
+		if !strings.HasSuffix(line, "]") {
+			return nil, false
+		}
+		end = len(line) - 1
+	} else {
+		message = line[end+2:]
+	}
+
+	parts := strings.Split(line[1:end], ":")
+	if len(parts) < 2 {
+		return nil, false
+	}
+	record := &LogRecord{Message: message}
+
+	location := logLocationPattern.FindStringSubmatch(parts[len(parts)-1])
+	if nil == location {
+		return nil, false
+	}
+	record.File = location[1]
+	record.Line, _ = strconv.Atoi(location[2])
+
+	severity := parts[len(parts)-2]
+	switch {
+	case "INFO" == severity:
+		record.Severity = LogInfo
+	case "WARNING" == severity:
+		record.Severity = LogWarning
+	case "ERROR" == severity:
+		record.Severity = LogError
+	case "FATAL" == severity:
+		record.Severity = LogFatal
+	case strings.HasPrefix(severity, "VERBOSE"):
+		verbosity, err := strconv.Atoi(severity[len("VERBOSE"):])
+		if nil != err {
+			return nil, false
+		}
+		record.Severity = LogVerbose
+		record.Verbosity = verbosity
+	default:
+		return nil, false
+	}
+
+	// The process and thread IDs precede the timestamp, a tick count may
+	// follow it.
+	ids := []int{}
+	for _, part := range parts[:len(parts)-2] {
+		if strings.Contains(part, "/") {
+			timestamp, err := time.ParseInLocation("0102/150405.000000", part, time.Local)
+			if nil != err {
+				timestamp, err = time.ParseInLocation("0102/150405", part, time.Local)
+			}
+			if nil != err {
+				return nil, false
+			}
+			record.Time = timestamp.AddDate(time.Now().Year(), 0, 0)
+			break
+		}
+		id, err := strconv.Atoi(part)
+		if nil != err {
+			return nil, false
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) > 0 {
+		record.PID = ids[0]
+	}
+	if len(ids) > 1 {
+		record.TID = ids[1]
+	}
+
+	return record, true
+}
+
+/*
+OnOutput implements Chromium.
+*/
+func (chrome *Chrome) OnOutput(callback func(line *OutputLine)) {
+	chrome.outputMux.Lock()
+	defer chrome.outputMux.Unlock()
+	chrome.outputHandlers = append(chrome.outputHandlers, callback)
+}
+
+/*
+SetStderrWriter implements Chromium.
+*/
+func (chrome *Chrome) SetStderrWriter(writer io.Writer) {
+	chrome.outputMux.Lock()
+	defer chrome.outputMux.Unlock()
+	chrome.stderrWriter = writer
+}
+
+/*
+SetStdoutWriter implements Chromium.
+*/
+func (chrome *Chrome) SetStdoutWriter(writer io.Writer) {
+	chrome.outputMux.Lock()
+	defer chrome.outputMux.Unlock()
+	chrome.stdoutWriter = writer
+}
+
+/*
+outputPipes connects the STDOUT and STDERR streams of the Chromium process to
+the output watchers.
+*/
+type outputPipes struct {
+	stderrReader *os.File
+	stderrWriter *os.File
+	stdoutReader *os.File
+	stdoutWriter *os.File
+}
+
+/*
+newOutputPipes creates the output pipes.
+*/
+func newOutputPipes() (*outputPipes, error) {
+	output := &outputPipes{}
+	var err error
+	if output.stdoutReader, output.stdoutWriter, err = os.Pipe(); nil != err {
+		return nil, errors.Wrap(err, "cannot create standard output pipe")
+	}
+	if output.stderrReader, output.stderrWriter, err = os.Pipe(); nil != err {
+		output.close()
+		return nil, errors.Wrap(err, "cannot create error output pipe")
+	}
+	return output, nil
+}
+
+/*
+closeWriters closes the ends of the pipes passed to the Chromium process.
+*/
+func (output *outputPipes) closeWriters() {
+	for _, file := range []*os.File{output.stdoutWriter, output.stderrWriter} {
+		if nil != file {
+			file.Close()
+		}
+	}
+}
+
+/*
+close closes all ends of the pipes.
+*/
+func (output *outputPipes) close() {
+	output.closeWriters()
+	for _, file := range []*os.File{output.stdoutReader, output.stderrReader} {
+		if nil != file {
+			file.Close()
+		}
+	}
+}
+
+/*
+watchOutputPipes starts the output watchers and returns a channel that is
+closed when both have returned.
+*/
+func (chrome *Chrome) watchOutputPipes(output *outputPipes) chan struct{} {
+	done := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go chrome.watchOutput(Stdout, output.stdoutReader, chrome.stdOUTFile, wg)
+	go chrome.watchOutput(Stderr, output.stderrReader, chrome.stdERRFile, wg)
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	return done
+}
+
+/*
+watchOutput reads an output stream of the Chromium process line by line. Each
+line is copied to the configured output file and writer and passed to the
+output handlers. The browser endpoint URL is reported when Chromium announces
+it on STDERR, and errors logged during startup are kept for the error returned
+by Launch.
+*/
+func (chrome *Chrome) watchOutput(stream OutputStream, reader io.ReadCloser, file *os.File, wg *sync.WaitGroup) {
+	defer wg.Done()
+	defer reader.Close()
+	buffer := bufio.NewReader(reader)
+	for {
+		text, err := buffer.ReadString('\n')
+		if "" != text {
+			chrome.handleOutput(stream, file, strings.TrimRight(text, "\r\n"))
+		}
+		if nil != err {
+			return
+		}
+	}
+}
+
+/*
+handleOutput processes a line of output.
+*/
+func (chrome *Chrome) handleOutput(stream OutputStream, file *os.File, text string) {
+	line := &OutputLine{
+		Stream: stream,
+		Text:   text,
+	}
+	line.Log, _ = ParseLogLine(text)
+
+	fmt.Fprintln(file, text)
+
+	chrome.outputMux.Lock()
+	writer := chrome.stdoutWriter
+	if Stderr == stream {
+		writer = chrome.stderrWriter
+	}
+	handlers := chrome.outputHandlers
+	endpoints := chrome.listening
+	endpoint, listening := parseDevToolsListening(text)
+	if chrome.starting && Stderr == stream && !listening && (nil == line.Log || line.Log.Severity >= LogError) {
+		chrome.startupMessages = append(chrome.startupMessages, startupMessage(line))
+		if len(chrome.startupMessages) > maxStartupMessages {
+			chrome.startupMessages = chrome.startupMessages[1:]
+		}
+	}
+	chrome.outputMux.Unlock()
+
+	if nil != writer {
+		fmt.Fprintln(writer, text)
+	}
+
+	if Stderr == stream && listening {
+		select {
+		case endpoints <- endpoint:
+		default:
+		}
+	}
+
+	for _, handler := range handlers {
+		handler(line)
+	}
+}
+
+/*
+startupMessage formats an output line for the error returned by Launch.
+*/
+func startupMessage(line *OutputLine) string {
+	if nil == line.Log {
+		return line.Text
+	}
+	return fmt.Sprintf("%s: %s", line.Log.Severity, line.Log.Message)
+}
+
+/*
+setStarting sets whether Launch is waiting for Chromium to start. Startup
+messages are reset when starting.
+*/
+func (chrome *Chrome) setStarting(starting bool) {
+	chrome.outputMux.Lock()
+	defer chrome.outputMux.Unlock()
+	chrome.starting = starting
+	if starting {
+		chrome.startupMessages = nil
+	}
+}
+
+/*
+startupError adds the errors Chromium logged during startup to a Launch error.
+*/
+func (chrome *Chrome) startupError(err error) error {
+	chrome.outputMux.Lock()
+	defer chrome.outputMux.Unlock()
+	if 0 == len(chrome.startupMessages) {
+		return err
+	}
+	return errors.Wrap(err, fmt.Sprintf("chrome reported %s", strings.Join(chrome.startupMessages, "; ")))
+}
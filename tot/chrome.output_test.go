@@ -0,0 +1,149 @@
+package chrome
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+/*
+safeBuffer is a bytes.Buffer that can be written to concurrently.
+*/
+type safeBuffer struct {
+	buffer bytes.Buffer
+	mux    sync.Mutex
+}
+
+func (buffer *safeBuffer) Write(p []byte) (int, error) {
+	buffer.mux.Lock()
+	defer buffer.mux.Unlock()
+	return buffer.buffer.Write(p)
+}
+
+func (buffer *safeBuffer) String() string {
+	buffer.mux.Lock()
+	defer buffer.mux.Unlock()
+	return buffer.buffer.String()
+}
+
+func TestParseLogLine(t *testing.T) {
+	record, ok := ParseLogLine("[1234:5678:0417/142305.123456:ERROR:gpu_init.cc(523)] Passthrough is not supported")
+	if !ok {
+		t.Fatalf("Expected a log record")
+	}
+	if 1234 != record.PID || 5678 != record.TID {
+		t.Errorf("Expected PID 1234 and TID 5678, received %d and %d", record.PID, record.TID)
+	}
+	if LogError != record.Severity {
+		t.Errorf("Expected ERROR, received %s", record.Severity)
+	}
+	if "gpu_init.cc" != record.File || 523 != record.Line {
+		t.Errorf("Expected gpu_init.cc(523), received %s(%d)", record.File, record.Line)
+	}
+	if "Passthrough is not supported" != record.Message {
+		t.Errorf("Expected 'Passthrough is not supported', received '%s'", record.Message)
+	}
+	if time.April != record.Time.Month() || 17 != record.Time.Day() || 14 != record.Time.Hour() || time.Now().Year() != record.Time.Year() {
+		t.Errorf("Expected April 17 14:23:05, received %s", record.Time)
+	}
+
+	record, ok = ParseLogLine("[0417/142305.123456:VERBOSE1:chrome_main.cc(42)] starting")
+	if !ok {
+		t.Fatalf("Expected a log record")
+	}
+	if LogVerbose != record.Severity || 1 != record.Verbosity {
+		t.Errorf("Expected VERBOSE1, received %s%d", record.Severity, record.Verbosity)
+	}
+	if 0 != record.PID {
+		t.Errorf("Expected no PID, received %d", record.PID)
+	}
+
+	record, ok = ParseLogLine("[WARNING:../../base/files/file.cc(7)]")
+	if !ok {
+		t.Fatalf("Expected a log record")
+	}
+	if LogWarning != record.Severity || "../../base/files/file.cc" != record.File || "" != record.Message {
+		t.Errorf("Unexpected record %+v", record)
+	}
+
+	for _, line := range []string{
+		"DevTools listening on ws://127.0.0.1:9222/devtools/browser/id",
+		"[1234:5678:0417/142305.123456:NOTICE:gpu_init.cc(523)] message",
+		"[1234:5678:0417/142305.123456:ERROR:gpu_init.cc] message",
+		"[x:ERROR:gpu_init.cc(1)] message",
+		"",
+	} {
+		if _, ok := ParseLogLine(line); ok {
+			t.Errorf("Expected '%s' not to be a log record", line)
+		}
+	}
+}
+
+func TestChromiumOutput(t *testing.T) {
+	chrome, _ := newFakeChrome(t, strings.Join([]string{
+		`echo 'hello from stdout'`,
+		`echo '[1:2:0417/142305.123456:WARNING:main.cc(10)] careful' >&2`,
+		`printf '%d\n/devtools/browser/browser-id\n' > "$dir/DevToolsActivePort"`,
+	}, "\n"))
+
+	stdout := &safeBuffer{}
+	stderr := &safeBuffer{}
+	chrome.SetStdoutWriter(stdout)
+	chrome.SetStderrWriter(stderr)
+
+	lines := make(chan *OutputLine, 10)
+	chrome.OnOutput(func(line *OutputLine) {
+		lines <- line
+	})
+	if err := chrome.Launch(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	defer chrome.Close()
+
+	var warning *OutputLine
+	for warning == nil {
+		select {
+		case line := <-lines:
+			if Stdout == line.Stream && nil != line.Log {
+				t.Errorf("Expected '%s' not to be a log record", line.Text)
+			}
+			if Stderr == line.Stream && nil != line.Log {
+				warning = line
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for output")
+		}
+	}
+	if LogWarning != warning.Log.Severity || "careful" != warning.Log.Message {
+		t.Errorf("Expected WARNING: careful, received %s: %s", warning.Log.Severity, warning.Log.Message)
+	}
+
+	if "hello from stdout\n" != stdout.String() {
+		t.Errorf("Expected 'hello from stdout', received '%s'", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "careful") {
+		t.Errorf("Expected the warning, received '%s'", stderr.String())
+	}
+}
+
+func TestChromiumLaunchFatal(t *testing.T) {
+	chrome, _ := newFakeChrome(t, strings.Join([]string{
+		`echo '[1:2:0417/142305.123456:INFO:main.cc(10)] starting' >&2`,
+		`echo '[1:2:0417/142305.123456:FATAL:zygote_host_impl_linux.cc(127)] No usable sandbox!' >&2`,
+		`exit 1`,
+	}, "\n"))
+
+	err := chrome.Launch()
+	if nil == err {
+		chrome.Close()
+		t.Fatalf("Expected error, received nil")
+	}
+	if !strings.Contains(err.Error(), "FATAL: No usable sandbox!") {
+		t.Errorf("Expected the fatal message, received '%s'", err.Error())
+	}
+	if strings.Contains(err.Error(), "starting") {
+		t.Errorf("Expected only errors, received '%s'", err.Error())
+	}
+}
@@ -0,0 +1,163 @@
+package chrome
+
+import (
+	"os"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/target"
+	"github.com/pkg/errors"
+)
+
+/*
+pipeFiles holds both ends of the pipes used by the remote debugging pipe
+transport. Chromium reads commands from file descriptor 3 and writes responses
+and events to file descriptor 4.
+*/
+type pipeFiles struct {
+	// commands is the write end of the command pipe.
+	commands *os.File
+
+	// commandsChild is the read end of the command pipe, passed to the child
+	// process as file descriptor 3.
+	commandsChild *os.File
+
+	// responses is the read end of the response pipe.
+	responses *os.File
+
+	// responsesChild is the write end of the response pipe, passed to the child
+	// process as file descriptor 4.
+	responsesChild *os.File
+}
+
+/*
+newPipeFiles creates the command and response pipes.
+*/
+func newPipeFiles() (*pipeFiles, error) {
+	commandsChild, commands, err := os.Pipe()
+	if nil != err {
+		return nil, errors.Wrap(err, "cannot create command pipe")
+	}
+	responses, responsesChild, err := os.Pipe()
+	if nil != err {
+		commandsChild.Close()
+		commands.Close()
+		return nil, errors.Wrap(err, "cannot create response pipe")
+	}
+	return &pipeFiles{
+		commands:       commands,
+		commandsChild:  commandsChild,
+		responses:      responses,
+		responsesChild: responsesChild,
+	}, nil
+}
+
+/*
+childFiles returns the files to pass to the child process as file descriptors 3
+and 4.
+*/
+func (pipe *pipeFiles) childFiles() []*os.File {
+	return []*os.File{pipe.commandsChild, pipe.responsesChild}
+}
+
+/*
+closeChildFiles closes the child ends of the pipes in this process once they
+have been passed to the child process.
+*/
+func (pipe *pipeFiles) closeChildFiles() {
+	pipe.commandsChild.Close()
+	pipe.responsesChild.Close()
+}
+
+/*
+close closes this process' ends of the pipes.
+*/
+func (pipe *pipeFiles) close() {
+	pipe.commands.Close()
+	pipe.responses.Close()
+}
+
+/*
+PipeMode implements Chromium.
+
+The pipe transport is used when the remote-debugging-pipe flag is set. All
+communication then happens over the browser socket, see BrowserSocket(), and
+tabs are driven by flattened target sessions instead of per-tab websockets.
+*/
+func (chrome *Chrome) PipeMode() bool {
+	return chrome.Flags().Has("remote-debugging-pipe")
+}
+
+/*
+waitForPipe waits for the browser to respond over the pipe transport.
+*/
+func (chrome *Chrome) waitForPipe() error {
+	_, err := chrome.Version()
+	return err
+}
+
+/*
+pipeVersion retrieves the browser version using the Browser.getVersion command
+instead of the /json/version endpoint.
+*/
+func (chrome *Chrome) pipeVersion() (*Version, error) {
+	browserSocket := chrome.currentBrowserSocket()
+	if nil == browserSocket {
+		return nil, errors.New("version query failed: the browser pipe is not connected")
+	}
+
+	result := <-browserSocket.Browser().WithTimeout(LaunchTimeout).GetVersion()
+	if nil != result.Err {
+		return nil, errors.Wrap(result.Err, "version query failed")
+	}
+	return &Version{
+		Browser:         result.Product,
+		ProtocolVersion: result.ProtocolVersion,
+		UserAgent:       result.UserAgent,
+		V8Version:       result.JSVersion,
+	}, nil
+}
+
+/*
+refreshPipeTabs retrieves the list of targets using the Target domain instead
+of the /json/list endpoint.
+*/
+func (chrome *Chrome) refreshPipeTabs() ([]*TabData, error) {
+	browser, err := chrome.BrowserSocket()
+	if nil != err {
+		return nil, err
+	}
+
+	result := <-browser.Target().GetTargets(nil)
+	if nil != result.Err {
+		return nil, errors.Wrap(result.Err, "target list query failed")
+	}
+
+	targets := []*TabData{}
+	for _, info := range result.Infos {
+		if "browser" == info.Type {
+			continue
+		}
+		data := &TabData{ID: string(info.ID)}
+		updateTabData(data, info)
+		targets = append(targets, data)
+	}
+	return targets, nil
+}
+
+/*
+closePipeTab closes a target using the Target domain instead of the
+/json/close endpoint.
+*/
+func (chrome *Chrome) closePipeTab(tab *Tab) (interface{}, error) {
+	browser, err := chrome.BrowserSocket()
+	if nil != err {
+		return nil, err
+	}
+
+	result := <-browser.Target().CloseTarget(&target.CloseTargetParams{
+		ID: target.ID(tab.Data().ID),
+	})
+	if nil != result.Err {
+		return nil, errors.Wrap(result.Err, "target close failed")
+	}
+	return result, nil
+}
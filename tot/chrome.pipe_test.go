@@ -0,0 +1,140 @@
+package chrome
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+)
+
+/*
+fakePipeChromeEnv is set when the test binary is started as a fake Chromium
+process that speaks the remote debugging pipe protocol.
+*/
+const fakePipeChromeEnv = "GO_CHROME_FAKE_PIPE_CHROME"
+
+func TestMain(m *testing.M) {
+	if "" != os.Getenv(fakePipeChromeEnv) {
+		fakePipeChrome()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+/*
+fakePipeChrome emulates the browser end of the remote debugging pipe on file
+descriptors 3 and 4.
+*/
+func fakePipeChrome() {
+	for _, arg := range os.Args {
+		if "--version" == arg {
+			fmt.Println("Chromium 120.0.6099.224")
+			return
+		}
+	}
+
+	commands := bufio.NewReader(os.NewFile(3, "commands"))
+	responses := os.NewFile(4, "responses")
+	results := map[string]string{
+		"Browser.getVersion": `{"product": "HeadlessChrome/120.0.6099.224", "protocolVersion": "1.3"}`,
+		"Target.closeTarget": `{"success": true}`,
+		"Target.getTargets":  `{"targetInfos": [{"targetId": "browser-id", "type": "browser"}, {"targetId": "tab-1", "type": "page", "url": "about:blank"}]}`,
+	}
+	// Targets, sessions and browser contexts are numbered in order of
+	// creation.
+	counters := map[string]int{}
+	numbered := map[string]string{
+		"Target.attachToTarget":       `{"sessionId": "session-%d"}`,
+		"Target.createBrowserContext": `{"browserContextId": "context-%d"}`,
+		"Target.createTarget":         `{"targetId": "tab-%d"}`,
+	}
+	for {
+		message, err := commands.ReadBytes(0)
+		if nil != err {
+			return
+		}
+		command := struct {
+			ID        int    `json:"id"`
+			Method    string `json:"method"`
+			SessionID string `json:"sessionId"`
+		}{}
+		json.Unmarshal(message[:len(message)-1], &command)
+
+		result, ok := results[command.Method]
+		if format, isNumbered := numbered[command.Method]; isNumbered {
+			counters[command.Method]++
+			result, ok = fmt.Sprintf(format, counters[command.Method]), true
+		}
+		if !ok {
+			result = "{}"
+		}
+		response, _ := json.Marshal(map[string]interface{}{
+			"id":        command.ID,
+			"result":    json.RawMessage(result),
+			"sessionId": command.SessionID,
+		})
+		responses.Write(append(response, 0))
+		if "Browser.close" == command.Method {
+			return
+		}
+	}
+}
+
+func TestChromiumPipeMode(t *testing.T) {
+	binary, err := os.Executable()
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	t.Setenv(fakePipeChromeEnv, "1")
+	chrome := New(
+		&Flags{
+			"remote-debugging-pipe": nil,
+			"user-data-dir":         t.TempDir(),
+		},
+		binary,
+		t.TempDir(),
+		"",
+		"",
+	)
+	if err := chrome.Launch(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	defer chrome.Close()
+
+	if chrome.Flags().Has("remote-debugging-port") {
+		t.Errorf("Expected no debugging port to be configured")
+	}
+	version, err := chrome.Version()
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if "HeadlessChrome/120.0.6099.224" != version.Browser {
+		t.Errorf("Expected 'HeadlessChrome/120.0.6099.224', received '%s'", version.Browser)
+	}
+
+	tab, err := chrome.NewTab("about:blank")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if "tab-1" != tab.Data().ID {
+		t.Errorf("Expected 'tab-1', received '%s'", tab.Data().ID)
+	}
+	if result := <-tab.Protocol().Page().Enable(); nil != result.Err {
+		t.Errorf("Expected nil, received error: '%s'", result.Err.Error())
+	}
+
+	if err := chrome.RefreshTabs(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if 1 != len(chrome.Tabs()) {
+		t.Errorf("Expected 1 tab, received %d", len(chrome.Tabs()))
+	}
+
+	if _, err := tab.Close(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if 0 != len(chrome.Tabs()) {
+		t.Errorf("Expected 0 tabs, received %d", len(chrome.Tabs()))
+	}
+}
@@ -0,0 +1,173 @@
+package chrome
+
+import (
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+/*
+CloseGracePeriod is the amount of time Close waits for Chromium to exit after
+requesting Browser.close before sending SIGTERM.
+*/
+var CloseGracePeriod = 5 * time.Second
+
+/*
+TermGracePeriod is the amount of time Close waits for Chromium to exit after
+sending SIGTERM before sending SIGKILL.
+*/
+var TermGracePeriod = 5 * time.Second
+
+/*
+ProcessOptions contains operating system attributes used to start the Chromium
+process.
+*/
+type ProcessOptions struct {
+	// Optional. NewSession starts Chromium in a new session, detached from
+	// the controlling terminal of this process. Unix only.
+	NewSession bool
+
+	// Optional. SharedProcessGroup starts Chromium in the process group of
+	// this process instead of a new one. Signals sent to this process group,
+	// e.g. by a terminal, then also reach Chromium, and Close can only signal
+	// the Chromium process rather than its whole process tree. Unix only.
+	SharedProcessGroup bool
+
+	// Optional. Sys is used as the system process attributes as is, the other
+	// options are ignored.
+	Sys *syscall.SysProcAttr
+}
+
+/*
+ProcessOptions implements Chromium.
+*/
+func (chrome *Chrome) ProcessOptions() *ProcessOptions {
+	return chrome.processOptions
+}
+
+/*
+SetProcessOptions implements Chromium.
+*/
+func (chrome *Chrome) SetProcessOptions(options *ProcessOptions) {
+	if nil == options {
+		options = &ProcessOptions{}
+	}
+	chrome.processOptions = options
+}
+
+/*
+Exited implements Chromium.
+
+The channel is created when the Chromium process is started and is closed once
+it exits, whether it was closed or it died unexpectedly; see ExitState() for
+the exit status. If the process was never started the channel is nil and never
+closes.
+*/
+func (chrome *Chrome) Exited() <-chan struct{} {
+	chrome.processMux.Lock()
+	defer chrome.processMux.Unlock()
+	return chrome.exited
+}
+
+/*
+ExitState implements Chromium.
+*/
+func (chrome *Chrome) ExitState() *os.ProcessState {
+	chrome.processMux.Lock()
+	defer chrome.processMux.Unlock()
+	return chrome.exitState
+}
+
+/*
+hasExited returns whether the launched Chromium process has exited.
+*/
+func (chrome *Chrome) hasExited() bool {
+	select {
+	case <-chrome.Exited():
+		return true
+	default:
+		return false
+	}
+}
+
+/*
+watchProcess waits for the Chromium process to exit, records the exit status
+and closes the exited channel.
+*/
+func (chrome *Chrome) watchProcess(process *os.Process, exited chan struct{}) {
+	state, err := process.Wait()
+	if nil != err {
+		log.Errorf("error waiting for Chromium process exit: %s", err)
+	}
+	chrome.processMux.Lock()
+	chrome.exitState = state
+	closing := chrome.closing
+	chrome.processMux.Unlock()
+	if !closing {
+		log.Warnf("Chromium exited unexpectedly: %s", state)
+	} else {
+		log.Infof("Chromium exited: %s", state)
+	}
+	close(exited)
+}
+
+/*
+waitForExit waits up to timeout for the Chromium process to exit and returns
+whether it did.
+*/
+func (chrome *Chrome) waitForExit(timeout time.Duration) bool {
+	select {
+	case <-chrome.Exited():
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+/*
+stopProcess shuts the Chromium process down. Browser.close is requested over
+the protocol first, then the process group is sent SIGTERM and finally SIGKILL
+if it hasn't exited within the grace periods.
+*/
+func (chrome *Chrome) stopProcess() error {
+	if nil == chrome.process || chrome.hasExited() {
+		return nil
+	}
+
+	if browserSocket, err := chrome.BrowserSocket(); nil == err {
+		// Chromium may exit before its response to Browser.close is read.
+		results := browserSocket.Browser().WithTimeout(CloseGracePeriod).Close()
+		select {
+		case <-chrome.Exited():
+			go func() { <-results }()
+			return nil
+		case result := <-results:
+			if nil == result.Err && chrome.waitForExit(CloseGracePeriod) {
+				return nil
+			}
+			if nil != result.Err {
+				log.Debugf("Browser.close failed: %s", result.Err)
+			}
+		}
+	}
+
+	log.Infof("Sending SIGTERM to Chromium process group %d", chrome.process.Pid)
+	if err := signalProcess(chrome.process, syscall.SIGTERM); nil != err {
+		log.Debugf("SIGTERM failed: %s", err)
+	}
+	if chrome.waitForExit(TermGracePeriod) {
+		return nil
+	}
+
+	log.Warnf("Sending SIGKILL to Chromium process group %d", chrome.process.Pid)
+	if err := signalProcess(chrome.process, syscall.SIGKILL); nil != err {
+		if !chrome.hasExited() {
+			return errors.Wrap(err, "chrome process kill failed")
+		}
+	}
+	<-chrome.Exited()
+	return nil
+}
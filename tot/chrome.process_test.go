@@ -0,0 +1,104 @@
+package chrome
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChromiumExited(t *testing.T) {
+	chrome, _ := launchFakeChrome(t, `printf '%d\n/devtools/browser/browser-id\n' > "$dir/DevToolsActivePort"; sleep 0.2; exit 3`)
+
+	select {
+	case <-chrome.Exited():
+		state := chrome.ExitState()
+		if nil == state {
+			t.Fatalf("Expected an exit status, received nil")
+		}
+		if 3 != state.ExitCode() {
+			t.Errorf("Expected 3, received %d", state.ExitCode())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for the process to exit")
+	}
+
+	if err := chrome.Close(); nil != err {
+		t.Errorf("Expected nil, received error: '%s'", err.Error())
+	}
+}
+
+func TestChromiumCloseKill(t *testing.T) {
+	termGracePeriod := TermGracePeriod
+	TermGracePeriod = 100 * time.Millisecond
+	defer func() { TermGracePeriod = termGracePeriod }()
+
+	// The fake process and its child ignore SIGTERM.
+	chrome, _ := launchFakeChrome(t, strings.Join([]string{
+		`trap '' TERM`,
+		`printf '%d\n/devtools/browser/browser-id\n' > "$dir/DevToolsActivePort"`,
+		`sleep 30 &`,
+		`while true; do sleep 0.1; done`,
+	}, "\n"))
+	exited := chrome.Exited()
+
+	start := time.Now()
+	if err := chrome.Close(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected Close to escalate to SIGKILL, took %s", elapsed)
+	}
+
+	select {
+	case <-exited:
+		if state := chrome.ExitState(); nil == state || state.Exited() {
+			t.Errorf("Expected the process to be killed, received %v", state)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for the process to exit")
+	}
+}
+
+func TestChromiumExitedNotLaunched(t *testing.T) {
+	chrome := New(
+		&Flags{},
+		"", //"path/to/chrome",
+		"", //"path/to/stderr",
+		"", //"path/to/stdout",
+		"", //"path/to/workdir",
+	)
+	select {
+	case <-chrome.Exited():
+		t.Errorf("Expected no exit, received %s", fmt.Sprint(chrome.ExitState()))
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestChromiumExitedRelaunch(t *testing.T) {
+	chrome, _ := launchFakeChrome(t, `printf '%d\n/devtools/browser/browser-id\n' > "$dir/DevToolsActivePort"`)
+	exited := chrome.Exited()
+	if exited != chrome.Exited() {
+		t.Errorf("Expected the same exit channel for the same process")
+	}
+	if err := chrome.Close(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	<-exited
+
+	if err := chrome.Launch(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	chrome.processMux.Lock()
+	closing := chrome.closing
+	chrome.processMux.Unlock()
+	if closing {
+		t.Errorf("Expected the closing flag to be reset by Launch")
+	}
+	if exited == chrome.Exited() {
+		t.Errorf("Expected a new exit channel for the relaunched process")
+	}
+	if nil != chrome.ExitState() {
+		t.Errorf("Expected no exit status, received %s", chrome.ExitState())
+	}
+}
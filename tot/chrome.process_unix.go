@@ -0,0 +1,52 @@
+//go:build !windows
+// +build !windows
+
+package chrome
+
+import (
+	"os"
+	"syscall"
+)
+
+/*
+processAttributes returns the system process attributes used to launch
+Chromium. By default Chromium is started in its own process group so it can be
+terminated along with its child processes.
+*/
+func processAttributes(options *ProcessOptions) *syscall.SysProcAttr {
+	if nil != options.Sys {
+		return options.Sys
+	}
+	// A session leader also leads a new process group, and can't be moved to
+	// another one.
+	if options.NewSession {
+		return &syscall.SysProcAttr{Setsid: true}
+	}
+	return &syscall.SysProcAttr{Setpgid: !options.SharedProcessGroup}
+}
+
+/*
+signalProcess sends a signal to every process in the process group led by
+process. If process doesn't lead a process group only process is signaled.
+*/
+func signalProcess(process *os.Process, signal syscall.Signal) error {
+	if err := syscall.Kill(-process.Pid, signal); syscall.ESRCH != err {
+		return err
+	}
+	return process.Signal(signal)
+}
+
+/*
+processExists returns whether a process with the specified PID is running.
+*/
+func processExists(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	process, err := os.FindProcess(pid)
+	if nil != err {
+		return false
+	}
+	err = process.Signal(syscall.Signal(0))
+	return nil == err || os.IsPermission(err) || syscall.EPERM == err
+}
@@ -0,0 +1,49 @@
+//go:build !windows
+// +build !windows
+
+package chrome
+
+import (
+	"syscall"
+	"testing"
+)
+
+func TestChromiumProcessGroup(t *testing.T) {
+	command := `printf '%d\n/devtools/browser/browser-id\n' > "$dir/DevToolsActivePort"`
+	tests := []struct {
+		options *ProcessOptions
+		leader  bool
+		session bool
+	}{
+		{&ProcessOptions{}, true, false},
+		{&ProcessOptions{SharedProcessGroup: true}, false, false},
+		{&ProcessOptions{NewSession: true}, true, true},
+	}
+	for _, test := range tests {
+		chrome, _ := newFakeChrome(t, command)
+		chrome.SetProcessOptions(test.options)
+		if err := chrome.Launch(); nil != err {
+			t.Fatalf("Expected nil, received error: '%s'", err.Error())
+		}
+
+		pid := chrome.process.Pid
+		pgid, err := syscall.Getpgid(pid)
+		if nil != err {
+			t.Fatalf("Expected nil, received error: '%s'", err.Error())
+		}
+		if test.leader != (pid == pgid) {
+			t.Errorf("%+v: expected process group leader %v, received pgid %d for pid %d", test.options, test.leader, pgid, pid)
+		}
+		sid, _, _ := syscall.RawSyscall(syscall.SYS_GETSID, uintptr(pid), 0, 0)
+		if test.session != (pid == int(sid)) {
+			t.Errorf("%+v: expected session leader %v, received sid %d for pid %d", test.options, test.session, sid, pid)
+		}
+
+		if err := chrome.Close(); nil != err {
+			t.Errorf("Expected nil, received error: '%s'", err.Error())
+		}
+		if !chrome.hasExited() {
+			t.Errorf("%+v: expected the process to be stopped", test.options)
+		}
+	}
+}
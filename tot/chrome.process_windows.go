@@ -0,0 +1,53 @@
+//go:build windows
+// +build windows
+
+package chrome
+
+import (
+	"os"
+	"syscall"
+)
+
+/*
+processAttributes returns the system process attributes used to launch
+Chromium. Process groups and sessions are not supported on Windows.
+*/
+func processAttributes(options *ProcessOptions) *syscall.SysProcAttr {
+	return options.Sys
+}
+
+/*
+signalProcess terminates the process. Process groups and signals other than
+kill are not supported on Windows.
+*/
+func signalProcess(process *os.Process, signal syscall.Signal) error {
+	return process.Kill()
+}
+
+/*
+stillActive is the exit code reported for a process that is still running.
+*/
+const stillActive = 259
+
+/*
+processExists returns whether a process with the specified PID is running.
+Signals can't be sent to processes on Windows so the process is opened and its
+exit code queried instead.
+*/
+func processExists(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	handle, err := syscall.OpenProcess(syscall.PROCESS_QUERY_INFORMATION, false, uint32(pid))
+	if nil != err {
+		// The process exists but belongs to another user.
+		return syscall.ERROR_ACCESS_DENIED == err
+	}
+	defer syscall.CloseHandle(handle)
+
+	var code uint32
+	if err := syscall.GetExitCodeProcess(handle, &code); nil != err {
+		return true
+	}
+	return stillActive == code
+}
@@ -0,0 +1,190 @@
+package chrome
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+/*
+profilePrefix prefixes the names of temporary profile directories created in
+the working directory.
+*/
+const profilePrefix = "profile-"
+
+/*
+profileOwnerFile is the name of the file in a temporary profile directory that
+records the PID of the process that created it.
+*/
+const profileOwnerFile = ".go-chrome-owner"
+
+/*
+profileSkipFiles lists lock and state files that are not copied from a profile
+template.
+*/
+var profileSkipFiles = map[string]bool{
+	"DevToolsActivePort": true,
+	"SingletonCookie":    true,
+	"SingletonLock":      true,
+	"SingletonSocket":    true,
+}
+
+/*
+ProfileDir implements Chromium.
+*/
+func (chrome *Chrome) ProfileDir() string {
+	return chrome.profileDir
+}
+
+/*
+ProfileTemplate implements Chromium.
+*/
+func (chrome *Chrome) ProfileTemplate() string {
+	return chrome.profileTemplate
+}
+
+/*
+SetProfileTemplate implements Chromium.
+*/
+func (chrome *Chrome) SetProfileTemplate(dir string) {
+	chrome.profileTemplate = dir
+}
+
+/*
+createProfile creates a temporary profile directory in the working directory,
+seeds it from the profile template if one is set and uses it as the
+user-data-dir. Temporary profiles left behind by processes that no longer exist
+are removed first.
+*/
+func (chrome *Chrome) createProfile() error {
+	removeStaleProfiles(chrome.Workdir())
+
+	dir, err := ioutil.TempDir(chrome.Workdir(), profilePrefix)
+	if nil != err {
+		return errors.Wrap(err, fmt.Sprintf("cannot create profile directory in '%s'", chrome.Workdir()))
+	}
+	chrome.profileDir = dir
+
+	err = ioutil.WriteFile(
+		filepath.Join(dir, profileOwnerFile),
+		[]byte(strconv.Itoa(os.Getpid())),
+		0600,
+	)
+	if nil != err {
+		chrome.removeProfile()
+		return errors.Wrap(err, fmt.Sprintf("cannot write profile owner file in '%s'", dir))
+	}
+
+	if "" != chrome.ProfileTemplate() {
+		if err := copyProfile(chrome.ProfileTemplate(), dir); nil != err {
+			chrome.removeProfile()
+			return errors.Wrap(err, fmt.Sprintf("cannot copy profile template '%s'", chrome.ProfileTemplate()))
+		}
+	}
+
+	chrome.Flags().Set("user-data-dir", dir)
+	return nil
+}
+
+/*
+removeProfile removes the temporary profile directory, if one was created, and
+the user-data-dir flag pointing to it so a new profile is created if Chromium is
+launched again.
+*/
+func (chrome *Chrome) removeProfile() {
+	if "" == chrome.profileDir {
+		return
+	}
+	if err := os.RemoveAll(chrome.profileDir); nil != err {
+		log.Warnf("could not remove profile directory '%s': %s", chrome.profileDir, err)
+		return
+	}
+	if dir, err := chrome.Flags().Get("user-data-dir"); nil == err && chrome.profileDir == dir {
+		chrome.Flags().Delete("user-data-dir")
+	}
+	chrome.profileDir = ""
+}
+
+/*
+removeStaleProfiles removes temporary profile directories in workdir that were
+created by processes that are no longer running.
+*/
+func removeStaleProfiles(workdir string) {
+	dirs, err := filepath.Glob(filepath.Join(workdir, profilePrefix+"*"))
+	if nil != err {
+		return
+	}
+	for _, dir := range dirs {
+		content, err := ioutil.ReadFile(filepath.Join(dir, profileOwnerFile))
+		if nil != err {
+			continue
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+		if nil != err || processExists(pid) {
+			continue
+		}
+		log.Debugf("removing stale profile directory '%s'", dir)
+		os.RemoveAll(dir)
+	}
+}
+
+/*
+copyProfile recursively copies a profile template directory.
+*/
+func copyProfile(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if nil != err {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if nil != err {
+			return err
+		}
+		if profileSkipFiles[info.Name()] {
+			return nil
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		case 0 != info.Mode()&os.ModeSymlink:
+			link, err := os.Readlink(path)
+			if nil != err {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			return copyFile(path, target, info.Mode().Perm())
+		}
+		return nil
+	})
+}
+
+/*
+copyFile copies a single file.
+*/
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if nil != err {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if nil != err {
+		return err
+	}
+	if _, err = io.Copy(out, in); nil != err {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,163 @@
+package chrome
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChromiumProfile(t *testing.T) {
+	template := t.TempDir()
+	os.MkdirAll(filepath.Join(template, "Default"), 0700)
+	ioutil.WriteFile(filepath.Join(template, "Default", "Preferences"), []byte("{}"), 0600)
+	ioutil.WriteFile(filepath.Join(template, "SingletonLock"), []byte("lock"), 0600)
+
+	workdir := t.TempDir()
+	chrome := New(
+		&Flags{},
+		"",
+		workdir,
+		"",
+		"",
+	)
+	chrome.SetProfileTemplate(template)
+	if template != chrome.ProfileTemplate() {
+		t.Errorf("Expected '%s', received '%s'", template, chrome.ProfileTemplate())
+	}
+
+	if err := chrome.createProfile(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	profile := chrome.ProfileDir()
+	if workdir != filepath.Dir(profile) {
+		t.Errorf("Expected a profile in '%s', received '%s'", workdir, profile)
+	}
+	if profile != chrome.userDataDir() {
+		t.Errorf("Expected '%s', received '%s'", profile, chrome.userDataDir())
+	}
+	if _, err := os.Stat(filepath.Join(profile, "Default", "Preferences")); nil != err {
+		t.Errorf("Expected the template to be copied: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(profile, "SingletonLock")); nil == err {
+		t.Errorf("Expected lock files not to be copied")
+	}
+
+	chrome.Close()
+	if _, err := os.Stat(profile); !os.IsNotExist(err) {
+		t.Errorf("Expected the profile to be removed")
+	}
+	if chrome.Flags().Has("user-data-dir") {
+		t.Errorf("Expected the user-data-dir flag to be removed")
+	}
+}
+
+func TestChromiumLaunchProfile(t *testing.T) {
+	server, _ := newVersionServer("ws://localhost/devtools/browser/browser-id")
+	defer server.Close()
+	port := strings.TrimPrefix(server.URL, "http://127.0.0.1:")
+
+	workdir := t.TempDir()
+	chrome := New(
+		&Flags{
+			"addr":                  "127.0.0.1",
+			"remote-debugging-port": 0,
+		},
+		writeFakeChrome(t, `printf '`+port+`\n/devtools/browser/browser-id\n' > "$dir/DevToolsActivePort"`),
+		workdir,
+		"",
+		"",
+	)
+	if err := chrome.Launch(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	profile := chrome.ProfileDir()
+	if "" == profile {
+		t.Fatalf("Expected a temporary profile to be created")
+	}
+
+	chrome.Close()
+	if _, err := os.Stat(profile); !os.IsNotExist(err) {
+		t.Errorf("Expected the profile to be removed")
+	}
+
+	if err := chrome.Launch(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	relaunched := chrome.ProfileDir()
+	chrome.Close()
+	if "" == relaunched || profile == relaunched {
+		t.Errorf("Expected a new temporary profile, received '%s'", relaunched)
+	}
+	if _, err := os.Stat(relaunched); !os.IsNotExist(err) {
+		t.Errorf("Expected the profile to be removed")
+	}
+}
+
+func TestChromiumCloseProfileStopError(t *testing.T) {
+	// A process that has already been waited for can't be signalled.
+	cmd := exec.Command("true")
+	if err := cmd.Run(); nil != err {
+		t.Skipf("cannot run a short-lived process: %s", err)
+	}
+	termGracePeriod := TermGracePeriod
+	TermGracePeriod = 10 * time.Millisecond
+	defer func() { TermGracePeriod = termGracePeriod }()
+
+	chrome := New(
+		&Flags{
+			"addr": "127.0.0.1",
+			"port": 1,
+		},
+		"",
+		t.TempDir(),
+		"",
+		"",
+	)
+	if err := chrome.createProfile(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	profile := chrome.ProfileDir()
+	chrome.process = cmd.Process
+	chrome.exited = make(chan struct{})
+
+	if err := chrome.Close(); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+	if _, err := os.Stat(profile); !os.IsNotExist(err) {
+		t.Errorf("Expected the profile to be removed")
+	}
+}
+
+func TestRemoveStaleProfiles(t *testing.T) {
+	// Obtain the PID of a process that is no longer running.
+	cmd := exec.Command("true")
+	if err := cmd.Run(); nil != err {
+		t.Skipf("cannot run a short-lived process: %s", err)
+	}
+	deadPID := cmd.Process.Pid
+
+	workdir := t.TempDir()
+	stale := filepath.Join(workdir, profilePrefix+"stale")
+	live := filepath.Join(workdir, profilePrefix+"live")
+	other := filepath.Join(workdir, "other")
+	for dir, pid := range map[string]int{stale: deadPID, live: os.Getpid(), other: deadPID} {
+		os.MkdirAll(dir, 0700)
+		ioutil.WriteFile(filepath.Join(dir, profileOwnerFile), []byte(strconv.Itoa(pid)), 0600)
+	}
+
+	removeStaleProfiles(workdir)
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("Expected the stale profile to be removed")
+	}
+	if _, err := os.Stat(live); nil != err {
+		t.Errorf("Expected the live profile to be kept")
+	}
+	if _, err := os.Stat(other); nil != err {
+		t.Errorf("Expected unrelated directories to be kept")
+	}
+}
@@ -0,0 +1,236 @@
+package chrome
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/target"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+/*
+destroyedTargetRetention is how long the ID of a destroyed target is kept to
+ignore events for it that are handled late.
+*/
+var destroyedTargetRetention = 30 * time.Second
+
+/*
+TabEventType describes a change to the list of open tabs.
+*/
+type TabEventType string
+
+const (
+	// TabCreated is emitted when a new target is discovered.
+	TabCreated TabEventType = "created"
+
+	// TabChanged is emitted when the metadata of a tab, such as its title or
+	// URL, changes.
+	TabChanged TabEventType = "changed"
+
+	// TabDestroyed is emitted when the target of a tab is closed.
+	TabDestroyed TabEventType = "destroyed"
+)
+
+/*
+TabEvent describes a tab lifecycle event.
+*/
+type TabEvent struct {
+	// The affected tab.
+	Tab *Tab
+
+	// The type of change.
+	Type TabEventType
+}
+
+/*
+OnTabEvent implements Chromium.
+*/
+func (chrome *Chrome) OnTabEvent(callback func(event *TabEvent)) {
+	chrome.tabMux.Lock()
+	defer chrome.tabMux.Unlock()
+	chrome.tabHandlers = append(chrome.tabHandlers, callback)
+}
+
+/*
+WatchTabs implements Chromium.
+
+Target discovery is enabled on the browser socket. Chromium reports all existing
+targets as created when discovery starts so the tab list is populated as well as
+kept in sync. Calling WatchTabs more than once has no additional effect.
+*/
+func (chrome *Chrome) WatchTabs() error {
+	browser, err := chrome.BrowserSocket()
+	if nil != err {
+		return errors.Wrap(err, "could not watch tabs")
+	}
+
+	chrome.tabMux.Lock()
+	if chrome.watchingTabs {
+		chrome.tabMux.Unlock()
+		return nil
+	}
+	chrome.watchingTabs = true
+	chrome.tabMux.Unlock()
+
+	browser.Target().OnTargetCreated(func(event *target.CreatedEvent) {
+		if nil != event.Err || nil == event.Info {
+			return
+		}
+		chrome.targetCreated(event.Info)
+	})
+	browser.Target().OnTargetDestroyed(func(event *target.DestroyedEvent) {
+		if nil != event.Err {
+			return
+		}
+		chrome.targetDestroyed(event.ID)
+	})
+	browser.Target().OnTargetInfoChanged(func(event *target.InfoChangedEvent) {
+		if nil != event.Err || nil == event.Info {
+			return
+		}
+		chrome.targetInfoChanged(event.Info)
+	})
+
+	result := <-browser.Target().SetDiscoverTargets(&target.SetDiscoverTargetsParams{
+		Discover: true,
+	})
+	if nil != result.Err {
+		chrome.tabMux.Lock()
+		chrome.watchingTabs = false
+		chrome.tabMux.Unlock()
+		return errors.Wrap(result.Err, "target discovery failed")
+	}
+
+	return nil
+}
+
+/*
+emitTabEvent passes a tab lifecycle event to all registered callbacks.
+*/
+func (chrome *Chrome) emitTabEvent(tab *Tab, eventType TabEventType) {
+	chrome.tabMux.Lock()
+	handlers := chrome.tabHandlers
+	chrome.tabMux.Unlock()
+
+	event := &TabEvent{
+		Tab:  tab,
+		Type: eventType,
+	}
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+/*
+targetCreated adds a tab for a newly discovered page target. Other targets,
+such as service workers and iframes, are ignored.
+
+Handlers for different events may run concurrently so target events are not
+guaranteed to arrive in order. Targets that have recently been reported as
+destroyed are ignored, including targets destroyed while the tab connects.
+*/
+func (chrome *Chrome) targetCreated(info *target.Info) {
+	if "page" != info.Type {
+		return
+	}
+
+	chrome.tabMux.Lock()
+	destroyed := chrome.destroyedTargets[string(info.ID)]
+	chrome.tabMux.Unlock()
+	if destroyed {
+		return
+	}
+	if _, err := chrome.GetTab(string(info.ID)); nil == err {
+		return
+	}
+
+	targetURL, err := url.Parse(info.URL)
+	if nil != err {
+		log.Warnf("target %s: invalid URL '%s': %s", info.ID, info.URL, err)
+		return
+	}
+	tab := &Tab{
+		chrome: chrome,
+		data: &TabData{
+			ID: string(info.ID),
+		},
+		url: targetURL,
+	}
+	if !chrome.PipeMode() {
+		tab.Data().WebSocketDebuggerURL = chrome.pageWebSocketURL(string(info.ID))
+	}
+	updateTabData(tab.Data(), info)
+	if err := tab.connect(); nil != err {
+		log.Warnf("target %s: %s", info.ID, err)
+		return
+	}
+	if added := chrome.addTargetTab(tab); added != tab {
+		tab.Socket().Stop()
+		return
+	}
+
+	chrome.emitTabEvent(tab, TabCreated)
+}
+
+/*
+addTargetTab adds the tab for a newly discovered target. Nil is returned if the
+target has been destroyed in the meantime, otherwise see addTab().
+*/
+func (chrome *Chrome) addTargetTab(tab *Tab) *Tab {
+	chrome.tabMux.Lock()
+	defer chrome.tabMux.Unlock()
+	if chrome.destroyedTargets[tab.Data().ID] {
+		return nil
+	}
+	return chrome.appendTab(tab)
+}
+
+/*
+targetDestroyed removes the tab for a closed target. The target ID is forgotten
+after destroyedTargetRetention.
+*/
+func (chrome *Chrome) targetDestroyed(targetID target.ID) {
+	chrome.tabMux.Lock()
+	chrome.destroyedTargets[string(targetID)] = true
+	chrome.tabMux.Unlock()
+	time.AfterFunc(destroyedTargetRetention, func() {
+		chrome.tabMux.Lock()
+		delete(chrome.destroyedTargets, string(targetID))
+		chrome.tabMux.Unlock()
+	})
+
+	existing, err := chrome.GetTab(string(targetID))
+	if nil != err {
+		return
+	}
+	tab := existing.(*Tab)
+	chrome.removeTab(tab)
+	tab.Socket().Stop()
+	chrome.emitTabEvent(tab, TabDestroyed)
+}
+
+/*
+targetInfoChanged updates the metadata of the tab for a changed target. A tab is
+created if the target has not been reported yet.
+*/
+func (chrome *Chrome) targetInfoChanged(info *target.Info) {
+	existing, err := chrome.GetTab(string(info.ID))
+	if nil != err {
+		chrome.targetCreated(info)
+		return
+	}
+	data := *existing.Data()
+	updateTabData(&data, info)
+	existing.(*Tab).setData(&data)
+	chrome.emitTabEvent(existing.(*Tab), TabChanged)
+}
+
+/*
+updateTabData copies target metadata into a TabData struct.
+*/
+func updateTabData(data *TabData, info *target.Info) {
+	data.Title = info.Title
+	data.Type = info.Type
+	data.URL = info.URL
+}
@@ -0,0 +1,204 @@
+package chrome
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+/*
+newBrowserServer returns a test server that emulates the developer tools
+endpoints of a browser. Every command sent to the browser socket receives an
+empty result and is followed by the provided event messages, which are spaced out
+so they are handled in order.
+*/
+func newBrowserServer(events ...string) (*httptest.Server, *Chrome) {
+	var server *httptest.Server
+	upgrader := websocket.Upgrader{}
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if "/json/version" == r.URL.Path {
+			fmt.Fprintf(
+				w,
+				`{"Browser": "HeadlessChrome/66.0.3359.117", "webSocketDebuggerUrl": "ws://%s/devtools/browser/browser-id"}`,
+				strings.TrimPrefix(server.URL, "http://"),
+			)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if nil != err {
+			return
+		}
+		defer conn.Close()
+		for {
+			command := map[string]interface{}{}
+			if err := conn.ReadJSON(&command); nil != err {
+				return
+			}
+			if !strings.HasPrefix(r.URL.Path, "/devtools/browser/") {
+				continue
+			}
+			conn.WriteJSON(map[string]interface{}{"id": command["id"], "result": json.RawMessage("{}")})
+			for _, event := range events {
+				time.Sleep(20 * time.Millisecond)
+				conn.WriteMessage(websocket.TextMessage, []byte(event))
+			}
+		}
+	}))
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+	chrome := New(
+		&Flags{
+			"addr": serverURL.Hostname(),
+			"port": port,
+		},
+		"", //"path/to/chrome",
+		"", //"path/to/stderr",
+		"", //"path/to/stdout",
+		"", //"path/to/workdir",
+	)
+	return server, chrome
+}
+
+func TestChromiumWatchTabs(t *testing.T) {
+	server, chrome := newBrowserServer(
+		`{"method": "Target.targetCreated", "params": {"targetInfo": {"targetId": "browser-id", "type": "browser"}}}`,
+		`{"method": "Target.targetCreated", "params": {"targetInfo": {"targetId": "tab-1", "type": "page", "title": "one", "url": "about:blank"}}}`,
+		`{"method": "Target.targetCreated", "params": {"targetInfo": {"targetId": "worker-1", "type": "service_worker", "url": "https://example.com/sw.js"}}}`,
+		`{"method": "Target.targetCreated", "params": {"targetInfo": {"targetId": "frame-1", "type": "iframe", "url": "https://example.com/frame"}}}`,
+		`{"method": "Target.targetCreated", "params": {"targetInfo": {"targetId": "tab-2", "type": "page", "title": "two", "url": "about:blank"}}}`,
+		`{"method": "Target.targetInfoChanged", "params": {"targetInfo": {"targetId": "tab-1", "type": "page", "title": "updated", "url": "https://example.com/"}}}`,
+		`{"method": "Target.targetDestroyed", "params": {"targetId": "tab-2"}}`,
+	)
+	defer server.Close()
+	defer chrome.Close()
+
+	events := make(chan *TabEvent, 10)
+	chrome.OnTabEvent(func(event *TabEvent) {
+		events <- event
+	})
+
+	if err := chrome.WatchTabs(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+
+	expected := []struct {
+		id        string
+		eventType TabEventType
+	}{
+		{"tab-1", TabCreated},
+		{"tab-2", TabCreated},
+		{"tab-1", TabChanged},
+		{"tab-2", TabDestroyed},
+	}
+	for _, exp := range expected {
+		select {
+		case event := <-events:
+			if exp.id != event.Tab.Data().ID || exp.eventType != event.Type {
+				t.Errorf("Expected %s %s, received %s %s", exp.id, exp.eventType, event.Tab.Data().ID, event.Type)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for %s %s", exp.id, exp.eventType)
+		}
+	}
+
+	if 1 != len(chrome.Tabs()) {
+		t.Fatalf("Expected 1 tab, received %d", len(chrome.Tabs()))
+	}
+	tab, err := chrome.GetTab("tab-1")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if "updated" != tab.Data().Title {
+		t.Errorf("Expected 'updated', received '%s'", tab.Data().Title)
+	}
+	if "https://example.com/" != tab.Data().URL {
+		t.Errorf("Expected 'https://example.com/', received '%s'", tab.Data().URL)
+	}
+	if !strings.HasSuffix(tab.Data().WebSocketDebuggerURL, "/devtools/page/tab-1") {
+		t.Errorf("Expected a page websocket URL, received '%s'", tab.Data().WebSocketDebuggerURL)
+	}
+}
+
+func TestChromiumTargetDestroyedRetention(t *testing.T) {
+	retention := destroyedTargetRetention
+	destroyedTargetRetention = 10 * time.Millisecond
+	defer func() { destroyedTargetRetention = retention }()
+
+	chrome := New(
+		&Flags{},
+		"", //"path/to/chrome",
+		"", //"path/to/stderr",
+		"", //"path/to/stdout",
+		"", //"path/to/workdir",
+	)
+	chrome.targetDestroyed("tab-1")
+	chrome.tabMux.Lock()
+	destroyed := chrome.destroyedTargets["tab-1"]
+	chrome.tabMux.Unlock()
+	if !destroyed {
+		t.Errorf("Expected the destroyed target to be recorded")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	chrome.tabMux.Lock()
+	remaining := len(chrome.destroyedTargets)
+	chrome.tabMux.Unlock()
+	if 0 != remaining {
+		t.Errorf("Expected 0 destroyed targets, received %d", remaining)
+	}
+}
+
+func TestChromiumAddTargetTabDestroyed(t *testing.T) {
+	chrome := New(
+		&Flags{},
+		"", //"path/to/chrome",
+		"", //"path/to/stderr",
+		"", //"path/to/stdout",
+		"", //"path/to/workdir",
+	)
+	tab := &Tab{
+		chrome: chrome,
+		data:   &TabData{ID: "tab-1"},
+	}
+
+	// The target is destroyed while its tab is connecting.
+	chrome.targetDestroyed("tab-1")
+	if added := chrome.addTargetTab(tab); nil != added {
+		t.Errorf("Expected nil, received tab %s", added.Data().ID)
+	}
+	if 0 != len(chrome.Tabs()) {
+		t.Errorf("Expected 0 tabs, received %d", len(chrome.Tabs()))
+	}
+
+	tab = &Tab{
+		chrome: chrome,
+		data:   &TabData{ID: "tab-2"},
+	}
+	if added := chrome.addTargetTab(tab); added != tab {
+		t.Errorf("Expected the tab to be added")
+	}
+}
+
+func TestChromiumWatchTabsError(t *testing.T) {
+	chrome := New(
+		&Flags{
+			"addr": "devnul",
+			"port": 9222,
+		},
+		"", //"path/to/chrome",
+		"", //"path/to/stderr",
+		"", //"path/to/stdout",
+		"", //"path/to/workdir",
+	)
+	if err := chrome.WatchTabs(); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
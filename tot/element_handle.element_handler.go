@@ -0,0 +1,430 @@
+package chrome
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/dom"
+	"github.com/mkenney/go-chrome/tot/cdtp/page"
+	"github.com/mkenney/go-chrome/tot/cdtp/runtime"
+	"github.com/pkg/errors"
+)
+
+/*
+BoundingBox is a rectangle in CSS pixels.
+*/
+type BoundingBox struct {
+	// Height is the height of the rectangle.
+	Height float64
+
+	// Width is the width of the rectangle.
+	Width float64
+
+	// X is the horizontal offset of the top left corner.
+	X float64
+
+	// Y is the vertical offset of the top left corner.
+	Y float64
+}
+
+/*
+newElementHandle returns a handle for a remote object, or nil if the object
+isn't an element.
+*/
+func newElementHandle(tab *Tab, object *runtime.RemoteObject) *ElementHandle {
+	if nil == object || "" == object.ObjectID || runtime.ObjectSubtype.Node != object.Subtype {
+		return nil
+	}
+	return &ElementHandle{
+		objectID: object.ObjectID,
+		tab:      tab,
+	}
+}
+
+/*
+ElementHandle is a struct representing a DOM element in a tab.
+*/
+type ElementHandle struct {
+	objectGroup string
+	objectID    runtime.RemoteObjectID
+	tab         *Tab
+}
+
+/*
+Attribute implements ElementHandler.
+*/
+func (handle *ElementHandle) Attribute(ctx context.Context, name string) (string, bool, error) {
+	var value *string
+	err := handle.callFunctionValue(
+		ctx,
+		`function(name) { return this.hasAttribute(name) ? this.getAttribute(name) : null }`,
+		&value,
+		name,
+	)
+	if nil != err || nil == value {
+		return "", false, err
+	}
+	return *value, true, nil
+}
+
+/*
+BoundingBox implements ElementHandler.
+*/
+func (handle *ElementHandle) BoundingBox(ctx context.Context) (*BoundingBox, error) {
+	result := <-handle.tab.Protocol().DOM().WithContext(ctx).GetBoxModel(&dom.GetBoxModelParams{
+		ObjectID: handle.objectID,
+	})
+	if nil != result.Err {
+		return nil, errors.Wrap(result.Err, "could not get the element box model")
+	}
+	if nil == result.Model {
+		return nil, errors.New("element has no box model")
+	}
+	return quadBoundingBox(result.Model.Border), nil
+}
+
+/*
+Click implements ElementHandler.
+*/
+func (handle *ElementHandle) Click(ctx context.Context) error {
+	if err := handle.scrollIntoView(ctx); nil != err {
+		return err
+	}
+	box, err := handle.BoundingBox(ctx)
+	if nil != err {
+		return err
+	}
+	return handle.tab.Mouse().Click(ctx, box.X+box.Width/2, box.Y+box.Height/2)
+}
+
+/*
+Focus implements ElementHandler.
+*/
+func (handle *ElementHandle) Focus(ctx context.Context) error {
+	result := <-handle.tab.Protocol().DOM().WithContext(ctx).Focus(&dom.FocusParams{
+		ObjectID: handle.objectID,
+	})
+	if nil != result.Err {
+		return errors.Wrap(result.Err, "could not focus the element")
+	}
+	return nil
+}
+
+/*
+NodeID implements ElementHandler.
+
+DOM node IDs are only assigned to nodes that have been pushed to the client,
+the document is requested once per document, see Tab.nodeID().
+*/
+func (handle *ElementHandle) NodeID(ctx context.Context) (dom.NodeID, error) {
+	return handle.tab.nodeID(ctx, handle.objectID)
+}
+
+/*
+ObjectID implements ElementHandler.
+*/
+func (handle *ElementHandle) ObjectID() runtime.RemoteObjectID {
+	return handle.objectID
+}
+
+/*
+QuerySelector implements ElementHandler.
+*/
+func (handle *ElementHandle) QuerySelector(ctx context.Context, selector string) (*ElementHandle, error) {
+	engine, selector, err := parseSelector(selector)
+	if nil != err {
+		return nil, err
+	}
+	return engine.Query(ctx, handle, selector)
+}
+
+/*
+QuerySelectorAll implements ElementHandler.
+*/
+func (handle *ElementHandle) QuerySelectorAll(ctx context.Context, selector string) ([]*ElementHandle, error) {
+	engine, selector, err := parseSelector(selector)
+	if nil != err {
+		return nil, err
+	}
+	return engine.QueryAll(ctx, handle, selector)
+}
+
+/*
+Release implements ElementHandler.
+*/
+func (handle *ElementHandle) Release(ctx context.Context) error {
+	result := <-handle.tab.Protocol().Runtime().WithContext(ctx).ReleaseObject(&runtime.ReleaseObjectParams{
+		ObjectID: handle.objectID,
+	})
+	if nil != result.Err {
+		return errors.Wrap(result.Err, "could not release the element")
+	}
+	return nil
+}
+
+/*
+ScreenshotElement implements ElementHandler.
+
+The element is scrolled into view and the screenshot is clipped to its bounding
+client rectangle in page coordinates.
+*/
+func (handle *ElementHandle) ScreenshotElement(ctx context.Context) ([]byte, error) {
+	if err := handle.scrollIntoView(ctx); nil != err {
+		return nil, err
+	}
+	box := &BoundingBox{}
+	err := handle.callFunctionValue(
+		ctx,
+		`function() {
+			const rect = this.getBoundingClientRect()
+			return {X: rect.left + window.scrollX, Y: rect.top + window.scrollY, Width: rect.width, Height: rect.height}
+		}`,
+		box,
+	)
+	if nil != err {
+		return nil, err
+	}
+	if 0 == box.Width || 0 == box.Height {
+		return nil, errors.New("element is not visible")
+	}
+
+	x := math.Floor(box.X)
+	y := math.Floor(box.Y)
+	result := <-handle.tab.Protocol().Page().WithContext(ctx).CaptureScreenshot(&page.CaptureScreenshotParams{
+		Clip: &page.Viewport{
+			X:      int(x),
+			Y:      int(y),
+			Width:  int(math.Ceil(box.X + box.Width - x)),
+			Height: int(math.Ceil(box.Y + box.Height - y)),
+			Scale:  1,
+		},
+	})
+	if nil != result.Err {
+		return nil, errors.Wrap(result.Err, "could not capture the screenshot")
+	}
+	data, err := base64.StdEncoding.DecodeString(result.Data)
+	if nil != err {
+		return nil, errors.Wrap(err, "could not decode the screenshot")
+	}
+	return data, nil
+}
+
+/*
+Tab implements ElementHandler.
+*/
+func (handle *ElementHandle) Tab() *Tab {
+	return handle.tab
+}
+
+/*
+Text implements ElementHandler.
+*/
+func (handle *ElementHandle) Text(ctx context.Context) (string, error) {
+	text := ""
+	err := handle.callFunctionValue(ctx, `function() { return this.textContent || "" }`, &text)
+	return text, err
+}
+
+/*
+Type implements ElementHandler.
+*/
+func (handle *ElementHandle) Type(ctx context.Context, text string) error {
+	if err := handle.Focus(ctx); nil != err {
+		return err
+	}
+	return handle.tab.Keyboard().Type(ctx, text)
+}
+
+/*
+callFunction calls a JavaScript function with the element as this. The result
+is added to the tab's object group.
+*/
+func (handle *ElementHandle) callFunction(
+	ctx context.Context,
+	declaration string,
+	returnByValue bool,
+	args ...interface{},
+) (*runtime.RemoteObject, error) {
+	arguments := make([]*runtime.CallArgument, 0, len(args))
+	for _, arg := range args {
+		arguments = append(arguments, &runtime.CallArgument{Value: arg})
+	}
+	result := <-handle.tab.Protocol().Runtime().WithContext(ctx).CallFunctionOn(&runtime.CallFunctionOnParams{
+		Arguments:           arguments,
+		AwaitPromise:        true,
+		FunctionDeclaration: declaration,
+		ObjectGroup:         handle.group(),
+		ObjectID:            handle.objectID,
+		ReturnByValue:       returnByValue,
+	})
+	if nil != result.Err {
+		return nil, errors.Wrap(result.Err, "could not call function on the element")
+	}
+	if nil != result.ExceptionDetails {
+		return nil, exceptionError(result.ExceptionDetails)
+	}
+	return result.Result, nil
+}
+
+/*
+callFunctionValue calls a JavaScript function with the element as this and
+decodes the returned value into value.
+*/
+func (handle *ElementHandle) callFunctionValue(
+	ctx context.Context,
+	declaration string,
+	value interface{},
+	args ...interface{},
+) error {
+	object, err := handle.callFunction(ctx, declaration, true, args...)
+	if nil != err {
+		return err
+	}
+	if nil == object {
+		return nil
+	}
+	data, err := json.Marshal(object.Value)
+	if nil != err {
+		return errors.Wrap(err, "could not encode the function result")
+	}
+	if err := json.Unmarshal(data, value); nil != err {
+		return errors.Wrap(err, "could not decode the function result")
+	}
+	return nil
+}
+
+/*
+group returns the object group remote objects obtained from the element are
+added to. Handles created while polling use a temporary group, all others the
+element group of the tab.
+*/
+func (handle *ElementHandle) group() string {
+	if "" != handle.objectGroup {
+		return handle.objectGroup
+	}
+	return handle.tab.elementGroup()
+}
+
+/*
+derive returns a handle for a remote object obtained from the element, or nil
+if the object isn't an element. The handle uses the same object group.
+*/
+func (handle *ElementHandle) derive(object *runtime.RemoteObject) *ElementHandle {
+	element := newElementHandle(handle.tab, object)
+	if nil != element {
+		element.objectGroup = handle.objectGroup
+	}
+	return element
+}
+
+/*
+queryOne calls a JavaScript function with the element as this that returns an
+element or null.
+*/
+func (handle *ElementHandle) queryOne(ctx context.Context, declaration string, args ...interface{}) (*ElementHandle, error) {
+	object, err := handle.callFunction(ctx, declaration, false, args...)
+	if nil != err {
+		return nil, err
+	}
+	return handle.derive(object), nil
+}
+
+/*
+queryAll calls a JavaScript function with the element as this that returns an
+array of elements.
+*/
+func (handle *ElementHandle) queryAll(ctx context.Context, declaration string, args ...interface{}) ([]*ElementHandle, error) {
+	array, err := handle.callFunction(ctx, declaration, false, args...)
+	if nil != err {
+		return nil, err
+	}
+	if nil == array || "" == array.ObjectID {
+		return []*ElementHandle{}, nil
+	}
+	result := <-handle.tab.Protocol().Runtime().WithContext(ctx).GetProperties(&runtime.GetPropertiesParams{
+		ObjectID:      array.ObjectID,
+		OwnProperties: true,
+	})
+	<-handle.tab.Protocol().Runtime().WithContext(ctx).ReleaseObject(&runtime.ReleaseObjectParams{
+		ObjectID: array.ObjectID,
+	})
+	if nil != result.Err {
+		return nil, errors.Wrap(result.Err, "could not get the matching elements")
+	}
+
+	indexes := []int{}
+	elements := map[int]*ElementHandle{}
+	for _, property := range result.Result {
+		index, err := strconv.Atoi(property.Name)
+		if nil != err {
+			continue
+		}
+		if element := handle.derive(property.Value); nil != element {
+			indexes = append(indexes, index)
+			elements[index] = element
+		}
+	}
+	sort.Ints(indexes)
+	handles := make([]*ElementHandle, 0, len(indexes))
+	for _, index := range indexes {
+		handles = append(handles, elements[index])
+	}
+	return handles, nil
+}
+
+/*
+isVisible returns whether the element has a non-empty bounding box and its
+visibility isn't hidden.
+*/
+func (handle *ElementHandle) isVisible(ctx context.Context) (bool, error) {
+	visible := false
+	err := handle.callFunctionValue(ctx, `function() { return (`+elementVisibleFunction+`)(this) }`, &visible)
+	return visible, err
+}
+
+/*
+scrollIntoView scrolls the element into the center of the viewport.
+*/
+func (handle *ElementHandle) scrollIntoView(ctx context.Context) error {
+	_, err := handle.callFunction(
+		ctx,
+		`function() { this.scrollIntoView({block: "center", inline: "center", behavior: "instant"}) }`,
+		true,
+	)
+	return err
+}
+
+/*
+exceptionError returns an error describing a JavaScript exception.
+*/
+func exceptionError(details *runtime.ExceptionDetails) error {
+	if nil != details.Exception && "" != details.Exception.Description {
+		return fmt.Errorf("javascript exception: %s", details.Exception.Description)
+	}
+	return fmt.Errorf("javascript exception: %s", details.Text)
+}
+
+/*
+quadBoundingBox returns the bounding box of a quad.
+*/
+func quadBoundingBox(quad dom.Quad) *BoundingBox {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for i := 0; i < len(quad); i += 2 {
+		minX = math.Min(minX, quad[i])
+		maxX = math.Max(maxX, quad[i])
+		minY = math.Min(minY, quad[i+1])
+		maxY = math.Max(maxY, quad[i+1])
+	}
+	return &BoundingBox{
+		Height: maxY - minY,
+		Width:  maxX - minX,
+		X:      minX,
+		Y:      minY,
+	}
+}
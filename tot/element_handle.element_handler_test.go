@@ -0,0 +1,290 @@
+package chrome
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/dom"
+)
+
+/*
+pageCommand is a command received by the test page socket.
+*/
+type pageCommand struct {
+	Method string
+	Params map[string]interface{}
+}
+
+/*
+newElementTab returns a tab connected to a test page socket that emulates a
+document with a single link element, and the list of commands it received.
+*/
+func newElementTab(t *testing.T) (*Tab, func() []pageCommand) {
+	mux := &sync.Mutex{}
+	commands := []pageCommand{}
+	tab := newPageTab(t, func(method string, raw json.RawMessage) (string, []string) {
+		params := map[string]interface{}{}
+		json.Unmarshal(raw, &params)
+		mux.Lock()
+		commands = append(commands, pageCommand{Method: method, Params: params})
+		mux.Unlock()
+
+		switch method {
+		case "Runtime.evaluate":
+			return `{"result": {"type": "object", "subtype": "node", "objectId": "document-1"}}`, nil
+		case "Runtime.getProperties":
+			return `{"result": [
+				{"name": "1", "value": {"type": "object", "subtype": "node", "objectId": "element-2"}},
+				{"name": "0", "value": {"type": "object", "subtype": "node", "objectId": "element-1"}},
+				{"name": "length", "value": {"type": "number", "value": 2}}
+			]}`, nil
+		case "DOM.getBoxModel":
+			return `{"model": {"border": [10, 20, 110, 20, 110, 70, 10, 70], "width": 100, "height": 50}}`, nil
+		case "DOM.requestNode":
+			if "element-2" == params["objectId"] {
+				return `{"nodeId": 2}`, nil
+			}
+			return `{"nodeId": 1}`, nil
+		case "Page.captureScreenshot":
+			return `{"data": "` + base64.StdEncoding.EncodeToString([]byte("png")) + `"}`, nil
+		case "Runtime.callFunctionOn":
+		default:
+			return "", nil
+		}
+
+		declaration, _ := params["functionDeclaration"].(string)
+		argument := ""
+		if arguments, ok := params["arguments"].([]interface{}); ok && len(arguments) > 0 {
+			argument, _ = arguments[0].(map[string]interface{})["value"].(string)
+		}
+		switch {
+		case strings.Contains(declaration, "querySelectorAll"):
+			return `{"result": {"type": "object", "subtype": "array", "objectId": "array-1"}}`, nil
+		case strings.Contains(declaration, "querySelector") && "a" == argument:
+			return `{"result": {"type": "object", "subtype": "node", "objectId": "element-1"}}`, nil
+		case strings.Contains(declaration, "querySelector"):
+			return `{"result": {"type": "object", "subtype": "null", "value": null}}`, nil
+		case strings.Contains(declaration, "textContent"):
+			return `{"result": {"type": "string", "value": "Home"}}`, nil
+		case strings.Contains(declaration, "getAttribute") && "href" == argument:
+			return `{"result": {"type": "string", "value": "/home"}}`, nil
+		case strings.Contains(declaration, "getAttribute") && "" == argument:
+			return `{"result": {"type": "object"}, "exceptionDetails": {"text": "Uncaught", "exception": {"type": "object", "description": "SyntaxError: invalid attribute name"}}}`, nil
+		case strings.Contains(declaration, "getAttribute"):
+			return `{"result": {"type": "object", "subtype": "null", "value": null}}`, nil
+		case strings.Contains(declaration, "getBoundingClientRect"):
+			return `{"result": {"type": "object", "value": {"X": 10.5, "Y": 1020, "Width": 100, "Height": 50}}}`, nil
+		}
+		return `{"result": {"type": "undefined"}}`, nil
+	})
+
+	return tab, func() []pageCommand {
+		mux.Lock()
+		defer mux.Unlock()
+		return append([]pageCommand{}, commands...)
+	}
+}
+
+func TestElementHandleQuery(t *testing.T) {
+	tab, commands := newElementTab(t)
+	ctx := context.Background()
+
+	link, err := tab.QuerySelector(ctx, "a")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if nil == link || "element-1" != link.ObjectID() {
+		t.Fatalf("Expected element-1, received %v", link)
+	}
+	if tab != link.Tab() {
+		t.Errorf("Expected the element to be in the tab")
+	}
+
+	missing, err := tab.QuerySelector(ctx, "#missing")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if nil != missing {
+		t.Errorf("Expected nil, received %v", missing)
+	}
+
+	elements, err := link.QuerySelectorAll(ctx, "span")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if 2 != len(elements) || "element-1" != elements[0].ObjectID() || "element-2" != elements[1].ObjectID() {
+		t.Errorf("Expected element-1 and element-2, received %v", elements)
+	}
+
+	groups := map[interface{}]bool{}
+	released := false
+	for _, command := range commands() {
+		switch command.Method {
+		case "Runtime.evaluate", "Runtime.callFunctionOn":
+			groups[command.Params["objectGroup"]] = true
+		case "Runtime.releaseObject":
+			released = released || "array-1" == command.Params["objectId"]
+		}
+	}
+	if 1 != len(groups) {
+		t.Errorf("Expected a single object group, received %v", groups)
+	}
+	if !released {
+		t.Errorf("Expected the result array to be released")
+	}
+
+	if err := tab.ReleaseElements(ctx); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	last := commands()[len(commands())-1]
+	if "Runtime.releaseObjectGroup" != last.Method {
+		t.Fatalf("Expected Runtime.releaseObjectGroup, received %s", last.Method)
+	}
+	if _, ok := groups[last.Params["objectGroup"]]; !ok {
+		t.Errorf("Expected the query object group to be released, received %v", last.Params["objectGroup"])
+	}
+	if tab.elementGroup() == last.Params["objectGroup"] {
+		t.Errorf("Expected a new object group after the release")
+	}
+}
+
+func TestElementHandleProperties(t *testing.T) {
+	tab, _ := newElementTab(t)
+	ctx := context.Background()
+	link := &ElementHandle{objectID: "element-1", tab: tab}
+
+	text, err := link.Text(ctx)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if "Home" != text {
+		t.Errorf("Expected 'Home', received '%s'", text)
+	}
+
+	href, ok, err := link.Attribute(ctx, "href")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if !ok || "/home" != href {
+		t.Errorf("Expected '/home', received '%s' (%v)", href, ok)
+	}
+	if _, ok, err := link.Attribute(ctx, "title"); nil != err || ok {
+		t.Errorf("Expected a missing attribute, received %v, %v", ok, err)
+	}
+	if _, _, err := link.Attribute(ctx, ""); nil == err || !strings.Contains(err.Error(), "SyntaxError: invalid attribute name") {
+		t.Errorf("Expected the exception, received %v", err)
+	}
+
+	box, err := link.BoundingBox(ctx)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if 10 != box.X || 20 != box.Y || 100 != box.Width || 50 != box.Height {
+		t.Errorf("Expected 100x50 at 10,20, received %+v", box)
+	}
+}
+
+func TestElementHandleInput(t *testing.T) {
+	tab, commands := newElementTab(t)
+	ctx := context.Background()
+	link := &ElementHandle{objectID: "element-1", tab: tab}
+
+	if err := link.Click(ctx); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if err := link.Type(ctx, "hi"); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+
+	mouse := []string{}
+	keys := []string{}
+	focused := false
+	for _, command := range commands() {
+		switch command.Method {
+		case "Input.dispatchMouseEvent":
+			mouse = append(mouse, command.Params["type"].(string))
+			if 60.0 != command.Params["x"] || 45.0 != command.Params["y"] {
+				t.Errorf("Expected a click at 60,45, received %v,%v", command.Params["x"], command.Params["y"])
+			}
+		case "Input.dispatchKeyEvent":
+			if text, ok := command.Params["text"].(string); ok {
+				keys = append(keys, text)
+			}
+		case "DOM.focus":
+			focused = "element-1" == command.Params["objectId"]
+		}
+	}
+	if "mouseMoved mousePressed mouseReleased" != strings.Join(mouse, " ") {
+		t.Errorf("Expected a click, received %v", mouse)
+	}
+	if !focused {
+		t.Errorf("Expected the element to be focused")
+	}
+	if "hi" != strings.Join(keys, "") {
+		t.Errorf("Expected 'hi', received %v", keys)
+	}
+}
+
+func TestElementHandleScreenshot(t *testing.T) {
+	tab, commands := newElementTab(t)
+	link := &ElementHandle{objectID: "element-1", tab: tab}
+
+	data, err := link.ScreenshotElement(context.Background())
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if "png" != string(data) {
+		t.Errorf("Expected 'png', received '%s'", data)
+	}
+
+	all := commands()
+	clip := all[len(all)-1].Params["clip"].(map[string]interface{})
+	if 10.0 != clip["x"] || 1020.0 != clip["y"] || 101.0 != clip["width"] || 50.0 != clip["height"] {
+		t.Errorf("Expected a 101x50 clip at 10,1020, received %v", clip)
+	}
+}
+
+func TestElementHandleNodeID(t *testing.T) {
+	tab, commands := newElementTab(t)
+	ctx := context.Background()
+	first := &ElementHandle{objectID: "element-1", tab: tab}
+	second := &ElementHandle{objectID: "element-2", tab: tab}
+
+	for _, test := range []struct {
+		handle   *ElementHandle
+		expected dom.NodeID
+	}{{first, 1}, {second, 2}, {first, 1}} {
+		nodeID, err := test.handle.NodeID(ctx)
+		if nil != err {
+			t.Fatalf("Expected nil, received error: '%s'", err.Error())
+		}
+		if test.expected != nodeID {
+			t.Errorf("Expected %d, received %d", test.expected, nodeID)
+		}
+	}
+	tab.resetElements()
+	if _, err := first.NodeID(ctx); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+
+	documents := 0
+	for _, command := range commands() {
+		if "DOM.getDocument" == command.Method {
+			documents++
+		}
+	}
+	if 2 != documents {
+		t.Errorf("Expected the document to be requested once per document, received %d requests", documents)
+	}
+}
+
+func TestQuadBoundingBox(t *testing.T) {
+	box := quadBoundingBox(dom.Quad{50, 0, 100, 50, 50, 100, 0, 50})
+	if 0 != box.X || 0 != box.Y || 100 != box.Width || 100 != box.Height {
+		t.Errorf("Expected 100x100 at 0,0, received %+v", box)
+	}
+}
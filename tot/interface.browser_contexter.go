@@ -0,0 +1,29 @@
+package chrome
+
+import (
+	"github.com/mkenney/go-chrome/tot/cdtp/target"
+)
+
+/*
+BrowserContexter defines an interface for managing an isolated (incognito)
+browser context. Tabs in different browser contexts don't share cookies,
+storage or cache.
+*/
+type BrowserContexter interface {
+	// Chromium returns the Chromium instance this browser context is in.
+	Chromium() *Chrome
+
+	// Close closes all tabs in the browser context and disposes it.
+	Close() error
+
+	// ID returns the browser context ID.
+	ID() target.BrowserContextID
+
+	// NewTab spawns a new tab in the browser context and returns a reference
+	// to it.
+	NewTab(url string) (*Tab, error)
+
+	// Tabs returns the list of the currently open tabs in the browser
+	// context.
+	Tabs() []*Tab
+}
@@ -1,6 +1,12 @@
 package chrome
 
-import "net/url"
+import (
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/mkenney/go-chrome/tot/socket"
+)
 
 /*
 Chromium defines an interface for interacting with Chromium based web browsers
@@ -14,9 +20,33 @@ type Chromium interface {
 	// default value such as '/usr/bin/google-chrome'.
 	Binary() string
 
+	// BinaryInfo returns the version and supported features of the Chromium
+	// binary, or an error if the binary is not a valid Chromium binary.
+	BinaryInfo() (*BinaryInfo, error)
+
+	// BrowserSocket returns a websocket connection to the browser target,
+	// which can drive browser-wide domains such as Target, Browser and
+	// SystemInfo.
+	BrowserSocket() (*socket.Socket, error)
+
 	// Close ends the Chromium process and cleans up.
 	Close() error
 
+	// Env returns the environment variables set for the Chromium process.
+	Env() map[string]string
+
+	// EnvMode returns how the environment of the Chromium process is built
+	// from the inherited environment and the variables returned by Env.
+	EnvMode() EnvMode
+
+	// Exited returns a channel that is closed when the Chromium process
+	// exits.
+	Exited() <-chan struct{}
+
+	// ExitState returns the exit status of the Chromium process, or nil if it
+	// has not exited.
+	ExitState() *os.ProcessState
+
 	// GetTab returns an open Tabber instance, or an error if the requested tab
 	// does not exist.
 	GetTab(tabID string) (tab Tabber, err error)
@@ -38,17 +68,71 @@ type Chromium interface {
 	// struct.
 	Launch() error
 
+	// NewBrowserContext creates an isolated (incognito) browser context.
+	NewBrowserContext() (*BrowserContext, error)
+
 	// NewTab spawns a new tab and returns a reference to it.
 	NewTab(url string) (*Tab, error)
 
+	// OnOutput registers a callback for each line of output written by the
+	// Chromium process. Lines that are Chromium log messages are parsed, see
+	// ParseLogLine.
+	OnOutput(callback func(line *OutputLine))
+
+	// OnTabEvent registers a callback for tab lifecycle events. Events are
+	// only emitted after WatchTabs has been called.
+	OnTabEvent(callback func(event *TabEvent))
+
+	// PipeMode returns whether the remote debugging pipe transport is used
+	// instead of a TCP debugging port.
+	PipeMode() bool
+
+	// ProcessOptions returns the operating system attributes used to start the
+	// Chromium process.
+	ProcessOptions() *ProcessOptions
+
 	// Port returns the port number the developer tools endpoints will listen
 	// on. Should return a sane default value such as 9222.
 	Port() int
 
+	// ProfileDir returns the temporary profile directory created by Launch,
+	// or an empty string if a user-data-dir flag was specified.
+	ProfileDir() string
+
+	// ProfileTemplate returns the path of the profile directory used to seed
+	// temporary profiles.
+	ProfileTemplate() string
+
 	// Query queries the developer tools endpoints and returns JSON data in the
 	// provided struct.
 	Query(path string, params url.Values, msg interface{}) (interface{}, error)
 
+	// RefreshTabs synchronizes the list of open tabs with the targets reported
+	// by the /json/list endpoint.
+	RefreshTabs() error
+
+	// SetEnv sets an environment variable for the Chromium process.
+	SetEnv(key, value string)
+
+	// SetEnvMode sets how the environment of the Chromium process is built.
+	SetEnvMode(mode EnvMode)
+
+	// SetProcessOptions sets the operating system attributes used to start
+	// the Chromium process.
+	SetProcessOptions(options *ProcessOptions)
+
+	// SetProfileTemplate sets a profile directory to copy into the temporary
+	// profile created by Launch.
+	SetProfileTemplate(dir string)
+
+	// SetStderrWriter sets a writer that receives a copy of the STDERR output
+	// of the Chromium process.
+	SetStderrWriter(writer io.Writer)
+
+	// SetStdoutWriter sets a writer that receives a copy of the STDOUT output
+	// of the Chromium process.
+	SetStdoutWriter(writer io.Writer)
+
 	// STDERR returns a string defining the location to write STDERR output.
 	STDERR() string
 
@@ -61,6 +145,10 @@ type Chromium interface {
 	// Version returns Chromium version data.
 	Version() (*Version, error)
 
+	// WatchTabs enables target discovery on the browser socket to keep the
+	// list of open tabs and their metadata current.
+	WatchTabs() error
+
 	// Workdir returns the path of the Chromium working directory. Should return
 	// a sane default value such as '/tmp/headless-chrome'.
 	Workdir() string
@@ -5,6 +5,9 @@ ChromiumFlags provides an interface for managing CLI arguments to the Chromium b
 */
 type ChromiumFlags interface {
 
+	// Delete removes a flag.
+	Delete(flag string)
+
 	// Get returns the specified flag values
 	Get(flag string) (interface{}, error)
 
@@ -14,7 +17,9 @@ type ChromiumFlags interface {
 	// List returns an array of each flag for use in os.StartProcess
 	List() []string
 
-	// Set sets a flag's values.
+	// Set sets a flag's values. Values may be an int, a string, a list of
+	// strings which is passed as a comma separated list, or nil for flags
+	// without a value.
 	Set(flag string, values interface{}) error
 
 	// String implments Stringer. It returns the set parameters formatted to be
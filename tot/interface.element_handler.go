@@ -0,0 +1,60 @@
+package chrome
+
+import (
+	"context"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/dom"
+	"github.com/mkenney/go-chrome/tot/cdtp/runtime"
+)
+
+/*
+ElementHandler defines an interface for interacting with a DOM element in a
+tab. Element handles reference a remote JavaScript object and are only valid
+until the tab navigates or the handle is released.
+*/
+type ElementHandler interface {
+	// Attribute returns the value of an attribute of the element and whether
+	// the element has the attribute.
+	Attribute(ctx context.Context, name string) (string, bool, error)
+
+	// BoundingBox returns the border box of the element relative to the
+	// viewport.
+	BoundingBox(ctx context.Context) (*BoundingBox, error)
+
+	// Click scrolls the element into view and clicks its center with the left
+	// mouse button.
+	Click(ctx context.Context) error
+
+	// Focus focuses the element.
+	Focus(ctx context.Context) error
+
+	// NodeID returns the DOM node ID of the element.
+	NodeID(ctx context.Context) (dom.NodeID, error)
+
+	// ObjectID returns the ID of the remote object referencing the element.
+	ObjectID() runtime.RemoteObjectID
+
+	// QuerySelector returns the first descendant of the element matching a
+	// selector, or nil if there is none. See SelectorEngine for the selector
+	// syntax.
+	QuerySelector(ctx context.Context, selector string) (*ElementHandle, error)
+
+	// QuerySelectorAll returns all descendants of the element matching a
+	// selector.
+	QuerySelectorAll(ctx context.Context, selector string) ([]*ElementHandle, error)
+
+	// Release releases the remote object referencing the element.
+	Release(ctx context.Context) error
+
+	// ScreenshotElement captures a PNG screenshot of the element.
+	ScreenshotElement(ctx context.Context) ([]byte, error)
+
+	// Tab returns the tab the element is in.
+	Tab() *Tab
+
+	// Text returns the text content of the element.
+	Text(ctx context.Context) (string, error)
+
+	// Type focuses the element and types text into it.
+	Type(ctx context.Context, text string) error
+}
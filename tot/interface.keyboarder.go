@@ -0,0 +1,31 @@
+package chrome
+
+import (
+	"context"
+)
+
+/*
+Keyboarder defines an interface for dispatching keyboard input to a tab using a
+US keyboard layout. Keys are named by their DOM key value, e.g. "a", "Enter" or
+"ArrowLeft", or by their DOM code, e.g. "KeyA".
+*/
+type Keyboarder interface {
+	// Down dispatches a keydown event. Modifier keys stay pressed and apply to
+	// following keyboard and mouse events until they are released.
+	Down(ctx context.Context, key string) error
+
+	// Modifiers returns the bit field of the pressed modifier keys.
+	Modifiers() int
+
+	// Press presses and releases a key or a chord of keys joined by "+", e.g.
+	// "Control+Shift+ArrowLeft". Keys are pressed in order and released in
+	// reverse order.
+	Press(ctx context.Context, keys string) error
+
+	// Type types text one character at a time. Characters that aren't on the
+	// keyboard layout are sent as char events.
+	Type(ctx context.Context, text string) error
+
+	// Up dispatches a keyup event.
+	Up(ctx context.Context, key string) error
+}
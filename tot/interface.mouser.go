@@ -0,0 +1,41 @@
+package chrome
+
+import (
+	"context"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/input"
+)
+
+/*
+Mouser defines an interface for dispatching mouse input to a tab. Coordinates
+are CSS pixels relative to the viewport. Mouse events carry the modifier keys
+pressed on the tab's keyboard.
+*/
+type Mouser interface {
+	// Click moves the mouse to a position and clicks the left button.
+	Click(ctx context.Context, x, y float64) error
+
+	// DoubleClick moves the mouse to a position and double clicks the left
+	// button.
+	DoubleClick(ctx context.Context, x, y float64) error
+
+	// Down presses a mouse button at the current position.
+	Down(ctx context.Context, button input.ButtonEventEnum) error
+
+	// Drag presses the left button at one position, moves the mouse to another
+	// position in steps and releases the button.
+	Drag(ctx context.Context, fromX, fromY, toX, toY float64, steps int) error
+
+	// Move moves the mouse to a position, dispatching steps intermediate
+	// mouseMoved events along a straight line.
+	Move(ctx context.Context, x, y float64, steps int) error
+
+	// Position returns the current mouse position.
+	Position() (float64, float64)
+
+	// Up releases a mouse button at the current position.
+	Up(ctx context.Context, button input.ButtonEventEnum) error
+
+	// Wheel scrolls the mouse wheel at the current position.
+	Wheel(ctx context.Context, deltaX, deltaY float64) error
+}
@@ -0,0 +1,22 @@
+package chrome
+
+import (
+	"context"
+)
+
+/*
+Pooler defines an interface for managing a pool of Chromium instances that
+lease tabs to concurrent workloads.
+*/
+type Pooler interface {
+	// Acquire leases a new tab, waiting until a lease is available or the
+	// context ends.
+	Acquire(ctx context.Context) (*Lease, error)
+
+	// Close closes all Chromium instances in the pool. Leases can't be
+	// acquired after the pool is closed.
+	Close() error
+
+	// Size returns the number of running Chromium instances in the pool.
+	Size() int
+}
@@ -0,0 +1,20 @@
+package chrome
+
+import (
+	"context"
+)
+
+/*
+SelectorEngine defines an interface for finding elements with a selector
+syntax. Element queries use the engine named by the selector prefix, e.g.
+"xpath=//a" or "text=Sign in", see RegisterSelectorEngine. Selectors without a
+prefix are CSS selectors, selectors starting with "//" are XPath expressions.
+*/
+type SelectorEngine interface {
+	// Query returns the first element below root matching a selector, or nil
+	// if there is none.
+	Query(ctx context.Context, root *ElementHandle, selector string) (*ElementHandle, error)
+
+	// QueryAll returns all elements below root matching a selector.
+	QueryAll(ctx context.Context, root *ElementHandle, selector string) ([]*ElementHandle, error)
+}
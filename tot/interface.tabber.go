@@ -1,8 +1,10 @@
 package chrome
 
 import (
+	"context"
 	"net/url"
 
+	"github.com/mkenney/go-chrome/tot/cdtp/runtime"
 	"github.com/mkenney/go-chrome/tot/socket"
 )
 
@@ -10,6 +12,10 @@ import (
 Tabber provides an interface for managing a Chromium tab
 */
 type Tabber interface {
+	// BrowserContext returns the browser context this tab was opened in, or
+	// nil if it is in the default browser context.
+	BrowserContext() *BrowserContext
+
 	// Browser returns the Chromium instance this tab is in
 	Chromium() *Chrome
 
@@ -19,12 +25,44 @@ type Tabber interface {
 	// Data returns the tab metadata
 	Data() *TabData
 
+	// Document returns a handle for the document of the tab.
+	Document(ctx context.Context) (*ElementHandle, error)
+
+	// Goto navigates the tab to a URL and waits for the waitUntil lifecycle
+	// condition. It returns the response to the main document request.
+	Goto(ctx context.Context, url string, waitUntil WaitUntil) (*NavigationResponse, error)
+
+	// Keyboard returns the keyboard of the tab.
+	Keyboard() *Keyboard
+
+	// Mouse returns the mouse of the tab.
+	Mouse() *Mouse
+
 	// Protocol returns the socket.Protocoller interface for this tab
 	Protocol() socket.Protocoller
 
+	// QuerySelector returns the first element in the tab matching a
+	// selector, or nil if there is none.
+	QuerySelector(ctx context.Context, selector string) (*ElementHandle, error)
+
+	// QuerySelectorAll returns all elements in the tab matching a selector.
+	QuerySelectorAll(ctx context.Context, selector string) ([]*ElementHandle, error)
+
+	// ReleaseElements releases all element handles returned by queries in
+	// the tab.
+	ReleaseElements(ctx context.Context) error
+
 	// Socket returns the socket.Socketer interface for this tab
 	Socket() socket.Socketer
 
 	// URL returns the URL of the websocket connection
 	URL() *url.URL
+
+	// WaitForFunction waits until a JavaScript expression evaluates to a
+	// truthy value and returns the value.
+	WaitForFunction(ctx context.Context, expression string) (*runtime.RemoteObject, error)
+
+	// WaitForSelector waits until an element matching a selector reaches a
+	// state and returns the element, or nil for the hidden state.
+	WaitForSelector(ctx context.Context, selector string, state SelectorState) (*ElementHandle, error)
 }
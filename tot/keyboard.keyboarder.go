@@ -0,0 +1,250 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/input"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ModifierAlt is the modifier bit of the Alt key.
+	ModifierAlt = 1
+
+	// ModifierControl is the modifier bit of the Control key.
+	ModifierControl = 2
+
+	// ModifierMeta is the modifier bit of the Meta (Command) key.
+	ModifierMeta = 4
+
+	// ModifierShift is the modifier bit of the Shift key.
+	ModifierShift = 8
+)
+
+/*
+modifierBits maps modifier key values to their modifier bits.
+*/
+var modifierBits = map[string]int{
+	"Alt":     ModifierAlt,
+	"Control": ModifierControl,
+	"Meta":    ModifierMeta,
+	"Shift":   ModifierShift,
+}
+
+/*
+newKeyboard returns a keyboard for a tab.
+*/
+func newKeyboard(tab *Tab) *Keyboard {
+	return &Keyboard{
+		mux:     &sync.Mutex{},
+		pressed: make(map[string]bool),
+		tab:     tab,
+	}
+}
+
+/*
+Keyboard is a struct representing the keyboard of a tab.
+*/
+type Keyboard struct {
+	modifiers int
+	mux       *sync.Mutex
+	pressed   map[string]bool
+	tab       *Tab
+}
+
+/*
+Down implements Keyboarder.
+*/
+func (keyboard *Keyboard) Down(ctx context.Context, key string) error {
+	definition, err := keyDefinitionFor(key)
+	if nil != err {
+		return err
+	}
+	return keyboard.down(ctx, definition)
+}
+
+/*
+Modifiers implements Keyboarder.
+*/
+func (keyboard *Keyboard) Modifiers() int {
+	keyboard.mux.Lock()
+	defer keyboard.mux.Unlock()
+	return keyboard.modifiers
+}
+
+/*
+Press implements Keyboarder.
+*/
+func (keyboard *Keyboard) Press(ctx context.Context, keys string) error {
+	definitions := []*keyDefinition{}
+	for _, key := range parseChord(keys) {
+		definition, err := keyDefinitionFor(key)
+		if nil != err {
+			return err
+		}
+		definitions = append(definitions, definition)
+	}
+	if 0 == len(definitions) {
+		return errors.New("no key to press")
+	}
+	return keyboard.press(ctx, definitions...)
+}
+
+/*
+Type implements Keyboarder.
+*/
+func (keyboard *Keyboard) Type(ctx context.Context, text string) error {
+	for _, char := range text {
+		if definition, ok := usKeyboardLayout[string(char)]; ok {
+			if err := keyboard.press(ctx, definition); nil != err {
+				return err
+			}
+			continue
+		}
+		result := <-keyboard.tab.Protocol().Input().WithContext(ctx).DispatchKeyEvent(&input.DispatchKeyEventParams{
+			Key:            string(char),
+			Modifiers:      keyboard.Modifiers(),
+			Text:           string(char),
+			Type:           input.KeyEvent.Char,
+			UnmodifiedText: string(char),
+		})
+		if nil != result.Err {
+			return errors.Wrap(result.Err, fmt.Sprintf("could not type '%s'", string(char)))
+		}
+	}
+	return nil
+}
+
+/*
+Up implements Keyboarder.
+*/
+func (keyboard *Keyboard) Up(ctx context.Context, key string) error {
+	definition, err := keyDefinitionFor(key)
+	if nil != err {
+		return err
+	}
+	return keyboard.up(ctx, definition)
+}
+
+/*
+down dispatches the keydown event of a key. Keys that insert text send a
+keyDown event, other keys and keys pressed with Control, Alt or Meta send a
+rawKeyDown event.
+*/
+func (keyboard *Keyboard) down(ctx context.Context, definition *keyDefinition) error {
+	keyboard.mux.Lock()
+	keyboard.modifiers |= modifierBits[definition.key]
+	modifiers := keyboard.modifiers
+	autoRepeat := keyboard.pressed[definition.code]
+	keyboard.pressed[definition.code] = true
+	keyboard.mux.Unlock()
+
+	key := definition.key
+	text := definition.text
+	if 0 != modifiers&ModifierShift && "" != definition.shiftKey {
+		key = definition.shiftKey
+		text = definition.shiftKey
+	}
+	if 0 != modifiers&^ModifierShift {
+		text = ""
+	}
+	eventType := input.KeyEvent.KeyDown
+	if "" == text {
+		eventType = input.KeyEvent.RawKeyDown
+	}
+
+	result := <-keyboard.tab.Protocol().Input().WithContext(ctx).DispatchKeyEvent(&input.DispatchKeyEventParams{
+		AutoRepeat:            autoRepeat,
+		Code:                  definition.code,
+		Key:                   key,
+		Location:              definition.location,
+		Modifiers:             modifiers,
+		NativeVirtualKeyCode:  definition.keyCode,
+		Text:                  text,
+		Type:                  eventType,
+		UnmodifiedText:        text,
+		WindowsVirtualKeyCode: definition.keyCode,
+	})
+	if nil != result.Err {
+		return errors.Wrap(result.Err, fmt.Sprintf("could not press '%s'", key))
+	}
+	return nil
+}
+
+/*
+press presses keys in order and releases them in reverse order. Keys that were
+pressed are released even if pressing a later key fails.
+*/
+func (keyboard *Keyboard) press(ctx context.Context, definitions ...*keyDefinition) error {
+	var err error
+	pressed := 0
+	for _, definition := range definitions {
+		if err = keyboard.down(ctx, definition); nil != err {
+			break
+		}
+		pressed++
+	}
+	for i := pressed - 1; i >= 0; i-- {
+		if upErr := keyboard.up(ctx, definitions[i]); nil != upErr && nil == err {
+			err = upErr
+		}
+	}
+	return err
+}
+
+/*
+up dispatches the keyup event of a key.
+*/
+func (keyboard *Keyboard) up(ctx context.Context, definition *keyDefinition) error {
+	keyboard.mux.Lock()
+	keyboard.modifiers &^= modifierBits[definition.key]
+	modifiers := keyboard.modifiers
+	delete(keyboard.pressed, definition.code)
+	keyboard.mux.Unlock()
+
+	result := <-keyboard.tab.Protocol().Input().WithContext(ctx).DispatchKeyEvent(&input.DispatchKeyEventParams{
+		Code:                  definition.code,
+		Key:                   definition.key,
+		Location:              definition.location,
+		Modifiers:             modifiers,
+		NativeVirtualKeyCode:  definition.keyCode,
+		Type:                  input.KeyEvent.KeyUp,
+		WindowsVirtualKeyCode: definition.keyCode,
+	})
+	if nil != result.Err {
+		return errors.Wrap(result.Err, fmt.Sprintf("could not release '%s'", definition.key))
+	}
+	return nil
+}
+
+/*
+keyDefinitionFor returns the definition of a key of the US keyboard layout.
+*/
+func keyDefinitionFor(key string) (*keyDefinition, error) {
+	definition, ok := usKeyboardLayout[key]
+	if !ok {
+		return nil, fmt.Errorf("unknown key '%s'", key)
+	}
+	return definition, nil
+}
+
+/*
+parseChord splits a chord of keys joined by "+". A "+" that doesn't follow a
+key is the plus key, so "+" and "Shift++" are valid chords.
+*/
+func parseChord(chord string) []string {
+	keys := []string{}
+	for "" != chord {
+		index := strings.Index(chord[1:], "+")
+		if -1 == index {
+			keys = append(keys, chord)
+			break
+		}
+		keys = append(keys, chord[:index+1])
+		chord = chord[index+2:]
+	}
+	return keys
+}
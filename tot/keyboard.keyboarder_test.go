@@ -0,0 +1,130 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+/*
+keyEvents formats the key events sent to the test page socket.
+*/
+func keyEvents(commands []pageCommand) []string {
+	events := []string{}
+	for _, command := range commands {
+		if "Input.dispatchKeyEvent" != command.Method {
+			continue
+		}
+		event := fmt.Sprintf("%s:%s", command.Params["type"], command.Params["key"])
+		if text, ok := command.Params["text"]; ok {
+			event += fmt.Sprintf(":%q", text)
+		}
+		if modifiers, ok := command.Params["modifiers"]; ok {
+			event += fmt.Sprintf(":%v", modifiers)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestParseChord(t *testing.T) {
+	tests := map[string]string{
+		"a":                       "a",
+		"Control+Shift+ArrowLeft": "Control|Shift|ArrowLeft",
+		"+":                       "+",
+		"Shift++":                 "Shift|+",
+		"":                        "",
+	}
+	for chord, expected := range tests {
+		if keys := strings.Join(parseChord(chord), "|"); expected != keys {
+			t.Errorf("%s: expected '%s', received '%s'", chord, expected, keys)
+		}
+	}
+}
+
+func TestKeyboardLayout(t *testing.T) {
+	tests := []struct {
+		key     string
+		code    string
+		keyCode int
+		text    string
+	}{
+		{"a", "KeyA", 65, "a"},
+		{"A", "KeyA", 65, "A"},
+		{"KeyZ", "KeyZ", 90, "z"},
+		{"7", "Digit7", 55, "7"},
+		{"&", "Digit7", 55, "&"},
+		{"?", "Slash", 191, "?"},
+		{" ", "Space", 32, " "},
+		{"\n", "Enter", 13, "\r"},
+		{"ArrowLeft", "ArrowLeft", 37, ""},
+		{"F12", "F12", 123, ""},
+		{"Shift", "ShiftLeft", 16, ""},
+	}
+	for _, test := range tests {
+		definition, err := keyDefinitionFor(test.key)
+		if nil != err {
+			t.Errorf("%q: expected nil, received error: '%s'", test.key, err.Error())
+			continue
+		}
+		if test.code != definition.code || test.keyCode != definition.keyCode || test.text != definition.text {
+			t.Errorf("%q: expected %s %d %q, received %s %d %q", test.key, test.code, test.keyCode, test.text, definition.code, definition.keyCode, definition.text)
+		}
+	}
+	if _, err := keyDefinitionFor("Hyper"); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
+
+func TestKeyboardType(t *testing.T) {
+	tab, commands := newElementTab(t)
+	if err := tab.Keyboard().Type(context.Background(), "Hi!\né"); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+
+	expected := strings.Join([]string{
+		`keyDown:H:"H"`, `keyUp:H`,
+		`keyDown:i:"i"`, `keyUp:i`,
+		`keyDown:!:"!"`, `keyUp:!`,
+		`keyDown:Enter:"\r"`, `keyUp:Enter`,
+		`char:é:"é"`,
+	}, " ")
+	if received := strings.Join(keyEvents(commands()), " "); expected != received {
+		t.Errorf("Expected '%s', received '%s'", expected, received)
+	}
+}
+
+func TestKeyboardPress(t *testing.T) {
+	tab, commands := newElementTab(t)
+	keyboard := tab.Keyboard()
+	ctx := context.Background()
+
+	if err := keyboard.Press(ctx, "Control+a"); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if err := keyboard.Press(ctx, "Shift+b"); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if 0 != keyboard.Modifiers() {
+		t.Errorf("Expected no modifiers, received %d", keyboard.Modifiers())
+	}
+
+	expected := strings.Join([]string{
+		`rawKeyDown:Control:2`, `rawKeyDown:a:2`, `keyUp:a:2`, `keyUp:Control`,
+		`rawKeyDown:Shift:8`, `keyDown:B:"B":8`, `keyUp:b:8`, `keyUp:Shift`,
+	}, " ")
+	if received := strings.Join(keyEvents(commands()), " "); expected != received {
+		t.Errorf("Expected '%s', received '%s'", expected, received)
+	}
+
+	if err := keyboard.Press(ctx, "Control+Hyper"); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+	if err := keyboard.Down(ctx, "Alt"); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if ModifierAlt != keyboard.Modifiers() {
+		t.Errorf("Expected Alt, received %d", keyboard.Modifiers())
+	}
+}
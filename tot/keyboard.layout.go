@@ -0,0 +1,140 @@
+package chrome
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+keyDefinition describes a key of a keyboard layout.
+*/
+type keyDefinition struct {
+	// code is the DOM code of the physical key, e.g. "KeyA".
+	code string
+
+	// key is the DOM key value, e.g. "a".
+	key string
+
+	// keyCode is the Windows virtual key code.
+	keyCode int
+
+	// location is the DOM key location, 1 for left and 2 for right modifier
+	// keys.
+	location int
+
+	// shiftKey is the key value while Shift is held, if it differs.
+	shiftKey string
+
+	// text is the text the key inserts, if any.
+	text string
+}
+
+/*
+usKeyboardLayout maps key values and DOM codes to the keys of a US keyboard.
+*/
+var usKeyboardLayout = newUSKeyboardLayout()
+
+/*
+newUSKeyboardLayout builds the US keyboard layout table.
+*/
+func newUSKeyboardLayout() map[string]*keyDefinition {
+	layout := map[string]*keyDefinition{}
+	add := func(definition *keyDefinition) {
+		if _, ok := layout[definition.code]; !ok {
+			layout[definition.code] = definition
+		}
+		layout[definition.key] = definition
+		if "" != definition.shiftKey {
+			shifted := *definition
+			shifted.key = definition.shiftKey
+			shifted.shiftKey = ""
+			shifted.text = definition.shiftKey
+			layout[definition.shiftKey] = &shifted
+		}
+	}
+
+	for letter := 'a'; letter <= 'z'; letter++ {
+		upper := strings.ToUpper(string(letter))
+		add(&keyDefinition{
+			code:     "Key" + upper,
+			key:      string(letter),
+			keyCode:  int('A' + letter - 'a'),
+			shiftKey: upper,
+			text:     string(letter),
+		})
+	}
+
+	for digit, shiftKey := range []string{")", "!", "@", "#", "$", "%", "^", "&", "*", "("} {
+		key := fmt.Sprintf("%d", digit)
+		add(&keyDefinition{
+			code:     "Digit" + key,
+			key:      key,
+			keyCode:  '0' + digit,
+			shiftKey: shiftKey,
+			text:     key,
+		})
+	}
+
+	for _, definition := range []*keyDefinition{
+		{code: "Backquote", key: "`", keyCode: 192, shiftKey: "~"},
+		{code: "Minus", key: "-", keyCode: 189, shiftKey: "_"},
+		{code: "Equal", key: "=", keyCode: 187, shiftKey: "+"},
+		{code: "BracketLeft", key: "[", keyCode: 219, shiftKey: "{"},
+		{code: "BracketRight", key: "]", keyCode: 221, shiftKey: "}"},
+		{code: "Backslash", key: `\`, keyCode: 220, shiftKey: "|"},
+		{code: "Semicolon", key: ";", keyCode: 186, shiftKey: ":"},
+		{code: "Quote", key: "'", keyCode: 222, shiftKey: `"`},
+		{code: "Comma", key: ",", keyCode: 188, shiftKey: "<"},
+		{code: "Period", key: ".", keyCode: 190, shiftKey: ">"},
+		{code: "Slash", key: "/", keyCode: 191, shiftKey: "?"},
+		{code: "Space", key: " ", keyCode: 32},
+	} {
+		definition.text = definition.key
+		add(definition)
+	}
+
+	for _, definition := range []*keyDefinition{
+		{code: "Backspace", key: "Backspace", keyCode: 8},
+		{code: "Tab", key: "Tab", keyCode: 9},
+		{code: "Enter", key: "Enter", keyCode: 13, text: "\r"},
+		{code: "ShiftLeft", key: "Shift", keyCode: 16, location: 1},
+		{code: "ControlLeft", key: "Control", keyCode: 17, location: 1},
+		{code: "AltLeft", key: "Alt", keyCode: 18, location: 1},
+		{code: "Pause", key: "Pause", keyCode: 19},
+		{code: "CapsLock", key: "CapsLock", keyCode: 20},
+		{code: "Escape", key: "Escape", keyCode: 27},
+		{code: "PageUp", key: "PageUp", keyCode: 33},
+		{code: "PageDown", key: "PageDown", keyCode: 34},
+		{code: "End", key: "End", keyCode: 35},
+		{code: "Home", key: "Home", keyCode: 36},
+		{code: "ArrowLeft", key: "ArrowLeft", keyCode: 37},
+		{code: "ArrowUp", key: "ArrowUp", keyCode: 38},
+		{code: "ArrowRight", key: "ArrowRight", keyCode: 39},
+		{code: "ArrowDown", key: "ArrowDown", keyCode: 40},
+		{code: "Insert", key: "Insert", keyCode: 45},
+		{code: "Delete", key: "Delete", keyCode: 46},
+		{code: "MetaLeft", key: "Meta", keyCode: 91, location: 1},
+		{code: "ContextMenu", key: "ContextMenu", keyCode: 93},
+	} {
+		add(definition)
+	}
+	for _, definition := range []*keyDefinition{
+		{code: "ShiftRight", key: "Shift", keyCode: 16, location: 2},
+		{code: "ControlRight", key: "Control", keyCode: 17, location: 2},
+		{code: "AltRight", key: "Alt", keyCode: 18, location: 2},
+		{code: "MetaRight", key: "Meta", keyCode: 92, location: 2},
+	} {
+		layout[definition.code] = definition
+	}
+
+	for number := 1; number <= 12; number++ {
+		key := fmt.Sprintf("F%d", number)
+		add(&keyDefinition{code: key, key: key, keyCode: 111 + number})
+	}
+
+	// Line breaks are typed with the Enter key.
+	layout["\n"] = layout["Enter"]
+	layout["\r"] = layout["Enter"]
+
+	return layout
+}
@@ -0,0 +1,200 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/input"
+	"github.com/pkg/errors"
+)
+
+/*
+newMouse returns a mouse for a tab.
+*/
+func newMouse(tab *Tab, keyboard *Keyboard) *Mouse {
+	return &Mouse{
+		button:   input.ButtonEvent.None,
+		keyboard: keyboard,
+		mux:      &sync.Mutex{},
+		tab:      tab,
+	}
+}
+
+/*
+Mouse is a struct representing the mouse of a tab.
+*/
+type Mouse struct {
+	button   input.ButtonEventEnum
+	keyboard *Keyboard
+	mux      *sync.Mutex
+	tab      *Tab
+	x        float64
+	y        float64
+}
+
+/*
+Click implements Mouser.
+*/
+func (mouse *Mouse) Click(ctx context.Context, x, y float64) error {
+	return mouse.click(ctx, x, y, 1)
+}
+
+/*
+DoubleClick implements Mouser.
+*/
+func (mouse *Mouse) DoubleClick(ctx context.Context, x, y float64) error {
+	return mouse.click(ctx, x, y, 2)
+}
+
+/*
+Down implements Mouser.
+*/
+func (mouse *Mouse) Down(ctx context.Context, button input.ButtonEventEnum) error {
+	return mouse.down(ctx, button, 1)
+}
+
+/*
+Drag implements Mouser.
+*/
+func (mouse *Mouse) Drag(ctx context.Context, fromX, fromY, toX, toY float64, steps int) error {
+	if err := mouse.Move(ctx, fromX, fromY, 1); nil != err {
+		return err
+	}
+	if err := mouse.Down(ctx, input.ButtonEvent.Left); nil != err {
+		return err
+	}
+	if err := mouse.Move(ctx, toX, toY, steps); nil != err {
+		return err
+	}
+	return mouse.Up(ctx, input.ButtonEvent.Left)
+}
+
+/*
+Move implements Mouser. At least one mouseMoved event is dispatched.
+*/
+func (mouse *Mouse) Move(ctx context.Context, x, y float64, steps int) error {
+	if steps < 1 {
+		steps = 1
+	}
+	fromX, fromY := mouse.Position()
+	for step := 1; step <= steps; step++ {
+		stepX := fromX + (x-fromX)*float64(step)/float64(steps)
+		stepY := fromY + (y-fromY)*float64(step)/float64(steps)
+
+		mouse.mux.Lock()
+		mouse.x = stepX
+		mouse.y = stepY
+		button := mouse.button
+		mouse.mux.Unlock()
+
+		err := mouse.dispatch(ctx, &input.DispatchMouseEventParams{
+			Button: button,
+			Type:   input.MouseEvent.MouseMoved,
+			X:      int(math.Round(stepX)),
+			Y:      int(math.Round(stepY)),
+		})
+		if nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+Position implements Mouser.
+*/
+func (mouse *Mouse) Position() (float64, float64) {
+	mouse.mux.Lock()
+	defer mouse.mux.Unlock()
+	return mouse.x, mouse.y
+}
+
+/*
+Up implements Mouser.
+*/
+func (mouse *Mouse) Up(ctx context.Context, button input.ButtonEventEnum) error {
+	return mouse.up(ctx, button, 1)
+}
+
+/*
+Wheel implements Mouser.
+*/
+func (mouse *Mouse) Wheel(ctx context.Context, deltaX, deltaY float64) error {
+	x, y := mouse.Position()
+	return mouse.dispatch(ctx, &input.DispatchMouseEventParams{
+		DeltaX: int(math.Round(deltaX)),
+		DeltaY: int(math.Round(deltaY)),
+		Type:   input.MouseEvent.MouseWheel,
+		X:      int(math.Round(x)),
+		Y:      int(math.Round(y)),
+	})
+}
+
+/*
+click moves the mouse to a position and clicks the left button clickCount
+times. Each press reports the number of clicks so far, as a user's clicks do.
+*/
+func (mouse *Mouse) click(ctx context.Context, x, y float64, clickCount int) error {
+	if err := mouse.Move(ctx, x, y, 1); nil != err {
+		return err
+	}
+	for count := 1; count <= clickCount; count++ {
+		if err := mouse.down(ctx, input.ButtonEvent.Left, count); nil != err {
+			return err
+		}
+		if err := mouse.up(ctx, input.ButtonEvent.Left, count); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+dispatch dispatches a mouse event with the pressed keyboard modifiers.
+*/
+func (mouse *Mouse) dispatch(ctx context.Context, event *input.DispatchMouseEventParams) error {
+	event.Modifiers = mouse.keyboard.Modifiers()
+	result := <-mouse.tab.Protocol().Input().WithContext(ctx).DispatchMouseEvent(event)
+	if nil != result.Err {
+		return errors.Wrap(result.Err, fmt.Sprintf("could not dispatch %s event", event.Type))
+	}
+	return nil
+}
+
+/*
+down presses a mouse button.
+*/
+func (mouse *Mouse) down(ctx context.Context, button input.ButtonEventEnum, clickCount int) error {
+	mouse.mux.Lock()
+	mouse.button = button
+	x, y := mouse.x, mouse.y
+	mouse.mux.Unlock()
+
+	return mouse.dispatch(ctx, &input.DispatchMouseEventParams{
+		Button:     button,
+		ClickCount: clickCount,
+		Type:       input.MouseEvent.MousePressed,
+		X:          int(math.Round(x)),
+		Y:          int(math.Round(y)),
+	})
+}
+
+/*
+up releases a mouse button.
+*/
+func (mouse *Mouse) up(ctx context.Context, button input.ButtonEventEnum, clickCount int) error {
+	mouse.mux.Lock()
+	mouse.button = input.ButtonEvent.None
+	x, y := mouse.x, mouse.y
+	mouse.mux.Unlock()
+
+	return mouse.dispatch(ctx, &input.DispatchMouseEventParams{
+		Button:     button,
+		ClickCount: clickCount,
+		Type:       input.MouseEvent.MouseReleased,
+		X:          int(math.Round(x)),
+		Y:          int(math.Round(y)),
+	})
+}
@@ -0,0 +1,95 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/input"
+)
+
+/*
+mouseEvents formats the mouse events sent to the test page socket.
+*/
+func mouseEvents(commands []pageCommand) []string {
+	events := []string{}
+	for _, command := range commands {
+		if "Input.dispatchMouseEvent" != command.Method {
+			continue
+		}
+		event := fmt.Sprintf("%s@%v,%v", command.Params["type"], command.Params["x"], command.Params["y"])
+		if button, ok := command.Params["button"]; ok && "none" != button {
+			event += fmt.Sprintf(":%v", button)
+		}
+		if count, ok := command.Params["clickCount"]; ok {
+			event += fmt.Sprintf(":%v", count)
+		}
+		if modifiers, ok := command.Params["modifiers"]; ok {
+			event += fmt.Sprintf(":m%v", modifiers)
+		}
+		if deltaY, ok := command.Params["deltaY"]; ok {
+			event += fmt.Sprintf(":dy%v", deltaY)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestMouseClick(t *testing.T) {
+	tab, commands := newElementTab(t)
+	mouse := tab.Mouse()
+	ctx := context.Background()
+
+	if err := mouse.Click(ctx, 10, 20); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if err := mouse.DoubleClick(ctx, 10, 20); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if err := tab.Keyboard().Down(ctx, "Shift"); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if err := mouse.Down(ctx, input.ButtonEvent.Right); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if err := mouse.Up(ctx, input.ButtonEvent.Right); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+
+	expected := strings.Join([]string{
+		"mouseMoved@10,20", "mousePressed@10,20:left:1", "mouseReleased@10,20:left:1",
+		"mouseMoved@10,20", "mousePressed@10,20:left:1", "mouseReleased@10,20:left:1", "mousePressed@10,20:left:2", "mouseReleased@10,20:left:2",
+		"mousePressed@10,20:right:1:m8", "mouseReleased@10,20:right:1:m8",
+	}, " ")
+	if received := strings.Join(mouseEvents(commands()), " "); expected != received {
+		t.Errorf("Expected '%s', received '%s'", expected, received)
+	}
+}
+
+func TestMouseDrag(t *testing.T) {
+	tab, commands := newElementTab(t)
+	mouse := tab.Mouse()
+	ctx := context.Background()
+
+	if err := mouse.Drag(ctx, 10, 10, 40, 70, 3); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if err := mouse.Wheel(ctx, 0, 120); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if x, y := mouse.Position(); 40 != x || 70 != y {
+		t.Errorf("Expected the mouse at 40,70, received %v,%v", x, y)
+	}
+
+	expected := strings.Join([]string{
+		"mouseMoved@10,10",
+		"mousePressed@10,10:left:1",
+		"mouseMoved@20,30:left", "mouseMoved@30,50:left", "mouseMoved@40,70:left",
+		"mouseReleased@40,70:left:1",
+		"mouseWheel@40,70:dy120",
+	}, " ")
+	if received := strings.Join(mouseEvents(commands()), " "); expected != received {
+		t.Errorf("Expected '%s', received '%s'", expected, received)
+	}
+}
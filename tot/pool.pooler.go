@@ -0,0 +1,449 @@
+package chrome
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+/*
+PoolOptions configures a Pool.
+*/
+type PoolOptions struct {
+	// Optional. HealthCheck verifies an instance is usable before a lease is
+	// created on it. Defaults to requesting Browser.getVersion with a
+	// HealthCheckTimeout timeout.
+	HealthCheck func(chrome *Chrome) error
+
+	// Optional. HealthCheckTimeout is the timeout for the default health
+	// check. Defaults to 5 seconds.
+	HealthCheckTimeout time.Duration
+
+	// Optional. Isolated creates each leased tab in a new browser context,
+	// which is disposed when the lease is released, so leases sharing an
+	// instance don't share cookies, storage or cache.
+	Isolated bool
+
+	// Optional. MaxConcurrency is the maximum number of simultaneous leases.
+	// Defaults to Size.
+	MaxConcurrency int
+
+	// Optional. MaxUses is the number of leases after which an instance is
+	// recycled. 0 means instances are never recycled because of use.
+	MaxUses int
+
+	// Optional. New creates and launches a Chromium instance. Defaults to
+	// launching an instance with NewLaunchOptions().
+	New func() (*Chrome, error)
+
+	// Optional. Size is the maximum number of Chromium instances. Defaults
+	// to 1.
+	Size int
+}
+
+/*
+NewPool returns a pointer to a Pool. Chromium instances are launched on demand.
+*/
+func NewPool(options *PoolOptions) *Pool {
+	opts := PoolOptions{}
+	if nil != options {
+		opts = *options
+	}
+	if opts.Size <= 0 {
+		opts.Size = 1
+	}
+	if opts.MaxConcurrency <= 0 {
+		opts.MaxConcurrency = opts.Size
+	}
+	if opts.HealthCheckTimeout <= 0 {
+		opts.HealthCheckTimeout = 5 * time.Second
+	}
+	if nil == opts.New {
+		opts.New = launchPoolInstance
+	}
+	if nil == opts.HealthCheck {
+		timeout := opts.HealthCheckTimeout
+		opts.HealthCheck = func(chrome *Chrome) error {
+			return checkInstance(chrome, timeout)
+		}
+	}
+
+	return &Pool{
+		changed: make(chan struct{}),
+		mux:     &sync.Mutex{},
+		options: opts,
+		slots:   make(chan struct{}, opts.MaxConcurrency),
+	}
+}
+
+/*
+Pool implements Pooler.
+*/
+type Pool struct {
+	changed   chan struct{}
+	closed    bool
+	instances []*poolInstance
+	mux       *sync.Mutex
+	options   PoolOptions
+	pending   int
+	slots     chan struct{}
+}
+
+/*
+poolInstance tracks the use of a Chromium instance in a Pool.
+*/
+type poolInstance struct {
+	chrome  *Chrome
+	leases  int
+	retired bool
+	uses    int
+}
+
+/*
+Lease is a tab leased from a Pool. Release must be called when the tab is no
+longer needed.
+*/
+type Lease struct {
+	browserContext *BrowserContext
+	instance       *poolInstance
+	once           *sync.Once
+	pool           *Pool
+	tab            *Tab
+}
+
+/*
+launchPoolInstance launches a Chromium instance with the default launch options.
+*/
+func launchPoolInstance() (*Chrome, error) {
+	chrome, err := NewWithOptions(NewLaunchOptions(), "", "", "", "")
+	if nil != err {
+		return nil, err
+	}
+	if err := chrome.Launch(); nil != err {
+		return nil, err
+	}
+	return chrome, nil
+}
+
+/*
+checkInstance verifies that an instance responds to Browser.getVersion.
+*/
+func checkInstance(chrome *Chrome, timeout time.Duration) error {
+	browser, err := chrome.BrowserSocket()
+	if nil != err {
+		return err
+	}
+	result := <-browser.Browser().WithTimeout(timeout).GetVersion()
+	return result.Err
+}
+
+/*
+Acquire implements Pooler.
+
+Leases are spread across instances, the least used instance is chosen and new
+instances are launched while fewer than Size are running. Instances that fail
+the health check, have crashed or have reached MaxUses are recycled. If no
+instance is available Acquire waits for one until the context is done.
+*/
+func (pool *Pool) Acquire(ctx context.Context) (*Lease, error) {
+	select {
+	case pool.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	lease, err := pool.lease(ctx)
+	if nil != err {
+		<-pool.slots
+		return nil, err
+	}
+	return lease, nil
+}
+
+/*
+lease creates a lease on a healthy instance, once a concurrency slot has been
+acquired.
+*/
+func (pool *Pool) lease(ctx context.Context) (*Lease, error) {
+	for {
+		if err := ctx.Err(); nil != err {
+			return nil, err
+		}
+
+		instance, err := pool.instance(ctx)
+		if nil != err {
+			return nil, err
+		}
+
+		if err := pool.options.HealthCheck(instance.chrome); nil != err {
+			log.Warnf("pool instance failed health check: %s", err)
+			pool.retire(instance)
+			pool.release(instance)
+			continue
+		}
+
+		lease := &Lease{
+			instance: instance,
+			once:     &sync.Once{},
+			pool:     pool,
+		}
+		if err := lease.open(); nil != err {
+			pool.release(instance)
+			return nil, err
+		}
+		return lease, nil
+	}
+}
+
+/*
+instance selects or launches an instance and reserves a lease on it. If every
+instance is retired or unavailable and the pool is full, instance waits until
+an instance is released, removed or launched or the context is done.
+*/
+func (pool *Pool) instance(ctx context.Context) (*poolInstance, error) {
+	pool.mux.Lock()
+	for {
+		if pool.closed {
+			pool.mux.Unlock()
+			return nil, errors.New("pool is closed")
+		}
+
+		// Retired and exited instances are on their way out and don't count
+		// against Size.
+		var selected *poolInstance
+		running := 0
+		for _, instance := range pool.instances {
+			if instance.retired || instance.chrome.hasExited() {
+				continue
+			}
+			running++
+			if nil == selected || instance.leases < selected.leases {
+				selected = instance
+			}
+		}
+
+		// Launch a new instance while the pool isn't full and all running
+		// instances are in use.
+		if (nil == selected || selected.leases > 0) && running+pool.pending < pool.options.Size {
+			pool.pending++
+			pool.mux.Unlock()
+
+			chrome, err := pool.options.New()
+
+			pool.mux.Lock()
+			pool.pending--
+			pool.notify()
+			if nil != err {
+				pool.mux.Unlock()
+				return nil, errors.Wrap(err, "could not launch pool instance")
+			}
+			// Close has already stopped the instances if it was called
+			// during the launch.
+			if pool.closed {
+				pool.mux.Unlock()
+				chrome.Close()
+				return nil, errors.New("pool is closed")
+			}
+			selected = &poolInstance{chrome: chrome}
+			pool.instances = append(pool.instances, selected)
+			go pool.watch(selected)
+		}
+
+		if nil != selected {
+			selected.leases++
+			selected.uses++
+			if pool.options.MaxUses > 0 && selected.uses >= pool.options.MaxUses {
+				selected.retired = true
+			}
+			pool.mux.Unlock()
+			return selected, nil
+		}
+
+		changed := pool.changed
+		pool.mux.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		pool.mux.Lock()
+	}
+}
+
+/*
+notify wakes any Acquire calls waiting for an instance. The caller must hold
+the pool mutex.
+*/
+func (pool *Pool) notify() {
+	close(pool.changed)
+	pool.changed = make(chan struct{})
+}
+
+/*
+watch retires an instance when its Chromium process exits.
+*/
+func (pool *Pool) watch(instance *poolInstance) {
+	<-instance.chrome.Exited()
+	state := instance.chrome.ExitState()
+
+	pool.mux.Lock()
+	closed := pool.closed
+	pool.mux.Unlock()
+	if !closed {
+		log.Warnf("pool instance exited: %s", state)
+	}
+
+	pool.retire(instance)
+	pool.mux.Lock()
+	leases := instance.leases
+	pool.mux.Unlock()
+	if 0 == leases {
+		pool.remove(instance)
+	}
+}
+
+/*
+retire prevents new leases on an instance. The instance is closed once its last
+lease is released.
+*/
+func (pool *Pool) retire(instance *poolInstance) {
+	pool.mux.Lock()
+	instance.retired = true
+	pool.notify()
+	pool.mux.Unlock()
+}
+
+/*
+release ends a lease on an instance and closes the instance if it is retired
+and no longer in use.
+*/
+func (pool *Pool) release(instance *poolInstance) {
+	pool.mux.Lock()
+	instance.leases--
+	done := instance.retired && 0 == instance.leases
+	pool.notify()
+	pool.mux.Unlock()
+
+	if done {
+		pool.remove(instance)
+	}
+}
+
+/*
+remove removes an instance from the pool and closes it.
+*/
+func (pool *Pool) remove(instance *poolInstance) {
+	pool.mux.Lock()
+	found := false
+	instances := make([]*poolInstance, 0, len(pool.instances))
+	for _, i := range pool.instances {
+		if i == instance {
+			found = true
+			continue
+		}
+		instances = append(instances, i)
+	}
+	pool.instances = instances
+	pool.notify()
+	pool.mux.Unlock()
+
+	if found {
+		if err := instance.chrome.Close(); nil != err {
+			log.Warnf("could not close pool instance: %s", err)
+		}
+	}
+}
+
+/*
+Close implements Pooler.
+*/
+func (pool *Pool) Close() error {
+	pool.mux.Lock()
+	pool.closed = true
+	instances := pool.instances
+	pool.instances = nil
+	pool.notify()
+	pool.mux.Unlock()
+
+	var lastErr error
+	for _, instance := range instances {
+		if err := instance.chrome.Close(); nil != err {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+/*
+Size implements Pooler.
+*/
+func (pool *Pool) Size() int {
+	pool.mux.Lock()
+	defer pool.mux.Unlock()
+	return len(pool.instances)
+}
+
+/*
+open creates the leased tab, in a new browser context if the pool is isolated.
+*/
+func (lease *Lease) open() error {
+	chrome := lease.instance.chrome
+	if !lease.pool.options.Isolated {
+		tab, err := chrome.NewTab("about:blank")
+		if nil != err {
+			return errors.Wrap(err, "could not open leased tab")
+		}
+		lease.tab = tab
+		return nil
+	}
+
+	browserContext, err := chrome.NewBrowserContext()
+	if nil != err {
+		return err
+	}
+	tab, err := browserContext.NewTab("about:blank")
+	if nil != err {
+		browserContext.Close()
+		return errors.Wrap(err, "could not open leased tab")
+	}
+	lease.browserContext = browserContext
+	lease.tab = tab
+	return nil
+}
+
+/*
+Chromium returns the Chromium instance the leased tab belongs to.
+*/
+func (lease *Lease) Chromium() *Chrome {
+	return lease.instance.chrome
+}
+
+/*
+Release closes the leased tab, disposes its browser context and returns the
+lease to the pool. Calling Release more than once has no additional effect.
+*/
+func (lease *Lease) Release() error {
+	var err error
+	lease.once.Do(func() {
+		if !lease.instance.chrome.hasExited() {
+			if nil != lease.browserContext {
+				err = lease.browserContext.Close()
+			} else {
+				_, err = lease.tab.Close()
+			}
+		}
+		lease.pool.release(lease.instance)
+		<-lease.pool.slots
+	})
+	return err
+}
+
+/*
+Tab returns the leased tab.
+*/
+func (lease *Lease) Tab() *Tab {
+	return lease.tab
+}
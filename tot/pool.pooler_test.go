@@ -0,0 +1,269 @@
+package chrome
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+/*
+newPipePool returns a pool of fake pipe Chromium instances and a function that
+returns the instances launched so far.
+*/
+func newPipePool(t *testing.T, options *PoolOptions) (*Pool, func() []*Chrome) {
+	mux := &sync.Mutex{}
+	launched := []*Chrome{}
+	options.New = func() (*Chrome, error) {
+		chrome := launchPipeChrome(t)
+		mux.Lock()
+		launched = append(launched, chrome)
+		mux.Unlock()
+		return chrome, nil
+	}
+
+	pool := NewPool(options)
+	t.Cleanup(func() { pool.Close() })
+	return pool, func() []*Chrome {
+		mux.Lock()
+		defer mux.Unlock()
+		return append([]*Chrome{}, launched...)
+	}
+}
+
+func TestPoolAcquire(t *testing.T) {
+	pool, launched := newPipePool(t, &PoolOptions{Size: 2, MaxConcurrency: 4})
+
+	leases := []*Lease{}
+	for a := 0; a < 4; a++ {
+		lease, err := pool.Acquire(context.Background())
+		if nil != err {
+			t.Fatalf("Expected nil, received error: '%s'", err.Error())
+		}
+		leases = append(leases, lease)
+	}
+	if 2 != pool.Size() {
+		t.Errorf("Expected 2 instances, received %d", pool.Size())
+	}
+	if 2 != len(launched()) {
+		t.Errorf("Expected 2 launches, received %d", len(launched()))
+	}
+	if leases[0].Chromium() == leases[1].Chromium() {
+		t.Errorf("Expected leases to be spread across instances")
+	}
+	if "" == leases[0].Tab().Data().ID {
+		t.Errorf("Expected a leased tab")
+	}
+
+	for _, lease := range leases {
+		if err := lease.Release(); nil != err {
+			t.Errorf("Expected nil, received error: '%s'", err.Error())
+		}
+	}
+	if err := leases[0].Release(); nil != err {
+		t.Errorf("Expected nil, received error: '%s'", err.Error())
+	}
+	if 0 != len(leases[0].Chromium().Tabs()) {
+		t.Errorf("Expected leased tabs to be closed, received %d", len(leases[0].Chromium().Tabs()))
+	}
+}
+
+func TestPoolMaxConcurrency(t *testing.T) {
+	pool, _ := newPipePool(t, &PoolOptions{MaxConcurrency: 1})
+
+	lease, err := pool.Acquire(context.Background())
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := pool.Acquire(ctx); context.DeadlineExceeded != err {
+		t.Errorf("Expected context.DeadlineExceeded, received '%v'", err)
+	}
+
+	lease.Release()
+	lease, err = pool.Acquire(context.Background())
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	lease.Release()
+}
+
+func TestPoolIsolated(t *testing.T) {
+	pool, _ := newPipePool(t, &PoolOptions{Isolated: true})
+
+	lease, err := pool.Acquire(context.Background())
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if lease.Tab().BrowserContext() != lease.browserContext {
+		t.Errorf("Expected the leased tab to be in the lease browser context")
+	}
+	if "context-1" != lease.browserContext.ID() {
+		t.Errorf("Expected 'context-1', received '%s'", lease.browserContext.ID())
+	}
+	if err := lease.Release(); nil != err {
+		t.Errorf("Expected nil, received error: '%s'", err.Error())
+	}
+}
+
+func TestPoolMaxUses(t *testing.T) {
+	pool, launched := newPipePool(t, &PoolOptions{MaxUses: 2})
+
+	for a := 0; a < 3; a++ {
+		lease, err := pool.Acquire(context.Background())
+		if nil != err {
+			t.Fatalf("Expected nil, received error: '%s'", err.Error())
+		}
+		lease.Release()
+	}
+	instances := launched()
+	if 2 != len(instances) {
+		t.Fatalf("Expected 2 launches, received %d", len(instances))
+	}
+	if !instances[0].hasExited() {
+		t.Errorf("Expected the recycled instance to be closed")
+	}
+	if 1 != pool.Size() {
+		t.Errorf("Expected 1 instance, received %d", pool.Size())
+	}
+}
+
+func TestPoolMaxUsesLeased(t *testing.T) {
+	pool, launched := newPipePool(t, &PoolOptions{MaxConcurrency: 2, MaxUses: 1})
+
+	// The first instance is retired but still leased, so it doesn't count
+	// against Size.
+	first, err := pool.Acquire(context.Background())
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	defer first.Release()
+	second, err := pool.Acquire(context.Background())
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	defer second.Release()
+
+	if 2 != len(launched()) {
+		t.Errorf("Expected 2 launches, received %d", len(launched()))
+	}
+	if first.Chromium() == second.Chromium() {
+		t.Errorf("Expected the retired instance to be replaced")
+	}
+}
+
+func TestPoolCrash(t *testing.T) {
+	pool, launched := newPipePool(t, &PoolOptions{})
+
+	lease, err := pool.Acquire(context.Background())
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	exited := lease.Chromium().Exited()
+	lease.Chromium().process.Kill()
+	select {
+	case <-exited:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for the process to exit")
+	}
+	lease.Release()
+
+	lease, err = pool.Acquire(context.Background())
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	defer lease.Release()
+	if 2 != len(launched()) {
+		t.Errorf("Expected the crashed instance to be replaced, received %d launches", len(launched()))
+	}
+}
+
+func TestPoolCloseDuringLaunch(t *testing.T) {
+	launching := make(chan struct{})
+	proceed := make(chan struct{})
+	var chrome *Chrome
+	pool := NewPool(&PoolOptions{
+		Size: 1,
+		New: func() (*Chrome, error) {
+			close(launching)
+			<-proceed
+			chrome = launchPipeChrome(t)
+			return chrome, nil
+		},
+	})
+
+	acquired := make(chan error)
+	go func() {
+		_, err := pool.Acquire(context.Background())
+		acquired <- err
+	}()
+	<-launching
+	pool.Close()
+	close(proceed)
+
+	select {
+	case err := <-acquired:
+		if nil == err {
+			t.Errorf("Expected error, received nil")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for Acquire")
+	}
+	if !chrome.hasExited() {
+		t.Errorf("Expected the instance launched during Close to be stopped")
+	}
+	if 0 != pool.Size() {
+		t.Errorf("Expected 0 instances, received %d", pool.Size())
+	}
+}
+
+func TestPoolAcquireWait(t *testing.T) {
+	launching := make(chan struct{})
+	proceed := make(chan struct{})
+	pool := NewPool(&PoolOptions{
+		MaxConcurrency: 3,
+		New: func() (*Chrome, error) {
+			close(launching)
+			<-proceed
+			return launchPipeChrome(t), nil
+		},
+	})
+	defer pool.Close()
+
+	acquired := make(chan *Lease, 2)
+	acquire := func() {
+		lease, err := pool.Acquire(context.Background())
+		if nil != err {
+			t.Errorf("Expected nil, received error: '%s'", err.Error())
+		}
+		acquired <- lease
+	}
+	go acquire()
+	<-launching
+
+	// The pool is full while the only instance is launching.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := pool.Acquire(ctx); context.DeadlineExceeded != err {
+		t.Errorf("Expected context.DeadlineExceeded, received %v", err)
+	}
+
+	go acquire()
+	time.Sleep(50 * time.Millisecond)
+	close(proceed)
+	for a := 0; a < 2; a++ {
+		select {
+		case lease := <-acquired:
+			if nil != lease {
+				defer lease.Release()
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for Acquire")
+		}
+	}
+	if 1 != pool.Size() {
+		t.Errorf("Expected 1 instance, received %d", pool.Size())
+	}
+}
@@ -0,0 +1,69 @@
+package chrome
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+/*
+selectorEnginePattern matches the engine name prefix of a selector.
+*/
+var selectorEnginePattern = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9_-]*)=`)
+
+/*
+selectorEngines holds the registered selector engines by name.
+*/
+var selectorEngines = map[string]SelectorEngine{
+	"css":    CSSSelectorEngine,
+	"pierce": PierceSelectorEngine,
+	"role":   RoleSelectorEngine,
+	"text":   TextSelectorEngine,
+	"xpath":  XPathSelectorEngine,
+}
+
+/*
+selectorEnginesMux guards selectorEngines.
+*/
+var selectorEnginesMux = &sync.RWMutex{}
+
+/*
+RegisterSelectorEngine registers a selector engine. Selectors prefixed with
+"name=" are passed to the engine without the prefix. Engine names are case
+insensitive and can't be registered twice.
+*/
+func RegisterSelectorEngine(name string, engine SelectorEngine) error {
+	if !selectorEnginePattern.MatchString(name + "=") {
+		return fmt.Errorf("invalid selector engine name '%s'", name)
+	}
+	name = strings.ToLower(name)
+
+	selectorEnginesMux.Lock()
+	defer selectorEnginesMux.Unlock()
+	if _, ok := selectorEngines[name]; ok {
+		return fmt.Errorf("selector engine '%s' is already registered", name)
+	}
+	selectorEngines[name] = engine
+	return nil
+}
+
+/*
+parseSelector returns the engine of a selector and the selector without the
+engine prefix.
+*/
+func parseSelector(selector string) (SelectorEngine, string, error) {
+	if match := selectorEnginePattern.FindStringSubmatch(selector); nil != match {
+		selectorEnginesMux.RLock()
+		engine, ok := selectorEngines[strings.ToLower(match[1])]
+		selectorEnginesMux.RUnlock()
+		if !ok {
+			return nil, "", fmt.Errorf("unknown selector engine '%s'", match[1])
+		}
+		return engine, selector[len(match[0]):], nil
+	}
+	if strings.HasPrefix(selector, "//") || strings.HasPrefix(selector, "(//") {
+		return XPathSelectorEngine, selector, nil
+	}
+	return CSSSelectorEngine, selector, nil
+}
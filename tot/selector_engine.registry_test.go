@@ -0,0 +1,86 @@
+package chrome
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/runtime"
+)
+
+/*
+testSelectorEngine is a selector engine returning the selector as the object
+ID of the matching element.
+*/
+type testSelectorEngine struct{}
+
+func (engine *testSelectorEngine) Query(ctx context.Context, root *ElementHandle, selector string) (*ElementHandle, error) {
+	return &ElementHandle{objectID: runtime.RemoteObjectID("test-" + string(root.objectID) + "-" + selector), tab: root.tab}, nil
+}
+
+func (engine *testSelectorEngine) QueryAll(ctx context.Context, root *ElementHandle, selector string) ([]*ElementHandle, error) {
+	element, err := engine.Query(ctx, root, selector)
+	return []*ElementHandle{element}, err
+}
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		engine   SelectorEngine
+		query    string
+	}{
+		{`a[href="/home"]`, CSSSelectorEngine, `a[href="/home"]`},
+		{`[data-id="1"]`, CSSSelectorEngine, `[data-id="1"]`},
+		{`css=div > a`, CSSSelectorEngine, `div > a`},
+		{`//a[@href]`, XPathSelectorEngine, `//a[@href]`},
+		{`(//a)[2]`, XPathSelectorEngine, `(//a)[2]`},
+		{`XPath=.//a`, XPathSelectorEngine, `.//a`},
+		{`text="Sign in"`, TextSelectorEngine, `"Sign in"`},
+		{`text=a=b`, TextSelectorEngine, `a=b`},
+		{`role=button[name="Sign in"]`, RoleSelectorEngine, `button[name="Sign in"]`},
+		{`pierce=#submit`, PierceSelectorEngine, `#submit`},
+	}
+	for _, test := range tests {
+		engine, query, err := parseSelector(test.selector)
+		if nil != err {
+			t.Errorf("%s: expected nil, received error: '%s'", test.selector, err.Error())
+			continue
+		}
+		if test.engine != engine || test.query != query {
+			t.Errorf("%s: expected %T '%s', received %T '%s'", test.selector, test.engine, test.query, engine, query)
+		}
+	}
+
+	if _, _, err := parseSelector("missing=a"); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
+
+func TestRegisterSelectorEngine(t *testing.T) {
+	tab, _ := newElementTab(t)
+	ctx := context.Background()
+
+	if err := RegisterSelectorEngine("test-engine", &testSelectorEngine{}); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if err := RegisterSelectorEngine("Test-Engine", &testSelectorEngine{}); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+	if err := RegisterSelectorEngine("test engine", &testSelectorEngine{}); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+
+	element, err := tab.QuerySelector(ctx, "test-engine=foo")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if "test-document-1-foo" != element.ObjectID() {
+		t.Errorf("Expected test-document-1-foo, received %s", element.ObjectID())
+	}
+	elements, err := element.QuerySelectorAll(ctx, "TEST-ENGINE=bar")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if 1 != len(elements) || "test-test-document-1-foo-bar" != elements[0].ObjectID() {
+		t.Errorf("Expected test-test-document-1-foo-bar, received %v", elements)
+	}
+}
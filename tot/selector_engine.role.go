@@ -0,0 +1,149 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/accessibility"
+	"github.com/mkenney/go-chrome/tot/cdtp/dom"
+	"github.com/pkg/errors"
+)
+
+/*
+roleSelectorPattern matches a role selector, e.g. button[name="Sign in"].
+*/
+var roleSelectorPattern = regexp.MustCompile(`^\s*([a-zA-Z][\w-]*)\s*(\[\s*name\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\]"']*?))\s*\])?\s*$`)
+
+/*
+RoleSelectorEngine finds elements by their ARIA role and accessible name, as
+computed by the browser's accessibility tree. It is registered as "role", e.g.
+"role=button" or `role=button[name="Sign in"]`. Names must match exactly.
+Elements in open shadow roots are included, elements in closed and user agent
+shadow roots are not.
+*/
+var RoleSelectorEngine SelectorEngine = &roleSelectorEngine{}
+
+/*
+roleSelectorEngine is the selector engine for ARIA roles.
+*/
+type roleSelectorEngine struct{}
+
+/*
+Query implements SelectorEngine.
+*/
+func (engine *roleSelectorEngine) Query(ctx context.Context, root *ElementHandle, selector string) (*ElementHandle, error) {
+	elements, err := engine.query(ctx, root, selector, 1)
+	if nil != err || 0 == len(elements) {
+		return nil, err
+	}
+	return elements[0], nil
+}
+
+/*
+QueryAll implements SelectorEngine.
+*/
+func (engine *roleSelectorEngine) QueryAll(ctx context.Context, root *ElementHandle, selector string) ([]*ElementHandle, error) {
+	return engine.query(ctx, root, selector, -1)
+}
+
+/*
+query returns up to limit elements below root matching a role selector in
+document order, or all of them if limit is negative.
+*/
+func (engine *roleSelectorEngine) query(ctx context.Context, root *ElementHandle, selector string, limit int) ([]*ElementHandle, error) {
+	match := roleSelectorPattern.FindStringSubmatch(selector)
+	if nil == match {
+		return nil, fmt.Errorf("invalid role selector '%s'", selector)
+	}
+	role := match[1]
+	hasName := "" != match[2]
+	name := match[3] + match[4] + match[5]
+
+	described := <-root.tab.Protocol().DOM().WithContext(ctx).DescribeNode(&dom.DescribeNodeParams{
+		Depth:    -1,
+		ObjectID: root.objectID,
+		Pierce:   true,
+	})
+	if nil != described.Err {
+		return nil, errors.Wrap(described.Err, "could not describe the root element")
+	}
+	if nil == described.Node {
+		return nil, errors.New("could not describe the root element")
+	}
+	backendIDs := []dom.BackendNodeID{}
+	collectElements(described.Node, &backendIDs)
+
+	// The accessibility tree is queried once for the whole subtree, the
+	// matching nodes are then taken in document order.
+	tree := <-root.tab.Protocol().Accessibility().WithContext(ctx).QueryAXTree(&accessibility.QueryAXTreeParams{
+		AccessibleName: name,
+		ObjectID:       root.objectID,
+		Role:           role,
+	})
+	if nil != tree.Err {
+		return nil, errors.Wrap(tree.Err, "could not query the accessibility tree")
+	}
+	matches := map[dom.BackendNodeID]bool{}
+	for _, node := range tree.Nodes {
+		if node.Ignored || role != axValueString(node.Role) {
+			continue
+		}
+		if hasName && name != axValueString(node.Name) {
+			continue
+		}
+		matches[node.BackendDOMNodeID] = true
+	}
+
+	elements := []*ElementHandle{}
+	for _, backendID := range backendIDs {
+		if 0 <= limit && len(elements) >= limit {
+			break
+		}
+		if !matches[backendID] {
+			continue
+		}
+		resolved := <-root.tab.Protocol().DOM().WithContext(ctx).ResolveNode(&dom.ResolveNodeParams{
+			BackendNodeID: backendID,
+			ObjectGroup:   root.group(),
+		})
+		if nil != resolved.Err {
+			return nil, errors.Wrap(resolved.Err, "could not resolve the matching element")
+		}
+		if element := root.derive(resolved.Object); nil != element {
+			elements = append(elements, element)
+		}
+	}
+	return elements, nil
+}
+
+/*
+collectElements appends the backend IDs of the element nodes below a node, and
+in its open shadow roots, to ids in document order.
+*/
+func collectElements(node *dom.Node, ids *[]dom.BackendNodeID) {
+	for _, shadowRoot := range node.ShadowRoots {
+		if dom.ShadowRootType("open") == shadowRoot.ShadowRootType {
+			collectElements(shadowRoot, ids)
+		}
+	}
+	for _, child := range node.Children {
+		if 1 == child.NodeType {
+			*ids = append(*ids, child.BackendNodeID)
+		}
+		collectElements(child, ids)
+	}
+}
+
+/*
+axValueString returns the string value of an accessibility value.
+*/
+func axValueString(value *accessibility.AXValue) string {
+	if nil == value {
+		return ""
+	}
+	if text, ok := value.Value.(string); ok {
+		return text
+	}
+	return ""
+}
@@ -0,0 +1,147 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+/*
+newRoleTab returns a tab connected to a test page socket that emulates a
+document with a button, a button in an open shadow root and a button in a
+closed shadow root, and the list of commands it received.
+*/
+func newRoleTab(t *testing.T) (*Tab, func() []pageCommand) {
+	mux := &sync.Mutex{}
+	commands := []pageCommand{}
+	tab := newPageTab(t, func(method string, raw json.RawMessage) (string, []string) {
+		params := map[string]interface{}{}
+		json.Unmarshal(raw, &params)
+		mux.Lock()
+		commands = append(commands, pageCommand{Method: method, Params: params})
+		mux.Unlock()
+
+		switch method {
+		case "DOM.describeNode":
+			return `{"node": {"nodeType": 9, "backendNodeId": 9, "children": [
+				{"nodeType": 1, "backendNodeId": 1, "nodeName": "HTML", "children": [
+					{"nodeType": 1, "backendNodeId": 2, "nodeName": "BODY", "children": [
+						{"nodeType": 1, "backendNodeId": 3, "nodeName": "BUTTON", "children": [
+							{"nodeType": 3, "backendNodeId": 6, "nodeName": "#text"}
+						]},
+						{"nodeType": 1, "backendNodeId": 4, "nodeName": "DIV", "shadowRoots": [
+							{"nodeType": 11, "backendNodeId": 7, "shadowRootType": "open", "children": [
+								{"nodeType": 1, "backendNodeId": 5, "nodeName": "BUTTON"}
+							]}
+						]},
+						{"nodeType": 1, "backendNodeId": 10, "nodeName": "DIV", "shadowRoots": [
+							{"nodeType": 11, "backendNodeId": 11, "shadowRootType": "closed", "children": [
+								{"nodeType": 1, "backendNodeId": 8, "nodeName": "BUTTON"}
+							]}
+						]}
+					]}
+				]}
+			]}}`, nil
+		case "Accessibility.queryAXTree":
+			nodes := []string{}
+			for _, node := range roleTabNodes {
+				if params["role"] != node[1] || (nil != params["accessibleName"] && params["accessibleName"] != node[2]) {
+					continue
+				}
+				nodes = append(nodes, fmt.Sprintf(
+					`{"nodeId": "%s", "ignored": %s, "role": {"type": "role", "value": "%s"}, "name": {"type": "computedString", "value": "%s"}, "backendDOMNodeId": %s}`,
+					node[0], node[3], node[1], node[2], node[0],
+				))
+			}
+			return `{"nodes": [` + strings.Join(nodes, ",") + `]}`, nil
+		case "DOM.resolveNode":
+			return fmt.Sprintf(`{"object": {"type": "object", "subtype": "node", "objectId": "node-%v"}}`, params["backendNodeId"]), nil
+		}
+		return "", nil
+	})
+
+	return tab, func() []pageCommand {
+		mux.Lock()
+		defer mux.Unlock()
+		return append([]pageCommand{}, commands...)
+	}
+}
+
+/*
+roleTabNodes are the accessibility nodes of the newRoleTab document: the
+backend node ID, role, name and whether the node is ignored.
+*/
+var roleTabNodes = [][4]string{
+	{"1", "generic", "", "true"},
+	{"2", "generic", "", "false"},
+	{"3", "button", "Sign in", "false"},
+	{"4", "generic", "", "false"},
+	{"5", "button", "Cancel", "false"},
+	{"8", "button", "Cancel", "false"},
+	{"10", "generic", "", "false"},
+}
+
+func TestRoleSelectorEngine(t *testing.T) {
+	tab, commands := newRoleTab(t)
+	ctx := context.Background()
+	document := &ElementHandle{objectID: "document-1", tab: tab}
+
+	tests := map[string][]string{
+		`role=button`:                     {"node-3", "node-5"},
+		`role=button[name="Sign in"]`:     {"node-3"},
+		`role=button [ name = 'Cancel' ]`: {"node-5"},
+		`role=button[name=Cancel]`:        {"node-5"},
+		`role=button[name=""]`:            {},
+		`role=generic`:                    {"node-2", "node-4", "node-10"},
+		`role=link`:                       {},
+	}
+	for selector, expected := range tests {
+		elements, err := document.QuerySelectorAll(ctx, selector)
+		if nil != err {
+			t.Fatalf("%s: expected nil, received error: '%s'", selector, err.Error())
+		}
+		received := []string{}
+		for _, element := range elements {
+			received = append(received, string(element.ObjectID()))
+		}
+		if fmt.Sprint(expected) != fmt.Sprint(received) {
+			t.Errorf("%s: expected %v, received %v", selector, expected, received)
+		}
+	}
+
+	element, err := document.QuerySelector(ctx, `role=button[name="Cancel"]`)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if nil == element || "node-5" != element.ObjectID() {
+		t.Errorf("Expected node-5, received %v", element)
+	}
+	if _, err := document.QuerySelector(ctx, `role=button[title="Cancel"]`); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+
+	trees := 0
+	for _, command := range commands() {
+		switch command.Method {
+		case "DOM.describeNode":
+			if "document-1" != command.Params["objectId"] || -1.0 != command.Params["depth"] || true != command.Params["pierce"] {
+				t.Errorf("Expected the whole document subtree, received %v", command.Params)
+			}
+		case "Accessibility.queryAXTree":
+			trees++
+			if "document-1" != command.Params["objectId"] || nil == command.Params["role"] {
+				t.Errorf("Expected a role query of the document subtree, received %v", command.Params)
+			}
+		case "DOM.resolveNode":
+			if tab.elementGroup() != command.Params["objectGroup"] {
+				t.Errorf("Expected the element object group, received %v", command.Params["objectGroup"])
+			}
+		}
+	}
+	if 8 != trees {
+		t.Errorf("Expected a single accessibility tree query per selector, received %d", trees)
+	}
+}
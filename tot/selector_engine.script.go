@@ -0,0 +1,119 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+)
+
+/*
+CSSSelectorEngine finds elements with CSS selectors. It is the default engine
+and is registered as "css".
+*/
+var CSSSelectorEngine = NewScriptSelectorEngine(
+	`function(selector) { return Array.from(this.querySelectorAll(selector)) }`,
+	`function(selector) { return this.querySelector(selector) }`,
+)
+
+/*
+PierceSelectorEngine finds elements with CSS selectors, descending into open
+shadow roots. It is registered as "pierce", e.g. "pierce=button.submit".
+*/
+var PierceSelectorEngine = NewScriptSelectorEngine(`function(selector) {
+	const elements = []
+	const search = function(root) {
+		if (root.shadowRoot) {
+			search(root.shadowRoot)
+		}
+		for (const element of root.querySelectorAll("*")) {
+			if (element.matches(selector)) {
+				elements.push(element)
+			}
+			if (element.shadowRoot) {
+				search(element.shadowRoot)
+			}
+		}
+	}
+	search(this)
+	return elements
+}`, "")
+
+/*
+TextSelectorEngine finds the innermost elements containing a text. It is
+registered as "text". Quoted texts match the whitespace normalized text of an
+element exactly, e.g. text="Sign in", other texts match a case insensitive
+substring, e.g. text=sign in.
+*/
+var TextSelectorEngine = NewScriptSelectorEngine(`function(selector) {
+	const normalize = function(text) { return text.replace(/\s+/g, " ").trim() }
+	let matches = function(text) { return normalize(text).toLowerCase().includes(normalize(selector).toLowerCase()) }
+	const quoted = selector.match(/^"(.*)"$|^'(.*)'$/s)
+	if (quoted) {
+		const expected = normalize(undefined !== quoted[1] ? quoted[1] : quoted[2])
+		matches = function(text) { return normalize(text) === expected }
+	}
+	let elements = []
+	const walker = (this.ownerDocument || this).createTreeWalker(this, NodeFilter.SHOW_ELEMENT)
+	for (let element = walker.nextNode(); element; element = walker.nextNode()) {
+		if ("SCRIPT" === element.nodeName || "STYLE" === element.nodeName || !matches(element.textContent || "")) {
+			continue
+		}
+		elements = elements.filter(function(ancestor) { return !ancestor.contains(element) })
+		elements.push(element)
+	}
+	return elements
+}`, "")
+
+/*
+XPathSelectorEngine finds elements with XPath expressions. It is registered as
+"xpath", selectors starting with "//" use it without a prefix.
+*/
+var XPathSelectorEngine = NewScriptSelectorEngine(`function(selector) {
+	const document = this.ownerDocument || this
+	const result = document.evaluate(selector, this, null, XPathResult.ORDERED_NODE_SNAPSHOT_TYPE, null)
+	const elements = []
+	for (let i = 0; i < result.snapshotLength; i++) {
+		if (Node.ELEMENT_NODE === result.snapshotItem(i).nodeType) {
+			elements.push(result.snapshotItem(i))
+		}
+	}
+	return elements
+}`, "")
+
+/*
+NewScriptSelectorEngine returns a selector engine that calls JavaScript
+functions in the page with the root element as this and the selector as the
+argument. The all function returns an array of the matching elements, the
+optional one function returns the first matching element or null, it defaults
+to the first element returned by the all function.
+*/
+func NewScriptSelectorEngine(all, one string) SelectorEngine {
+	if "" == one {
+		one = fmt.Sprintf(`function(selector) { return (%s).call(this, selector)[0] || null }`, all)
+	}
+	return &scriptSelectorEngine{
+		all: all,
+		one: one,
+	}
+}
+
+/*
+scriptSelectorEngine is a selector engine implemented by JavaScript functions.
+*/
+type scriptSelectorEngine struct {
+	all string
+	one string
+}
+
+/*
+Query implements SelectorEngine.
+*/
+func (engine *scriptSelectorEngine) Query(ctx context.Context, root *ElementHandle, selector string) (*ElementHandle, error) {
+	return root.queryOne(ctx, engine.one, selector)
+}
+
+/*
+QueryAll implements SelectorEngine.
+*/
+func (engine *scriptSelectorEngine) QueryAll(ctx context.Context, root *ElementHandle, selector string) ([]*ElementHandle, error) {
+	return root.queryAll(ctx, engine.all, selector)
+}
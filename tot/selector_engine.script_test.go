@@ -0,0 +1,72 @@
+package chrome
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestScriptSelectorEngines(t *testing.T) {
+	tab, commands := newElementTab(t)
+	ctx := context.Background()
+	document := &ElementHandle{objectID: "document-1", tab: tab}
+
+	tests := []struct {
+		selector    string
+		declaration string
+		argument    string
+	}{
+		{`//a[@href]`, "XPathResult.ORDERED_NODE_SNAPSHOT_TYPE", `//a[@href]`},
+		{`xpath=//a`, "XPathResult.ORDERED_NODE_SNAPSHOT_TYPE", `//a`},
+		{`text="Sign in"`, "createTreeWalker", `"Sign in"`},
+		{`text=sign in`, "createTreeWalker", `sign in`},
+		{`pierce=button.submit`, "shadowRoot", `button.submit`},
+	}
+	for _, test := range tests {
+		if _, err := document.QuerySelector(ctx, test.selector); nil != err {
+			t.Fatalf("%s: expected nil, received error: '%s'", test.selector, err.Error())
+		}
+		if _, err := document.QuerySelectorAll(ctx, test.selector); nil != err {
+			t.Fatalf("%s: expected nil, received error: '%s'", test.selector, err.Error())
+		}
+
+		calls := []pageCommand{}
+		for _, command := range commands() {
+			if "Runtime.callFunctionOn" == command.Method {
+				calls = append(calls, command)
+			}
+		}
+		for _, call := range calls[len(calls)-2:] {
+			declaration, _ := call.Params["functionDeclaration"].(string)
+			if !strings.Contains(declaration, test.declaration) {
+				t.Errorf("%s: expected a declaration using %s, received '%s'", test.selector, test.declaration, declaration)
+			}
+			argument := call.Params["arguments"].([]interface{})[0].(map[string]interface{})["value"]
+			if test.argument != argument {
+				t.Errorf("%s: expected the argument '%s', received '%v'", test.selector, test.argument, argument)
+			}
+			if "document-1" != call.Params["objectId"] {
+				t.Errorf("%s: expected a call on document-1, received %v", test.selector, call.Params["objectId"])
+			}
+		}
+	}
+
+	elements, err := document.QuerySelectorAll(ctx, "pierce=a")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if 2 != len(elements) || "element-1" != elements[0].ObjectID() {
+		t.Errorf("Expected element-1 and element-2, received %v", elements)
+	}
+}
+
+func TestNewScriptSelectorEngine(t *testing.T) {
+	engine := NewScriptSelectorEngine(`function(selector) { return [] }`, "").(*scriptSelectorEngine)
+	if `function(selector) { return (function(selector) { return [] }).call(this, selector)[0] || null }` != engine.one {
+		t.Errorf("Expected the first element of all, received '%s'", engine.one)
+	}
+	engine = NewScriptSelectorEngine(`function(selector) { return [] }`, `function(selector) { return null }`).(*scriptSelectorEngine)
+	if `function(selector) { return null }` != engine.one {
+		t.Errorf("Expected the one function, received '%s'", engine.one)
+	}
+}
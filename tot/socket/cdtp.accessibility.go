@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/accessibility"
 )
@@ -17,6 +19,22 @@ type AccessibilityProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *AccessibilityProtocol) WithContext(ctx context.Context) *AccessibilityProtocol {
+	return &AccessibilityProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *AccessibilityProtocol) WithTimeout(timeout time.Duration) *AccessibilityProtocol {
+	return &AccessibilityProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 GetPartialAXTree fetches the accessibility node and partial accessibility tree
 for this DOM node, if it exists.
@@ -32,8 +50,37 @@ func (protocol *AccessibilityProtocol) GetPartialAXTree(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
+		} else {
+			result.Err = json.Unmarshal(response.Result, &result)
+		}
+		resultChan <- result
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+/*
+QueryAXTree queries a DOM node's accessibility subtree for accessible name and
+role. The name and role are computed for all nodes in the subtree, including
+those that are ignored for accessibility.
+
+https://chromedevtools.github.io/devtools-protocol/tot/Accessibility/#method-queryAXTree
+EXPERIMENTAL.
+*/
+func (protocol *AccessibilityProtocol) QueryAXTree(
+	params *accessibility.QueryAXTreeParams,
+) <-chan *accessibility.QueryAXTreeResult {
+	resultChan := make(chan *accessibility.QueryAXTreeResult)
+	command := NewCommand(protocol.Socket, "Accessibility.queryAXTree", params)
+	result := &accessibility.QueryAXTreeResult{}
+
+	go func() {
+		response := <-protocol.Socket.SendCommand(command)
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -106,3 +106,51 @@ func TestAccessibilityGetPartialAXTree(t *testing.T) {
 		t.Errorf("Expected error message, got empty string")
 	}
 }
+
+func TestAccessibilityQueryAXTree(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	params := &accessibility.QueryAXTreeParams{
+		ObjectID: "object-1",
+		Role:     "button",
+	}
+	resultChan := mockSocket.Accessibility().QueryAXTree(params)
+	mockResult := accessibility.QueryAXTreeResult{
+		Nodes: []*accessibility.AXNode{{
+			NodeID:           accessibility.AXNodeID("NodeID"),
+			Role:             &accessibility.AXValue{Value: "button"},
+			BackendDOMNodeID: dom.BackendNodeID(1),
+		}},
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected success, got error: %s", result.Err)
+	}
+	if 1 != len(result.Nodes) || dom.BackendNodeID(1) != result.Nodes[0].BackendDOMNodeID {
+		tmp, _ := json.Marshal(result.Nodes)
+		t.Errorf("Expected dataset, got '%s'", tmp)
+	}
+
+	resultChan = mockSocket.Accessibility().QueryAXTree(params)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID: mockSocket.CurCommandID(),
+		Error: &Error{
+			Code:    1,
+			Data:    []byte(`"error data"`),
+			Message: "error message",
+		},
+	})
+	result = <-resultChan
+	if nil == result.Err {
+		t.Errorf("Expected error, got success")
+	}
+}
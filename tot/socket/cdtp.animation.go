@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/animation"
 )
@@ -15,6 +17,22 @@ type AnimationProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *AnimationProtocol) WithContext(ctx context.Context) *AnimationProtocol {
+	return &AnimationProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *AnimationProtocol) WithTimeout(timeout time.Duration) *AnimationProtocol {
+	return &AnimationProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 Disable animation domain notifications.
 
@@ -27,8 +45,8 @@ func (protocol *AnimationProtocol) Disable() <-chan *animation.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -49,8 +67,8 @@ func (protocol *AnimationProtocol) Enable() <-chan *animation.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -73,8 +91,8 @@ func (protocol *AnimationProtocol) GetCurrentTime(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -97,8 +115,8 @@ func (protocol *AnimationProtocol) GetPlaybackRate() <-chan *animation.GetPlayba
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -123,8 +141,8 @@ func (protocol *AnimationProtocol) ReleaseAnimations(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -147,8 +165,8 @@ func (protocol *AnimationProtocol) ResolveAnimation(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -174,8 +192,8 @@ func (protocol *AnimationProtocol) SeekAnimations(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -198,8 +216,8 @@ func (protocol *AnimationProtocol) SetPaused(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -222,8 +240,8 @@ func (protocol *AnimationProtocol) SetPlaybackRate(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -246,8 +264,8 @@ func (protocol *AnimationProtocol) SetTiming(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -264,8 +282,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Animation/#event-animatio
 */
 func (protocol *AnimationProtocol) OnAnimationCanceled(
 	callback func(event *animation.CanceledEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newAnimationCanceledHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newAnimationCanceledHandler returns a Animation.animationCanceled event handler
+that has not been registered with the socket.
+*/
+func (protocol *AnimationProtocol) newAnimationCanceledHandler(
+	callback func(event *animation.CanceledEvent),
+) *Handler {
+	return NewEventHandler(
 		"Animation.animationCanceled",
 		func(response *Response) {
 			event := &animation.CanceledEvent{}
@@ -276,7 +306,36 @@ func (protocol *AnimationProtocol) OnAnimationCanceled(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeAnimationCanceled returns a subscription that delivers
+Animation.animationCanceled events on a typed channel until it is unsubscribed.
+See OnAnimationCanceled().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Animation/#event-animationCanceled
+*/
+func (protocol *AnimationProtocol) SubscribeAnimationCanceled(
+	options *SubscriptionOptions,
+) *Subscription[*animation.CanceledEvent] {
+	sub := NewSubscription[*animation.CanceledEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newAnimationCanceledHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForAnimationCanceled blocks until an Animation.animationCanceled event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Animation/#event-animationCanceled
+*/
+func (protocol *AnimationProtocol) WaitForAnimationCanceled(
+	ctx context.Context,
+	predicate func(event *animation.CanceledEvent) bool,
+) (*animation.CanceledEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeAnimationCanceled(nil), predicate)
 }
 
 /*
@@ -287,8 +346,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Animation/#event-animatio
 */
 func (protocol *AnimationProtocol) OnAnimationCreated(
 	callback func(event *animation.CreatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newAnimationCreatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newAnimationCreatedHandler returns a Animation.animationCreated event handler
+that has not been registered with the socket.
+*/
+func (protocol *AnimationProtocol) newAnimationCreatedHandler(
+	callback func(event *animation.CreatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Animation.animationCreated",
 		func(response *Response) {
 			event := &animation.CreatedEvent{}
@@ -299,7 +370,36 @@ func (protocol *AnimationProtocol) OnAnimationCreated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeAnimationCreated returns a subscription that delivers
+Animation.animationCreated events on a typed channel until it is unsubscribed.
+See OnAnimationCreated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Animation/#event-animationCreated
+*/
+func (protocol *AnimationProtocol) SubscribeAnimationCreated(
+	options *SubscriptionOptions,
+) *Subscription[*animation.CreatedEvent] {
+	sub := NewSubscription[*animation.CreatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newAnimationCreatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForAnimationCreated blocks until an Animation.animationCreated event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Animation/#event-animationCreated
+*/
+func (protocol *AnimationProtocol) WaitForAnimationCreated(
+	ctx context.Context,
+	predicate func(event *animation.CreatedEvent) bool,
+) (*animation.CreatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeAnimationCreated(nil), predicate)
 }
 
 /*
@@ -310,8 +410,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Animation/#event-animatio
 */
 func (protocol *AnimationProtocol) OnAnimationStarted(
 	callback func(event *animation.StartedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newAnimationStartedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newAnimationStartedHandler returns a Animation.animationStarted event handler
+that has not been registered with the socket.
+*/
+func (protocol *AnimationProtocol) newAnimationStartedHandler(
+	callback func(event *animation.StartedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Animation.animationStarted",
 		func(response *Response) {
 			event := &animation.StartedEvent{}
@@ -322,5 +434,34 @@ func (protocol *AnimationProtocol) OnAnimationStarted(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeAnimationStarted returns a subscription that delivers
+Animation.animationStarted events on a typed channel until it is unsubscribed.
+See OnAnimationStarted().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Animation/#event-animationStarted
+*/
+func (protocol *AnimationProtocol) SubscribeAnimationStarted(
+	options *SubscriptionOptions,
+) *Subscription[*animation.StartedEvent] {
+	sub := NewSubscription[*animation.StartedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newAnimationStartedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForAnimationStarted blocks until an Animation.animationStarted event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Animation/#event-animationStarted
+*/
+func (protocol *AnimationProtocol) WaitForAnimationStarted(
+	ctx context.Context,
+	predicate func(event *animation.StartedEvent) bool,
+) (*animation.StartedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeAnimationStarted(nil), predicate)
 }
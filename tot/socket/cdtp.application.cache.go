@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/application/cache"
 )
@@ -16,6 +18,22 @@ type ApplicationCacheProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *ApplicationCacheProtocol) WithContext(ctx context.Context) *ApplicationCacheProtocol {
+	return &ApplicationCacheProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *ApplicationCacheProtocol) WithTimeout(timeout time.Duration) *ApplicationCacheProtocol {
+	return &ApplicationCacheProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 Enable enables application cache domain notifications.
 
@@ -28,8 +46,8 @@ func (protocol *ApplicationCacheProtocol) Enable() <-chan *cache.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -53,8 +71,8 @@ func (protocol *ApplicationCacheProtocol) GetForFrame(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -78,8 +96,8 @@ func (protocol *ApplicationCacheProtocol) GetFramesWithManifests() <-chan *cache
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -104,8 +122,8 @@ func (protocol *ApplicationCacheProtocol) GetManifestForFrame(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -124,8 +142,21 @@ https://chromedevtools.github.io/devtools-protocol/tot/ApplicationCache/#event-a
 */
 func (protocol *ApplicationCacheProtocol) OnApplicationCacheStatusUpdated(
 	callback func(event *cache.StatusUpdatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newApplicationCacheStatusUpdatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newApplicationCacheStatusUpdatedHandler returns a
+ApplicationCache.applicationCacheStatusUpdated event handler that has not been
+registered with the socket.
+*/
+func (protocol *ApplicationCacheProtocol) newApplicationCacheStatusUpdatedHandler(
+	callback func(event *cache.StatusUpdatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"ApplicationCache.applicationCacheStatusUpdated",
 		func(response *Response) {
 			event := &cache.StatusUpdatedEvent{}
@@ -136,7 +167,37 @@ func (protocol *ApplicationCacheProtocol) OnApplicationCacheStatusUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeApplicationCacheStatusUpdated returns a subscription that delivers
+ApplicationCache.applicationCacheStatusUpdated events on a typed channel until
+it is unsubscribed. See OnApplicationCacheStatusUpdated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/ApplicationCache/#event-applicationCacheStatusUpdated
+*/
+func (protocol *ApplicationCacheProtocol) SubscribeApplicationCacheStatusUpdated(
+	options *SubscriptionOptions,
+) *Subscription[*cache.StatusUpdatedEvent] {
+	sub := NewSubscription[*cache.StatusUpdatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newApplicationCacheStatusUpdatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForApplicationCacheStatusUpdated blocks until an
+ApplicationCache.applicationCacheStatusUpdated event that satisfies the
+predicate is received and returns it. A nil predicate matches the first event.
+See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/ApplicationCache/#event-applicationCacheStatusUpdated
+*/
+func (protocol *ApplicationCacheProtocol) WaitForApplicationCacheStatusUpdated(
+	ctx context.Context,
+	predicate func(event *cache.StatusUpdatedEvent) bool,
+) (*cache.StatusUpdatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeApplicationCacheStatusUpdated(nil), predicate)
 }
 
 /*
@@ -146,8 +207,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/ApplicationCache/#event-n
 */
 func (protocol *ApplicationCacheProtocol) OnNetworkStateUpdated(
 	callback func(event *cache.NetworkStateUpdatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newNetworkStateUpdatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newNetworkStateUpdatedHandler returns a ApplicationCache.networkStateUpdated
+event handler that has not been registered with the socket.
+*/
+func (protocol *ApplicationCacheProtocol) newNetworkStateUpdatedHandler(
+	callback func(event *cache.NetworkStateUpdatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"ApplicationCache.networkStateUpdated",
 		func(response *Response) {
 			event := &cache.NetworkStateUpdatedEvent{}
@@ -158,5 +231,34 @@ func (protocol *ApplicationCacheProtocol) OnNetworkStateUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeNetworkStateUpdated returns a subscription that delivers
+ApplicationCache.networkStateUpdated events on a typed channel until it is
+unsubscribed. See OnNetworkStateUpdated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/ApplicationCache/#event-networkStateUpdated
+*/
+func (protocol *ApplicationCacheProtocol) SubscribeNetworkStateUpdated(
+	options *SubscriptionOptions,
+) *Subscription[*cache.NetworkStateUpdatedEvent] {
+	sub := NewSubscription[*cache.NetworkStateUpdatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newNetworkStateUpdatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForNetworkStateUpdated blocks until an ApplicationCache.networkStateUpdated
+event that satisfies the predicate is received and returns it. A nil predicate
+matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/ApplicationCache/#event-networkStateUpdated
+*/
+func (protocol *ApplicationCacheProtocol) WaitForNetworkStateUpdated(
+	ctx context.Context,
+	predicate func(event *cache.NetworkStateUpdatedEvent) bool,
+) (*cache.NetworkStateUpdatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeNetworkStateUpdated(nil), predicate)
 }
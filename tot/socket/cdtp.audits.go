@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/audits"
 )
@@ -17,6 +19,22 @@ type AuditsProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *AuditsProtocol) WithContext(ctx context.Context) *AuditsProtocol {
+	return &AuditsProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *AuditsProtocol) WithTimeout(timeout time.Duration) *AuditsProtocol {
+	return &AuditsProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 GetEncodedResponse returns the response body and size if it were re-encoded with
 the specified settings. Only applies to images.
@@ -32,8 +50,8 @@ func (protocol *AuditsProtocol) GetEncodedResponse(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
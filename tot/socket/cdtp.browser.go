@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/browser"
 )
@@ -16,6 +18,22 @@ type BrowserProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *BrowserProtocol) WithContext(ctx context.Context) *BrowserProtocol {
+	return &BrowserProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *BrowserProtocol) WithTimeout(timeout time.Duration) *BrowserProtocol {
+	return &BrowserProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 Close closes the browser gracefully.
 
@@ -28,8 +46,8 @@ func (protocol *BrowserProtocol) Close() <-chan *browser.CloseResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -50,8 +68,8 @@ func (protocol *BrowserProtocol) GetVersion() <-chan *browser.GetVersionResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -76,8 +94,8 @@ func (protocol *BrowserProtocol) GetWindowBounds(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -103,8 +121,8 @@ func (protocol *BrowserProtocol) GetWindowForTarget(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -129,8 +147,8 @@ func (protocol *BrowserProtocol) SetWindowBounds(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
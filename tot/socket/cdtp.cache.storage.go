@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/cache/storage"
 )
@@ -16,6 +18,22 @@ type CacheStorageProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *CacheStorageProtocol) WithContext(ctx context.Context) *CacheStorageProtocol {
+	return &CacheStorageProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *CacheStorageProtocol) WithTimeout(timeout time.Duration) *CacheStorageProtocol {
+	return &CacheStorageProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 DeleteCache deletes a cache.
 
@@ -30,8 +48,8 @@ func (protocol *CacheStorageProtocol) DeleteCache(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -54,8 +72,8 @@ func (protocol *CacheStorageProtocol) DeleteEntry(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -78,8 +96,8 @@ func (protocol *CacheStorageProtocol) RequestCacheNames(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -104,8 +122,8 @@ func (protocol *CacheStorageProtocol) RequestCachedResponse(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -130,8 +148,8 @@ func (protocol *CacheStorageProtocol) RequestEntries(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
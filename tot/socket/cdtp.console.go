@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/console"
 )
@@ -16,6 +18,22 @@ type ConsoleProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *ConsoleProtocol) WithContext(ctx context.Context) *ConsoleProtocol {
+	return &ConsoleProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *ConsoleProtocol) WithTimeout(timeout time.Duration) *ConsoleProtocol {
+	return &ConsoleProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 ClearMessages does nothing.
 
@@ -28,8 +46,8 @@ func (protocol *ConsoleProtocol) ClearMessages() <-chan *console.ClearMessagesRe
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -51,8 +69,8 @@ func (protocol *ConsoleProtocol) Disable() <-chan *console.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -74,8 +92,8 @@ func (protocol *ConsoleProtocol) Enable() <-chan *console.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -92,8 +110,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Console/#event-messageAdd
 */
 func (protocol *ConsoleProtocol) OnMessageAdded(
 	callback func(event *console.MessageAddedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newMessageAddedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newMessageAddedHandler returns a Console.messageAdded event handler that has not
+been registered with the socket.
+*/
+func (protocol *ConsoleProtocol) newMessageAddedHandler(
+	callback func(event *console.MessageAddedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Console.messageAdded",
 		func(response *Response) {
 			event := &console.MessageAddedEvent{}
@@ -104,5 +134,33 @@ func (protocol *ConsoleProtocol) OnMessageAdded(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeMessageAdded returns a subscription that delivers Console.messageAdded
+events on a typed channel until it is unsubscribed. See OnMessageAdded().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Console/#event-messageAdded
+*/
+func (protocol *ConsoleProtocol) SubscribeMessageAdded(
+	options *SubscriptionOptions,
+) *Subscription[*console.MessageAddedEvent] {
+	sub := NewSubscription[*console.MessageAddedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newMessageAddedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForMessageAdded blocks until a Console.messageAdded event that satisfies the
+predicate is received and returns it. A nil predicate matches the first event.
+See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Console/#event-messageAdded
+*/
+func (protocol *ConsoleProtocol) WaitForMessageAdded(
+	ctx context.Context,
+	predicate func(event *console.MessageAddedEvent) bool,
+) (*console.MessageAddedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeMessageAdded(nil), predicate)
 }
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/css"
 )
@@ -23,6 +25,22 @@ type CSSProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *CSSProtocol) WithContext(ctx context.Context) *CSSProtocol {
+	return &CSSProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *CSSProtocol) WithTimeout(timeout time.Duration) *CSSProtocol {
+	return &CSSProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 AddRule inserts a new rule with the given ruleText in a stylesheet with given
 styleSheetId, at the position specified by location.
@@ -38,8 +56,8 @@ func (protocol *CSSProtocol) AddRule(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -64,8 +82,8 @@ func (protocol *CSSProtocol) CollectClassNames(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -91,8 +109,8 @@ func (protocol *CSSProtocol) CreateStyleSheet(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -115,8 +133,8 @@ func (protocol *CSSProtocol) Disable() <-chan *css.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -138,8 +156,8 @@ func (protocol *CSSProtocol) Enable() <-chan *css.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -163,8 +181,8 @@ func (protocol *CSSProtocol) ForcePseudoState(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -187,8 +205,8 @@ func (protocol *CSSProtocol) GetBackgroundColors(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -214,8 +232,8 @@ func (protocol *CSSProtocol) GetComputedStyleForNode(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -242,8 +260,8 @@ func (protocol *CSSProtocol) GetInlineStylesForNode(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -269,8 +287,8 @@ func (protocol *CSSProtocol) GetMatchedStylesForNode(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -293,8 +311,8 @@ func (protocol *CSSProtocol) GetMediaQueries() <-chan *css.GetMediaQueriesResult
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -320,8 +338,8 @@ func (protocol *CSSProtocol) GetPlatformFontsForNode(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -347,8 +365,8 @@ func (protocol *CSSProtocol) GetStyleSheetText(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -374,8 +392,8 @@ func (protocol *CSSProtocol) SetEffectivePropertyValueForNode(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -398,8 +416,8 @@ func (protocol *CSSProtocol) SetKeyframeKey(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -424,8 +442,8 @@ func (protocol *CSSProtocol) SetMediaText(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -450,8 +468,8 @@ func (protocol *CSSProtocol) SetRuleSelector(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -476,8 +494,8 @@ func (protocol *CSSProtocol) SetStyleSheetText(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -502,8 +520,8 @@ func (protocol *CSSProtocol) SetStyleTexts(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -526,8 +544,8 @@ func (protocol *CSSProtocol) StartRuleUsageTracking() <-chan *css.StartRuleUsage
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -549,8 +567,8 @@ func (protocol *CSSProtocol) StopRuleUsageTracking() <-chan *css.StopRuleUsageTr
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -574,8 +592,8 @@ func (protocol *CSSProtocol) TakeCoverageDelta() <-chan *css.TakeCoverageDeltaRe
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -594,8 +612,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-fontsUpdated
 */
 func (protocol *CSSProtocol) OnFontsUpdated(
 	callback func(event *css.FontsUpdatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newFontsUpdatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newFontsUpdatedHandler returns a CSS.fontsUpdated event handler that has not
+been registered with the socket.
+*/
+func (protocol *CSSProtocol) newFontsUpdatedHandler(
+	callback func(event *css.FontsUpdatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"CSS.fontsUpdated",
 		func(response *Response) {
 			event := &css.FontsUpdatedEvent{}
@@ -606,7 +636,35 @@ func (protocol *CSSProtocol) OnFontsUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeFontsUpdated returns a subscription that delivers CSS.fontsUpdated
+events on a typed channel until it is unsubscribed. See OnFontsUpdated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-fontsUpdated
+*/
+func (protocol *CSSProtocol) SubscribeFontsUpdated(
+	options *SubscriptionOptions,
+) *Subscription[*css.FontsUpdatedEvent] {
+	sub := NewSubscription[*css.FontsUpdatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newFontsUpdatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForFontsUpdated blocks until a CSS.fontsUpdated event that satisfies the
+predicate is received and returns it. A nil predicate matches the first event.
+See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-fontsUpdated
+*/
+func (protocol *CSSProtocol) WaitForFontsUpdated(
+	ctx context.Context,
+	predicate func(event *css.FontsUpdatedEvent) bool,
+) (*css.FontsUpdatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeFontsUpdated(nil), predicate)
 }
 
 /*
@@ -619,8 +677,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-mediaQueryResu
 */
 func (protocol *CSSProtocol) OnMediaQueryResultChanged(
 	callback func(event *css.MediaQueryResultChangedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newMediaQueryResultChangedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newMediaQueryResultChangedHandler returns a CSS.mediaQueryResultChanged event
+handler that has not been registered with the socket.
+*/
+func (protocol *CSSProtocol) newMediaQueryResultChangedHandler(
+	callback func(event *css.MediaQueryResultChangedEvent),
+) *Handler {
+	return NewEventHandler(
 		"CSS.mediaQueryResultChanged",
 		func(response *Response) {
 			event := &css.MediaQueryResultChangedEvent{}
@@ -631,7 +701,36 @@ func (protocol *CSSProtocol) OnMediaQueryResultChanged(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeMediaQueryResultChanged returns a subscription that delivers
+CSS.mediaQueryResultChanged events on a typed channel until it is unsubscribed.
+See OnMediaQueryResultChanged().
+
+https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-mediaQueryResultChanged
+*/
+func (protocol *CSSProtocol) SubscribeMediaQueryResultChanged(
+	options *SubscriptionOptions,
+) *Subscription[*css.MediaQueryResultChangedEvent] {
+	sub := NewSubscription[*css.MediaQueryResultChangedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newMediaQueryResultChangedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForMediaQueryResultChanged blocks until a CSS.mediaQueryResultChanged event
+that satisfies the predicate is received and returns it. A nil predicate matches
+the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-mediaQueryResultChanged
+*/
+func (protocol *CSSProtocol) WaitForMediaQueryResultChanged(
+	ctx context.Context,
+	predicate func(event *css.MediaQueryResultChangedEvent) bool,
+) (*css.MediaQueryResultChangedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeMediaQueryResultChanged(nil), predicate)
 }
 
 /*
@@ -642,8 +741,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-styleSheetAdde
 */
 func (protocol *CSSProtocol) OnStyleSheetAdded(
 	callback func(event *css.StyleSheetAddedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newStyleSheetAddedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newStyleSheetAddedHandler returns a CSS.styleSheetAdded event handler that has
+not been registered with the socket.
+*/
+func (protocol *CSSProtocol) newStyleSheetAddedHandler(
+	callback func(event *css.StyleSheetAddedEvent),
+) *Handler {
+	return NewEventHandler(
 		"CSS.styleSheetAdded",
 		func(response *Response) {
 			event := &css.StyleSheetAddedEvent{}
@@ -654,7 +765,36 @@ func (protocol *CSSProtocol) OnStyleSheetAdded(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeStyleSheetAdded returns a subscription that delivers
+CSS.styleSheetAdded events on a typed channel until it is unsubscribed. See
+OnStyleSheetAdded().
+
+https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-styleSheetAdded
+*/
+func (protocol *CSSProtocol) SubscribeStyleSheetAdded(
+	options *SubscriptionOptions,
+) *Subscription[*css.StyleSheetAddedEvent] {
+	sub := NewSubscription[*css.StyleSheetAddedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newStyleSheetAddedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForStyleSheetAdded blocks until a CSS.styleSheetAdded event that satisfies
+the predicate is received and returns it. A nil predicate matches the first
+event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-styleSheetAdded
+*/
+func (protocol *CSSProtocol) WaitForStyleSheetAdded(
+	ctx context.Context,
+	predicate func(event *css.StyleSheetAddedEvent) bool,
+) (*css.StyleSheetAddedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeStyleSheetAdded(nil), predicate)
 }
 
 /*
@@ -666,8 +806,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-styleSheetChan
 */
 func (protocol *CSSProtocol) OnStyleSheetChanged(
 	callback func(event *css.StyleSheetChangedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newStyleSheetChangedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newStyleSheetChangedHandler returns a CSS.styleSheetChanged event handler that
+has not been registered with the socket.
+*/
+func (protocol *CSSProtocol) newStyleSheetChangedHandler(
+	callback func(event *css.StyleSheetChangedEvent),
+) *Handler {
+	return NewEventHandler(
 		"CSS.styleSheetChanged",
 		func(response *Response) {
 			event := &css.StyleSheetChangedEvent{}
@@ -678,7 +830,36 @@ func (protocol *CSSProtocol) OnStyleSheetChanged(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeStyleSheetChanged returns a subscription that delivers
+CSS.styleSheetChanged events on a typed channel until it is unsubscribed. See
+OnStyleSheetChanged().
+
+https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-styleSheetChanged
+*/
+func (protocol *CSSProtocol) SubscribeStyleSheetChanged(
+	options *SubscriptionOptions,
+) *Subscription[*css.StyleSheetChangedEvent] {
+	sub := NewSubscription[*css.StyleSheetChangedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newStyleSheetChangedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForStyleSheetChanged blocks until a CSS.styleSheetChanged event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-styleSheetChanged
+*/
+func (protocol *CSSProtocol) WaitForStyleSheetChanged(
+	ctx context.Context,
+	predicate func(event *css.StyleSheetChangedEvent) bool,
+) (*css.StyleSheetChangedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeStyleSheetChanged(nil), predicate)
 }
 
 /*
@@ -689,8 +870,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-styleSheetRemo
 */
 func (protocol *CSSProtocol) OnStyleSheetRemoved(
 	callback func(event *css.StyleSheetRemovedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newStyleSheetRemovedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newStyleSheetRemovedHandler returns a CSS.styleSheetRemoved event handler that
+has not been registered with the socket.
+*/
+func (protocol *CSSProtocol) newStyleSheetRemovedHandler(
+	callback func(event *css.StyleSheetRemovedEvent),
+) *Handler {
+	return NewEventHandler(
 		"CSS.styleSheetRemoved",
 		func(response *Response) {
 			event := &css.StyleSheetRemovedEvent{}
@@ -701,5 +894,34 @@ func (protocol *CSSProtocol) OnStyleSheetRemoved(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeStyleSheetRemoved returns a subscription that delivers
+CSS.styleSheetRemoved events on a typed channel until it is unsubscribed. See
+OnStyleSheetRemoved().
+
+https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-styleSheetRemoved
+*/
+func (protocol *CSSProtocol) SubscribeStyleSheetRemoved(
+	options *SubscriptionOptions,
+) *Subscription[*css.StyleSheetRemovedEvent] {
+	sub := NewSubscription[*css.StyleSheetRemovedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newStyleSheetRemovedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForStyleSheetRemoved blocks until a CSS.styleSheetRemoved event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/CSS/#event-styleSheetRemoved
+*/
+func (protocol *CSSProtocol) WaitForStyleSheetRemoved(
+	ctx context.Context,
+	predicate func(event *css.StyleSheetRemovedEvent) bool,
+) (*css.StyleSheetRemovedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeStyleSheetRemoved(nil), predicate)
 }
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/database"
 )
@@ -15,6 +17,22 @@ type DatabaseProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *DatabaseProtocol) WithContext(ctx context.Context) *DatabaseProtocol {
+	return &DatabaseProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *DatabaseProtocol) WithTimeout(timeout time.Duration) *DatabaseProtocol {
+	return &DatabaseProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 Disable disables database tracking, prevents database events from being sent to
 the client.
@@ -28,8 +46,8 @@ func (protocol *DatabaseProtocol) Disable() <-chan *database.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -51,8 +69,8 @@ func (protocol *DatabaseProtocol) Enable() <-chan *database.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -75,8 +93,8 @@ func (protocol *DatabaseProtocol) ExecuteSQL(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -101,8 +119,8 @@ func (protocol *DatabaseProtocol) GetTableNames(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -121,8 +139,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Database/#event-addDataba
 */
 func (protocol *DatabaseProtocol) OnAdd(
 	callback func(event *database.AddEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newAddHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newAddHandler returns a Database.addDatabase event handler that has not been
+registered with the socket.
+*/
+func (protocol *DatabaseProtocol) newAddHandler(
+	callback func(event *database.AddEvent),
+) *Handler {
+	return NewEventHandler(
 		"Database.addDatabase",
 		func(response *Response) {
 			event := &database.AddEvent{}
@@ -133,5 +163,33 @@ func (protocol *DatabaseProtocol) OnAdd(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeAdd returns a subscription that delivers Database.addDatabase events on
+a typed channel until it is unsubscribed. See OnAdd().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Database/#event-addDatabase
+*/
+func (protocol *DatabaseProtocol) SubscribeAdd(
+	options *SubscriptionOptions,
+) *Subscription[*database.AddEvent] {
+	sub := NewSubscription[*database.AddEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newAddHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForAdd blocks until a Database.addDatabase event that satisfies the
+predicate is received and returns it. A nil predicate matches the first event.
+See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Database/#event-addDatabase
+*/
+func (protocol *DatabaseProtocol) WaitForAdd(
+	ctx context.Context,
+	predicate func(event *database.AddEvent) bool,
+) (*database.AddEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeAdd(nil), predicate)
 }
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/debugger"
 )
@@ -18,6 +20,22 @@ type DebuggerProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *DebuggerProtocol) WithContext(ctx context.Context) *DebuggerProtocol {
+	return &DebuggerProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *DebuggerProtocol) WithTimeout(timeout time.Duration) *DebuggerProtocol {
+	return &DebuggerProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 ContinueToLocation continues execution until specific location is reached.
 
@@ -32,8 +50,8 @@ func (protocol *DebuggerProtocol) ContinueToLocation(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -54,8 +72,8 @@ func (protocol *DebuggerProtocol) Disable() <-chan *debugger.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -77,8 +95,8 @@ func (protocol *DebuggerProtocol) Enable() <-chan *debugger.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -103,8 +121,8 @@ func (protocol *DebuggerProtocol) EvaluateOnCallFrame(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -130,8 +148,8 @@ func (protocol *DebuggerProtocol) GetPossibleBreakpoints(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -156,8 +174,8 @@ func (protocol *DebuggerProtocol) GetScriptSource(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -182,8 +200,8 @@ func (protocol *DebuggerProtocol) GetStackTrace(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -206,8 +224,8 @@ func (protocol *DebuggerProtocol) Pause() <-chan *debugger.PauseResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -230,8 +248,8 @@ func (protocol *DebuggerProtocol) PauseOnAsyncCall(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -254,8 +272,8 @@ func (protocol *DebuggerProtocol) RemoveBreakpoint(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -278,8 +296,8 @@ func (protocol *DebuggerProtocol) RestartFrame(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -302,8 +320,8 @@ func (protocol *DebuggerProtocol) Resume() <-chan *debugger.ResumeResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -329,8 +347,8 @@ func (protocol *DebuggerProtocol) ScheduleStepIntoAsync() <-chan *debugger.Sched
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -353,8 +371,8 @@ func (protocol *DebuggerProtocol) SearchInContent(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -379,8 +397,8 @@ func (protocol *DebuggerProtocol) SetAsyncCallStackDepth(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -408,8 +426,8 @@ func (protocol *DebuggerProtocol) SetBlackboxPatterns(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -435,8 +453,8 @@ func (protocol *DebuggerProtocol) SetBlackboxedRanges(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -459,8 +477,8 @@ func (protocol *DebuggerProtocol) SetBreakpoint(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -488,8 +506,8 @@ func (protocol *DebuggerProtocol) SetBreakpointByURL(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -514,8 +532,8 @@ func (protocol *DebuggerProtocol) SetBreakpointsActive(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -539,8 +557,8 @@ func (protocol *DebuggerProtocol) SetPauseOnExceptions(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -564,8 +582,8 @@ func (protocol *DebuggerProtocol) SetReturnValue(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -588,8 +606,8 @@ func (protocol *DebuggerProtocol) SetScriptSource(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -614,8 +632,8 @@ func (protocol *DebuggerProtocol) SetSkipAllPauses(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -639,8 +657,8 @@ func (protocol *DebuggerProtocol) SetVariableValue(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -663,8 +681,8 @@ func (protocol *DebuggerProtocol) StepInto(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -685,8 +703,8 @@ func (protocol *DebuggerProtocol) StepOut() <-chan *debugger.StepOutResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -707,8 +725,8 @@ func (protocol *DebuggerProtocol) StepOver() <-chan *debugger.StepOverResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -725,8 +743,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-breakpoin
 */
 func (protocol *DebuggerProtocol) OnBreakpointResolved(
 	callback func(event *debugger.BreakpointResolvedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newBreakpointResolvedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newBreakpointResolvedHandler returns a Debugger.breakpointResolved event handler
+that has not been registered with the socket.
+*/
+func (protocol *DebuggerProtocol) newBreakpointResolvedHandler(
+	callback func(event *debugger.BreakpointResolvedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Debugger.breakpointResolved",
 		func(response *Response) {
 			event := &debugger.BreakpointResolvedEvent{}
@@ -737,7 +767,36 @@ func (protocol *DebuggerProtocol) OnBreakpointResolved(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeBreakpointResolved returns a subscription that delivers
+Debugger.breakpointResolved events on a typed channel until it is unsubscribed.
+See OnBreakpointResolved().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-breakpointResolved
+*/
+func (protocol *DebuggerProtocol) SubscribeBreakpointResolved(
+	options *SubscriptionOptions,
+) *Subscription[*debugger.BreakpointResolvedEvent] {
+	sub := NewSubscription[*debugger.BreakpointResolvedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newBreakpointResolvedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForBreakpointResolved blocks until a Debugger.breakpointResolved event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-breakpointResolved
+*/
+func (protocol *DebuggerProtocol) WaitForBreakpointResolved(
+	ctx context.Context,
+	predicate func(event *debugger.BreakpointResolvedEvent) bool,
+) (*debugger.BreakpointResolvedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeBreakpointResolved(nil), predicate)
 }
 
 /*
@@ -748,8 +807,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-paused
 */
 func (protocol *DebuggerProtocol) OnPaused(
 	callback func(event *debugger.PausedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newPausedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newPausedHandler returns a Debugger.paused event handler that has not been
+registered with the socket.
+*/
+func (protocol *DebuggerProtocol) newPausedHandler(
+	callback func(event *debugger.PausedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Debugger.paused",
 		func(response *Response) {
 			event := &debugger.PausedEvent{}
@@ -760,7 +831,35 @@ func (protocol *DebuggerProtocol) OnPaused(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribePaused returns a subscription that delivers Debugger.paused events on a
+typed channel until it is unsubscribed. See OnPaused().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-paused
+*/
+func (protocol *DebuggerProtocol) SubscribePaused(
+	options *SubscriptionOptions,
+) *Subscription[*debugger.PausedEvent] {
+	sub := NewSubscription[*debugger.PausedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newPausedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForPaused blocks until a Debugger.paused event that satisfies the predicate
+is received and returns it. A nil predicate matches the first event. See
+WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-paused
+*/
+func (protocol *DebuggerProtocol) WaitForPaused(
+	ctx context.Context,
+	predicate func(event *debugger.PausedEvent) bool,
+) (*debugger.PausedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribePaused(nil), predicate)
 }
 
 /*
@@ -771,8 +870,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-resumed
 */
 func (protocol *DebuggerProtocol) OnResumed(
 	callback func(event *debugger.ResumedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newResumedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newResumedHandler returns a Debugger.resumed event handler that has not been
+registered with the socket.
+*/
+func (protocol *DebuggerProtocol) newResumedHandler(
+	callback func(event *debugger.ResumedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Debugger.resumed",
 		func(response *Response) {
 			event := &debugger.ResumedEvent{}
@@ -783,7 +894,35 @@ func (protocol *DebuggerProtocol) OnResumed(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeResumed returns a subscription that delivers Debugger.resumed events on
+a typed channel until it is unsubscribed. See OnResumed().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-resumed
+*/
+func (protocol *DebuggerProtocol) SubscribeResumed(
+	options *SubscriptionOptions,
+) *Subscription[*debugger.ResumedEvent] {
+	sub := NewSubscription[*debugger.ResumedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newResumedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForResumed blocks until a Debugger.resumed event that satisfies the
+predicate is received and returns it. A nil predicate matches the first event.
+See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-resumed
+*/
+func (protocol *DebuggerProtocol) WaitForResumed(
+	ctx context.Context,
+	predicate func(event *debugger.ResumedEvent) bool,
+) (*debugger.ResumedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeResumed(nil), predicate)
 }
 
 /*
@@ -794,8 +933,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-scriptFai
 */
 func (protocol *DebuggerProtocol) OnScriptFailedToParse(
 	callback func(event *debugger.ScriptFailedToParseEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newScriptFailedToParseHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newScriptFailedToParseHandler returns a Debugger.scriptFailedToParse event
+handler that has not been registered with the socket.
+*/
+func (protocol *DebuggerProtocol) newScriptFailedToParseHandler(
+	callback func(event *debugger.ScriptFailedToParseEvent),
+) *Handler {
+	return NewEventHandler(
 		"Debugger.scriptFailedToParse",
 		func(response *Response) {
 			event := &debugger.ScriptFailedToParseEvent{}
@@ -806,7 +957,36 @@ func (protocol *DebuggerProtocol) OnScriptFailedToParse(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeScriptFailedToParse returns a subscription that delivers
+Debugger.scriptFailedToParse events on a typed channel until it is unsubscribed.
+See OnScriptFailedToParse().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-scriptFailedToParse
+*/
+func (protocol *DebuggerProtocol) SubscribeScriptFailedToParse(
+	options *SubscriptionOptions,
+) *Subscription[*debugger.ScriptFailedToParseEvent] {
+	sub := NewSubscription[*debugger.ScriptFailedToParseEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newScriptFailedToParseHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForScriptFailedToParse blocks until a Debugger.scriptFailedToParse event
+that satisfies the predicate is received and returns it. A nil predicate matches
+the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-scriptFailedToParse
+*/
+func (protocol *DebuggerProtocol) WaitForScriptFailedToParse(
+	ctx context.Context,
+	predicate func(event *debugger.ScriptFailedToParseEvent) bool,
+) (*debugger.ScriptFailedToParseEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeScriptFailedToParse(nil), predicate)
 }
 
 /*
@@ -818,8 +998,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-scriptPar
 */
 func (protocol *DebuggerProtocol) OnScriptParsed(
 	callback func(event *debugger.ScriptParsedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newScriptParsedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newScriptParsedHandler returns a Debugger.scriptParsed event handler that has
+not been registered with the socket.
+*/
+func (protocol *DebuggerProtocol) newScriptParsedHandler(
+	callback func(event *debugger.ScriptParsedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Debugger.scriptParsed",
 		func(response *Response) {
 			event := &debugger.ScriptParsedEvent{}
@@ -830,5 +1022,33 @@ func (protocol *DebuggerProtocol) OnScriptParsed(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeScriptParsed returns a subscription that delivers Debugger.scriptParsed
+events on a typed channel until it is unsubscribed. See OnScriptParsed().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-scriptParsed
+*/
+func (protocol *DebuggerProtocol) SubscribeScriptParsed(
+	options *SubscriptionOptions,
+) *Subscription[*debugger.ScriptParsedEvent] {
+	sub := NewSubscription[*debugger.ScriptParsedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newScriptParsedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForScriptParsed blocks until a Debugger.scriptParsed event that satisfies
+the predicate is received and returns it. A nil predicate matches the first
+event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#event-scriptParsed
+*/
+func (protocol *DebuggerProtocol) WaitForScriptParsed(
+	ctx context.Context,
+	predicate func(event *debugger.ScriptParsedEvent) bool,
+) (*debugger.ScriptParsedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeScriptParsed(nil), predicate)
 }
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"github.com/mkenney/go-chrome/tot/cdtp/device/orientation"
+	"time"
 )
 
 /*
@@ -15,6 +17,22 @@ type DeviceOrientationProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *DeviceOrientationProtocol) WithContext(ctx context.Context) *DeviceOrientationProtocol {
+	return &DeviceOrientationProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *DeviceOrientationProtocol) WithTimeout(timeout time.Duration) *DeviceOrientationProtocol {
+	return &DeviceOrientationProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 ClearOverride clears the overridden Device Orientation.
 
@@ -27,8 +45,8 @@ func (protocol *DeviceOrientationProtocol) ClearOverride() <-chan *orientation.C
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -51,8 +69,8 @@ func (protocol *DeviceOrientationProtocol) SetOverride(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/dom/debugger"
 )
@@ -18,6 +20,22 @@ type DOMDebuggerProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *DOMDebuggerProtocol) WithContext(ctx context.Context) *DOMDebuggerProtocol {
+	return &DOMDebuggerProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *DOMDebuggerProtocol) WithTimeout(timeout time.Duration) *DOMDebuggerProtocol {
+	return &DOMDebuggerProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 GetEventListeners returns event listeners of the given object.
 
@@ -32,8 +50,8 @@ func (protocol *DOMDebuggerProtocol) GetEventListeners(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -59,8 +77,8 @@ func (protocol *DOMDebuggerProtocol) RemoveDOMBreakpoint(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -83,8 +101,8 @@ func (protocol *DOMDebuggerProtocol) RemoveEventListenerBreakpoint(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -108,8 +126,8 @@ func (protocol *DOMDebuggerProtocol) RemoveInstrumentationBreakpoint(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -132,8 +150,8 @@ func (protocol *DOMDebuggerProtocol) RemoveXHRBreakpoint(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -156,8 +174,8 @@ func (protocol *DOMDebuggerProtocol) SetDOMBreakpoint(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -180,8 +198,8 @@ func (protocol *DOMDebuggerProtocol) SetEventListenerBreakpoint(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -205,8 +223,8 @@ func (protocol *DOMDebuggerProtocol) SetInstrumentationBreakpoint(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -229,8 +247,8 @@ func (protocol *DOMDebuggerProtocol) SetXHRBreakpoint(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/dom"
 )
@@ -24,6 +26,22 @@ type DOMProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *DOMProtocol) WithContext(ctx context.Context) *DOMProtocol {
+	return &DOMProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *DOMProtocol) WithTimeout(timeout time.Duration) *DOMProtocol {
+	return &DOMProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 CollectClassNamesFromSubtree creates a deep copy of the specified node and
 places it into the target container before the given anchor.
@@ -40,8 +58,8 @@ func (protocol *DOMProtocol) CollectClassNamesFromSubtree(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -67,8 +85,8 @@ func (protocol *DOMProtocol) CopyTo(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -94,8 +112,8 @@ func (protocol *DOMProtocol) DescribeNode(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -118,8 +136,8 @@ func (protocol *DOMProtocol) Disable() <-chan *dom.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -144,8 +162,8 @@ func (protocol *DOMProtocol) DiscardSearchResults(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -166,8 +184,8 @@ func (protocol *DOMProtocol) Enable() <-chan *dom.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -190,8 +208,8 @@ func (protocol *DOMProtocol) Focus(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -214,8 +232,8 @@ func (protocol *DOMProtocol) GetAttributes(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -240,8 +258,8 @@ func (protocol *DOMProtocol) GetBoxModel(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -267,8 +285,8 @@ func (protocol *DOMProtocol) GetDocument(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -294,8 +312,8 @@ func (protocol *DOMProtocol) GetFlattenedDocument(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -320,8 +338,8 @@ func (protocol *DOMProtocol) GetNodeForLocation(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -346,8 +364,8 @@ func (protocol *DOMProtocol) GetOuterHTML(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -373,8 +391,8 @@ func (protocol *DOMProtocol) GetRelayoutBoundary(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -401,8 +419,8 @@ func (protocol *DOMProtocol) GetSearchResults(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -426,8 +444,8 @@ func (protocol *DOMProtocol) MarkUndoableState() <-chan *dom.MarkUndoableStateRe
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -450,8 +468,8 @@ func (protocol *DOMProtocol) MoveTo(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -478,8 +496,8 @@ func (protocol *DOMProtocol) PerformSearch(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -506,8 +524,8 @@ func (protocol *DOMProtocol) PushNodeByPathToFrontend(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -534,8 +552,8 @@ func (protocol *DOMProtocol) PushNodesByBackendIDsToFrontend(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -560,8 +578,8 @@ func (protocol *DOMProtocol) QuerySelector(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -586,8 +604,8 @@ func (protocol *DOMProtocol) QuerySelectorAll(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -610,8 +628,8 @@ func (protocol *DOMProtocol) Redo() <-chan *dom.RedoResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -634,8 +652,8 @@ func (protocol *DOMProtocol) RemoveAttribute(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -658,8 +676,8 @@ func (protocol *DOMProtocol) RemoveNode(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -684,8 +702,8 @@ func (protocol *DOMProtocol) RequestChildNodes(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -710,8 +728,8 @@ func (protocol *DOMProtocol) RequestNode(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -737,8 +755,8 @@ func (protocol *DOMProtocol) ResolveNode(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -763,8 +781,8 @@ func (protocol *DOMProtocol) SetAttributeValue(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -789,8 +807,8 @@ func (protocol *DOMProtocol) SetAttributesAsText(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -813,8 +831,8 @@ func (protocol *DOMProtocol) SetFileInputFiles(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -838,8 +856,8 @@ func (protocol *DOMProtocol) SetInspectedNode(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -862,8 +880,8 @@ func (protocol *DOMProtocol) SetNodeName(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -888,8 +906,8 @@ func (protocol *DOMProtocol) SetNodeValue(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -912,8 +930,8 @@ func (protocol *DOMProtocol) SetOuterHTML(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -935,8 +953,8 @@ func (protocol *DOMProtocol) Undo() <-chan *dom.UndoResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -953,8 +971,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-attributeModif
 */
 func (protocol *DOMProtocol) OnAttributeModified(
 	callback func(event *dom.AttributeModifiedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newAttributeModifiedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newAttributeModifiedHandler returns a DOM.attributeModified event handler that
+has not been registered with the socket.
+*/
+func (protocol *DOMProtocol) newAttributeModifiedHandler(
+	callback func(event *dom.AttributeModifiedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOM.attributeModified",
 		func(response *Response) {
 			event := &dom.AttributeModifiedEvent{}
@@ -965,7 +995,36 @@ func (protocol *DOMProtocol) OnAttributeModified(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeAttributeModified returns a subscription that delivers
+DOM.attributeModified events on a typed channel until it is unsubscribed. See
+OnAttributeModified().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-attributeModified
+*/
+func (protocol *DOMProtocol) SubscribeAttributeModified(
+	options *SubscriptionOptions,
+) *Subscription[*dom.AttributeModifiedEvent] {
+	sub := NewSubscription[*dom.AttributeModifiedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newAttributeModifiedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForAttributeModified blocks until a DOM.attributeModified event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-attributeModified
+*/
+func (protocol *DOMProtocol) WaitForAttributeModified(
+	ctx context.Context,
+	predicate func(event *dom.AttributeModifiedEvent) bool,
+) (*dom.AttributeModifiedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeAttributeModified(nil), predicate)
 }
 
 /*
@@ -976,8 +1035,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-attributeRemov
 */
 func (protocol *DOMProtocol) OnAttributeRemoved(
 	callback func(event *dom.AttributeRemovedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newAttributeRemovedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newAttributeRemovedHandler returns a DOM.attributeRemoved event handler that has
+not been registered with the socket.
+*/
+func (protocol *DOMProtocol) newAttributeRemovedHandler(
+	callback func(event *dom.AttributeRemovedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOM.attributeRemoved",
 		func(response *Response) {
 			event := &dom.AttributeRemovedEvent{}
@@ -988,7 +1059,36 @@ func (protocol *DOMProtocol) OnAttributeRemoved(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeAttributeRemoved returns a subscription that delivers
+DOM.attributeRemoved events on a typed channel until it is unsubscribed. See
+OnAttributeRemoved().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-attributeRemoved
+*/
+func (protocol *DOMProtocol) SubscribeAttributeRemoved(
+	options *SubscriptionOptions,
+) *Subscription[*dom.AttributeRemovedEvent] {
+	sub := NewSubscription[*dom.AttributeRemovedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newAttributeRemovedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForAttributeRemoved blocks until a DOM.attributeRemoved event that satisfies
+the predicate is received and returns it. A nil predicate matches the first
+event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-attributeRemoved
+*/
+func (protocol *DOMProtocol) WaitForAttributeRemoved(
+	ctx context.Context,
+	predicate func(event *dom.AttributeRemovedEvent) bool,
+) (*dom.AttributeRemovedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeAttributeRemoved(nil), predicate)
 }
 
 /*
@@ -999,8 +1099,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-characterDataM
 */
 func (protocol *DOMProtocol) OnCharacterDataModified(
 	callback func(event *dom.CharacterDataModifiedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newCharacterDataModifiedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newCharacterDataModifiedHandler returns a DOM.characterDataModified event
+handler that has not been registered with the socket.
+*/
+func (protocol *DOMProtocol) newCharacterDataModifiedHandler(
+	callback func(event *dom.CharacterDataModifiedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOM.characterDataModified",
 		func(response *Response) {
 			event := &dom.CharacterDataModifiedEvent{}
@@ -1011,7 +1123,36 @@ func (protocol *DOMProtocol) OnCharacterDataModified(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeCharacterDataModified returns a subscription that delivers
+DOM.characterDataModified events on a typed channel until it is unsubscribed.
+See OnCharacterDataModified().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-characterDataModified
+*/
+func (protocol *DOMProtocol) SubscribeCharacterDataModified(
+	options *SubscriptionOptions,
+) *Subscription[*dom.CharacterDataModifiedEvent] {
+	sub := NewSubscription[*dom.CharacterDataModifiedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newCharacterDataModifiedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForCharacterDataModified blocks until a DOM.characterDataModified event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-characterDataModified
+*/
+func (protocol *DOMProtocol) WaitForCharacterDataModified(
+	ctx context.Context,
+	predicate func(event *dom.CharacterDataModifiedEvent) bool,
+) (*dom.CharacterDataModifiedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeCharacterDataModified(nil), predicate)
 }
 
 /*
@@ -1022,8 +1163,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-childNodeCount
 */
 func (protocol *DOMProtocol) OnChildNodeCountUpdated(
 	callback func(event *dom.ChildNodeCountUpdatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newChildNodeCountUpdatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newChildNodeCountUpdatedHandler returns a DOM.childNodeCountUpdated event
+handler that has not been registered with the socket.
+*/
+func (protocol *DOMProtocol) newChildNodeCountUpdatedHandler(
+	callback func(event *dom.ChildNodeCountUpdatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOM.childNodeCountUpdated",
 		func(response *Response) {
 			event := &dom.ChildNodeCountUpdatedEvent{}
@@ -1034,7 +1187,36 @@ func (protocol *DOMProtocol) OnChildNodeCountUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeChildNodeCountUpdated returns a subscription that delivers
+DOM.childNodeCountUpdated events on a typed channel until it is unsubscribed.
+See OnChildNodeCountUpdated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-childNodeCountUpdated
+*/
+func (protocol *DOMProtocol) SubscribeChildNodeCountUpdated(
+	options *SubscriptionOptions,
+) *Subscription[*dom.ChildNodeCountUpdatedEvent] {
+	sub := NewSubscription[*dom.ChildNodeCountUpdatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newChildNodeCountUpdatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForChildNodeCountUpdated blocks until a DOM.childNodeCountUpdated event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-childNodeCountUpdated
+*/
+func (protocol *DOMProtocol) WaitForChildNodeCountUpdated(
+	ctx context.Context,
+	predicate func(event *dom.ChildNodeCountUpdatedEvent) bool,
+) (*dom.ChildNodeCountUpdatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeChildNodeCountUpdated(nil), predicate)
 }
 
 /*
@@ -1045,8 +1227,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-childNodeInser
 */
 func (protocol *DOMProtocol) OnChildNodeInserted(
 	callback func(event *dom.ChildNodeInsertedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newChildNodeInsertedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newChildNodeInsertedHandler returns a DOM.childNodeInserted event handler that
+has not been registered with the socket.
+*/
+func (protocol *DOMProtocol) newChildNodeInsertedHandler(
+	callback func(event *dom.ChildNodeInsertedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOM.childNodeInserted",
 		func(response *Response) {
 			event := &dom.ChildNodeInsertedEvent{}
@@ -1057,7 +1251,36 @@ func (protocol *DOMProtocol) OnChildNodeInserted(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeChildNodeInserted returns a subscription that delivers
+DOM.childNodeInserted events on a typed channel until it is unsubscribed. See
+OnChildNodeInserted().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-childNodeInserted
+*/
+func (protocol *DOMProtocol) SubscribeChildNodeInserted(
+	options *SubscriptionOptions,
+) *Subscription[*dom.ChildNodeInsertedEvent] {
+	sub := NewSubscription[*dom.ChildNodeInsertedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newChildNodeInsertedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForChildNodeInserted blocks until a DOM.childNodeInserted event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-childNodeInserted
+*/
+func (protocol *DOMProtocol) WaitForChildNodeInserted(
+	ctx context.Context,
+	predicate func(event *dom.ChildNodeInsertedEvent) bool,
+) (*dom.ChildNodeInsertedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeChildNodeInserted(nil), predicate)
 }
 
 /*
@@ -1068,8 +1291,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-childNodeRemov
 */
 func (protocol *DOMProtocol) OnChildNodeRemoved(
 	callback func(event *dom.ChildNodeRemovedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newChildNodeRemovedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newChildNodeRemovedHandler returns a DOM.childNodeRemoved event handler that has
+not been registered with the socket.
+*/
+func (protocol *DOMProtocol) newChildNodeRemovedHandler(
+	callback func(event *dom.ChildNodeRemovedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOM.childNodeRemoved",
 		func(response *Response) {
 			event := &dom.ChildNodeRemovedEvent{}
@@ -1080,7 +1315,36 @@ func (protocol *DOMProtocol) OnChildNodeRemoved(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeChildNodeRemoved returns a subscription that delivers
+DOM.childNodeRemoved events on a typed channel until it is unsubscribed. See
+OnChildNodeRemoved().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-childNodeRemoved
+*/
+func (protocol *DOMProtocol) SubscribeChildNodeRemoved(
+	options *SubscriptionOptions,
+) *Subscription[*dom.ChildNodeRemovedEvent] {
+	sub := NewSubscription[*dom.ChildNodeRemovedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newChildNodeRemovedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForChildNodeRemoved blocks until a DOM.childNodeRemoved event that satisfies
+the predicate is received and returns it. A nil predicate matches the first
+event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-childNodeRemoved
+*/
+func (protocol *DOMProtocol) WaitForChildNodeRemoved(
+	ctx context.Context,
+	predicate func(event *dom.ChildNodeRemovedEvent) bool,
+) (*dom.ChildNodeRemovedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeChildNodeRemoved(nil), predicate)
 }
 
 /*
@@ -1092,8 +1356,20 @@ EXPERIMENTAL.
 */
 func (protocol *DOMProtocol) OnDistributedNodesUpdated(
 	callback func(event *dom.DistributedNodesUpdatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newDistributedNodesUpdatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newDistributedNodesUpdatedHandler returns a DOM.distributedNodesUpdated event
+handler that has not been registered with the socket.
+*/
+func (protocol *DOMProtocol) newDistributedNodesUpdatedHandler(
+	callback func(event *dom.DistributedNodesUpdatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOM.distributedNodesUpdated",
 		func(response *Response) {
 			event := &dom.DistributedNodesUpdatedEvent{}
@@ -1104,7 +1380,38 @@ func (protocol *DOMProtocol) OnDistributedNodesUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeDistributedNodesUpdated returns a subscription that delivers
+DOM.distributedNodesUpdated events on a typed channel until it is unsubscribed.
+See OnDistributedNodesUpdated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-distributedNodesUpdated
+EXPERIMENTAL.
+*/
+func (protocol *DOMProtocol) SubscribeDistributedNodesUpdated(
+	options *SubscriptionOptions,
+) *Subscription[*dom.DistributedNodesUpdatedEvent] {
+	sub := NewSubscription[*dom.DistributedNodesUpdatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newDistributedNodesUpdatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForDistributedNodesUpdated blocks until a DOM.distributedNodesUpdated event
+that satisfies the predicate is received and returns it. A nil predicate matches
+the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-distributedNodesUpdated
+EXPERIMENTAL.
+*/
+func (protocol *DOMProtocol) WaitForDistributedNodesUpdated(
+	ctx context.Context,
+	predicate func(event *dom.DistributedNodesUpdatedEvent) bool,
+) (*dom.DistributedNodesUpdatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeDistributedNodesUpdated(nil), predicate)
 }
 
 /*
@@ -1116,8 +1423,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-documentUpdate
 */
 func (protocol *DOMProtocol) OnDocumentUpdated(
 	callback func(event *dom.DocumentUpdatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newDocumentUpdatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newDocumentUpdatedHandler returns a DOM.documentUpdated event handler that has
+not been registered with the socket.
+*/
+func (protocol *DOMProtocol) newDocumentUpdatedHandler(
+	callback func(event *dom.DocumentUpdatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOM.documentUpdated",
 		func(response *Response) {
 			event := &dom.DocumentUpdatedEvent{}
@@ -1128,7 +1447,36 @@ func (protocol *DOMProtocol) OnDocumentUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeDocumentUpdated returns a subscription that delivers
+DOM.documentUpdated events on a typed channel until it is unsubscribed. See
+OnDocumentUpdated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-documentUpdated
+*/
+func (protocol *DOMProtocol) SubscribeDocumentUpdated(
+	options *SubscriptionOptions,
+) *Subscription[*dom.DocumentUpdatedEvent] {
+	sub := NewSubscription[*dom.DocumentUpdatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newDocumentUpdatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForDocumentUpdated blocks until a DOM.documentUpdated event that satisfies
+the predicate is received and returns it. A nil predicate matches the first
+event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-documentUpdated
+*/
+func (protocol *DOMProtocol) WaitForDocumentUpdated(
+	ctx context.Context,
+	predicate func(event *dom.DocumentUpdatedEvent) bool,
+) (*dom.DocumentUpdatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeDocumentUpdated(nil), predicate)
 }
 
 /*
@@ -1139,8 +1487,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-inlineStyleInv
 */
 func (protocol *DOMProtocol) OnInlineStyleInvalidated(
 	callback func(event *dom.InlineStyleInvalidatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newInlineStyleInvalidatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newInlineStyleInvalidatedHandler returns a DOM.inlineStyleInvalidated event
+handler that has not been registered with the socket.
+*/
+func (protocol *DOMProtocol) newInlineStyleInvalidatedHandler(
+	callback func(event *dom.InlineStyleInvalidatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOM.inlineStyleInvalidated",
 		func(response *Response) {
 			event := &dom.InlineStyleInvalidatedEvent{}
@@ -1151,7 +1511,36 @@ func (protocol *DOMProtocol) OnInlineStyleInvalidated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeInlineStyleInvalidated returns a subscription that delivers
+DOM.inlineStyleInvalidated events on a typed channel until it is unsubscribed.
+See OnInlineStyleInvalidated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-inlineStyleInvalidated
+*/
+func (protocol *DOMProtocol) SubscribeInlineStyleInvalidated(
+	options *SubscriptionOptions,
+) *Subscription[*dom.InlineStyleInvalidatedEvent] {
+	sub := NewSubscription[*dom.InlineStyleInvalidatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newInlineStyleInvalidatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForInlineStyleInvalidated blocks until a DOM.inlineStyleInvalidated event
+that satisfies the predicate is received and returns it. A nil predicate matches
+the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-inlineStyleInvalidated
+*/
+func (protocol *DOMProtocol) WaitForInlineStyleInvalidated(
+	ctx context.Context,
+	predicate func(event *dom.InlineStyleInvalidatedEvent) bool,
+) (*dom.InlineStyleInvalidatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeInlineStyleInvalidated(nil), predicate)
 }
 
 /*
@@ -1162,8 +1551,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-pseudoElementA
 */
 func (protocol *DOMProtocol) OnPseudoElementAdded(
 	callback func(event *dom.PseudoElementAddedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newPseudoElementAddedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newPseudoElementAddedHandler returns a DOM.pseudoElementAdded event handler that
+has not been registered with the socket.
+*/
+func (protocol *DOMProtocol) newPseudoElementAddedHandler(
+	callback func(event *dom.PseudoElementAddedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOM.pseudoElementAdded",
 		func(response *Response) {
 			event := &dom.PseudoElementAddedEvent{}
@@ -1174,7 +1575,36 @@ func (protocol *DOMProtocol) OnPseudoElementAdded(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribePseudoElementAdded returns a subscription that delivers
+DOM.pseudoElementAdded events on a typed channel until it is unsubscribed. See
+OnPseudoElementAdded().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-pseudoElementAdded EXPERIMENTAL.
+*/
+func (protocol *DOMProtocol) SubscribePseudoElementAdded(
+	options *SubscriptionOptions,
+) *Subscription[*dom.PseudoElementAddedEvent] {
+	sub := NewSubscription[*dom.PseudoElementAddedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newPseudoElementAddedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForPseudoElementAdded blocks until a DOM.pseudoElementAdded event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-pseudoElementAdded EXPERIMENTAL.
+*/
+func (protocol *DOMProtocol) WaitForPseudoElementAdded(
+	ctx context.Context,
+	predicate func(event *dom.PseudoElementAddedEvent) bool,
+) (*dom.PseudoElementAddedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribePseudoElementAdded(nil), predicate)
 }
 
 /*
@@ -1185,8 +1615,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-pseudoElementR
 */
 func (protocol *DOMProtocol) OnPseudoElementRemoved(
 	callback func(event *dom.PseudoElementRemovedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newPseudoElementRemovedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newPseudoElementRemovedHandler returns a DOM.pseudoElementRemoved event handler
+that has not been registered with the socket.
+*/
+func (protocol *DOMProtocol) newPseudoElementRemovedHandler(
+	callback func(event *dom.PseudoElementRemovedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOM.pseudoElementRemoved",
 		func(response *Response) {
 			event := &dom.PseudoElementRemovedEvent{}
@@ -1197,7 +1639,36 @@ func (protocol *DOMProtocol) OnPseudoElementRemoved(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribePseudoElementRemoved returns a subscription that delivers
+DOM.pseudoElementRemoved events on a typed channel until it is unsubscribed. See
+OnPseudoElementRemoved().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-pseudoElementRemoved EXPERIMENTAL.
+*/
+func (protocol *DOMProtocol) SubscribePseudoElementRemoved(
+	options *SubscriptionOptions,
+) *Subscription[*dom.PseudoElementRemovedEvent] {
+	sub := NewSubscription[*dom.PseudoElementRemovedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newPseudoElementRemovedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForPseudoElementRemoved blocks until a DOM.pseudoElementRemoved event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-pseudoElementRemoved EXPERIMENTAL.
+*/
+func (protocol *DOMProtocol) WaitForPseudoElementRemoved(
+	ctx context.Context,
+	predicate func(event *dom.PseudoElementRemovedEvent) bool,
+) (*dom.PseudoElementRemovedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribePseudoElementRemoved(nil), predicate)
 }
 
 /*
@@ -1209,8 +1680,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-setChildNodes
 */
 func (protocol *DOMProtocol) OnSetChildNodes(
 	callback func(event *dom.SetChildNodesEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newSetChildNodesHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newSetChildNodesHandler returns a DOM.setChildNodes event handler that has not
+been registered with the socket.
+*/
+func (protocol *DOMProtocol) newSetChildNodesHandler(
+	callback func(event *dom.SetChildNodesEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOM.setChildNodes",
 		func(response *Response) {
 			event := &dom.SetChildNodesEvent{}
@@ -1221,7 +1704,35 @@ func (protocol *DOMProtocol) OnSetChildNodes(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeSetChildNodes returns a subscription that delivers DOM.setChildNodes
+events on a typed channel until it is unsubscribed. See OnSetChildNodes().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-setChildNodes
+*/
+func (protocol *DOMProtocol) SubscribeSetChildNodes(
+	options *SubscriptionOptions,
+) *Subscription[*dom.SetChildNodesEvent] {
+	sub := NewSubscription[*dom.SetChildNodesEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newSetChildNodesHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForSetChildNodes blocks until a DOM.setChildNodes event that satisfies the
+predicate is received and returns it. A nil predicate matches the first event.
+See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-setChildNodes
+*/
+func (protocol *DOMProtocol) WaitForSetChildNodes(
+	ctx context.Context,
+	predicate func(event *dom.SetChildNodesEvent) bool,
+) (*dom.SetChildNodesEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeSetChildNodes(nil), predicate)
 }
 
 /*
@@ -1232,8 +1743,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-shadowRootPopp
 */
 func (protocol *DOMProtocol) OnShadowRootPopped(
 	callback func(event *dom.ShadowRootPoppedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newShadowRootPoppedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newShadowRootPoppedHandler returns a DOM.shadowRootPopped event handler that has
+not been registered with the socket.
+*/
+func (protocol *DOMProtocol) newShadowRootPoppedHandler(
+	callback func(event *dom.ShadowRootPoppedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOM.shadowRootPopped",
 		func(response *Response) {
 			event := &dom.ShadowRootPoppedEvent{}
@@ -1244,7 +1767,36 @@ func (protocol *DOMProtocol) OnShadowRootPopped(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeShadowRootPopped returns a subscription that delivers
+DOM.shadowRootPopped events on a typed channel until it is unsubscribed. See
+OnShadowRootPopped().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-shadowRootPopped EXPERIMENTAL.
+*/
+func (protocol *DOMProtocol) SubscribeShadowRootPopped(
+	options *SubscriptionOptions,
+) *Subscription[*dom.ShadowRootPoppedEvent] {
+	sub := NewSubscription[*dom.ShadowRootPoppedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newShadowRootPoppedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForShadowRootPopped blocks until a DOM.shadowRootPopped event that satisfies
+the predicate is received and returns it. A nil predicate matches the first
+event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-shadowRootPopped EXPERIMENTAL.
+*/
+func (protocol *DOMProtocol) WaitForShadowRootPopped(
+	ctx context.Context,
+	predicate func(event *dom.ShadowRootPoppedEvent) bool,
+) (*dom.ShadowRootPoppedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeShadowRootPopped(nil), predicate)
 }
 
 /*
@@ -1255,8 +1807,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-shadowRootPush
 */
 func (protocol *DOMProtocol) OnShadowRootPushed(
 	callback func(event *dom.ShadowRootPushedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newShadowRootPushedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newShadowRootPushedHandler returns a DOM.shadowRootPushed event handler that has
+not been registered with the socket.
+*/
+func (protocol *DOMProtocol) newShadowRootPushedHandler(
+	callback func(event *dom.ShadowRootPushedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOM.shadowRootPushed",
 		func(response *Response) {
 			event := &dom.ShadowRootPushedEvent{}
@@ -1267,5 +1831,34 @@ func (protocol *DOMProtocol) OnShadowRootPushed(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeShadowRootPushed returns a subscription that delivers
+DOM.shadowRootPushed events on a typed channel until it is unsubscribed. See
+OnShadowRootPushed().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-shadowRootPushed EXPERIMENTAL.
+*/
+func (protocol *DOMProtocol) SubscribeShadowRootPushed(
+	options *SubscriptionOptions,
+) *Subscription[*dom.ShadowRootPushedEvent] {
+	sub := NewSubscription[*dom.ShadowRootPushedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newShadowRootPushedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForShadowRootPushed blocks until a DOM.shadowRootPushed event that satisfies
+the predicate is received and returns it. A nil predicate matches the first
+event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOM/#event-shadowRootPushed EXPERIMENTAL.
+*/
+func (protocol *DOMProtocol) WaitForShadowRootPushed(
+	ctx context.Context,
+	predicate func(event *dom.ShadowRootPushedEvent) bool,
+) (*dom.ShadowRootPushedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeShadowRootPushed(nil), predicate)
 }
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/dom/snapshot"
 )
@@ -17,6 +19,22 @@ type DOMSnapshotProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *DOMSnapshotProtocol) WithContext(ctx context.Context) *DOMSnapshotProtocol {
+	return &DOMSnapshotProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *DOMSnapshotProtocol) WithTimeout(timeout time.Duration) *DOMSnapshotProtocol {
+	return &DOMSnapshotProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 Get returns a document snapshot, including the full DOM tree of the root node
 (including iframes, template contents, and imported documents) in a flattened
@@ -34,8 +52,8 @@ func (protocol *DOMSnapshotProtocol) Get(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/dom/storage"
 )
@@ -16,6 +18,22 @@ type DOMStorageProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *DOMStorageProtocol) WithContext(ctx context.Context) *DOMStorageProtocol {
+	return &DOMStorageProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *DOMStorageProtocol) WithTimeout(timeout time.Duration) *DOMStorageProtocol {
+	return &DOMStorageProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 Clear clears  a stored item.
 
@@ -30,8 +48,8 @@ func (protocol *DOMStorageProtocol) Clear(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -53,8 +71,8 @@ func (protocol *DOMStorageProtocol) Disable() <-chan *storage.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -76,8 +94,8 @@ func (protocol *DOMStorageProtocol) Enable() <-chan *storage.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -100,8 +118,8 @@ func (protocol *DOMStorageProtocol) GetItems(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -126,8 +144,8 @@ func (protocol *DOMStorageProtocol) RemoveItem(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -150,8 +168,8 @@ func (protocol *DOMStorageProtocol) SetItem(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -168,8 +186,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOMStorage/#event-domStor
 */
 func (protocol *DOMStorageProtocol) OnItemAdded(
 	callback func(event *storage.ItemAddedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newItemAddedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newItemAddedHandler returns a DOMStorage.domStorageItemAdded event handler that
+has not been registered with the socket.
+*/
+func (protocol *DOMStorageProtocol) newItemAddedHandler(
+	callback func(event *storage.ItemAddedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOMStorage.domStorageItemAdded",
 		func(response *Response) {
 			event := &storage.ItemAddedEvent{}
@@ -180,7 +210,36 @@ func (protocol *DOMStorageProtocol) OnItemAdded(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeItemAdded returns a subscription that delivers
+DOMStorage.domStorageItemAdded events on a typed channel until it is
+unsubscribed. See OnItemAdded().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOMStorage/#event-domStorageItemAdded
+*/
+func (protocol *DOMStorageProtocol) SubscribeItemAdded(
+	options *SubscriptionOptions,
+) *Subscription[*storage.ItemAddedEvent] {
+	sub := NewSubscription[*storage.ItemAddedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newItemAddedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForItemAdded blocks until a DOMStorage.domStorageItemAdded event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOMStorage/#event-domStorageItemAdded
+*/
+func (protocol *DOMStorageProtocol) WaitForItemAdded(
+	ctx context.Context,
+	predicate func(event *storage.ItemAddedEvent) bool,
+) (*storage.ItemAddedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeItemAdded(nil), predicate)
 }
 
 /*
@@ -191,8 +250,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOMStorage/#event-domStor
 */
 func (protocol *DOMStorageProtocol) OnItemRemoved(
 	callback func(event *storage.ItemRemovedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newItemRemovedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newItemRemovedHandler returns a DOMStorage.domStorageItemRemoved event handler
+that has not been registered with the socket.
+*/
+func (protocol *DOMStorageProtocol) newItemRemovedHandler(
+	callback func(event *storage.ItemRemovedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOMStorage.domStorageItemRemoved",
 		func(response *Response) {
 			event := &storage.ItemRemovedEvent{}
@@ -203,7 +274,36 @@ func (protocol *DOMStorageProtocol) OnItemRemoved(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeItemRemoved returns a subscription that delivers
+DOMStorage.domStorageItemRemoved events on a typed channel until it is
+unsubscribed. See OnItemRemoved().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOMStorage/#event-domStorageItemRemoved
+*/
+func (protocol *DOMStorageProtocol) SubscribeItemRemoved(
+	options *SubscriptionOptions,
+) *Subscription[*storage.ItemRemovedEvent] {
+	sub := NewSubscription[*storage.ItemRemovedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newItemRemovedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForItemRemoved blocks until a DOMStorage.domStorageItemRemoved event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOMStorage/#event-domStorageItemRemoved
+*/
+func (protocol *DOMStorageProtocol) WaitForItemRemoved(
+	ctx context.Context,
+	predicate func(event *storage.ItemRemovedEvent) bool,
+) (*storage.ItemRemovedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeItemRemoved(nil), predicate)
 }
 
 /*
@@ -214,8 +314,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOMStorage/#event-domStor
 */
 func (protocol *DOMStorageProtocol) OnItemUpdated(
 	callback func(event *storage.ItemUpdatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newItemUpdatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newItemUpdatedHandler returns a DOMStorage.domStorageItemUpdated event handler
+that has not been registered with the socket.
+*/
+func (protocol *DOMStorageProtocol) newItemUpdatedHandler(
+	callback func(event *storage.ItemUpdatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOMStorage.domStorageItemUpdated",
 		func(response *Response) {
 			event := &storage.ItemUpdatedEvent{}
@@ -226,7 +338,36 @@ func (protocol *DOMStorageProtocol) OnItemUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeItemUpdated returns a subscription that delivers
+DOMStorage.domStorageItemUpdated events on a typed channel until it is
+unsubscribed. See OnItemUpdated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOMStorage/#event-domStorageItemUpdated
+*/
+func (protocol *DOMStorageProtocol) SubscribeItemUpdated(
+	options *SubscriptionOptions,
+) *Subscription[*storage.ItemUpdatedEvent] {
+	sub := NewSubscription[*storage.ItemUpdatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newItemUpdatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForItemUpdated blocks until a DOMStorage.domStorageItemUpdated event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOMStorage/#event-domStorageItemUpdated
+*/
+func (protocol *DOMStorageProtocol) WaitForItemUpdated(
+	ctx context.Context,
+	predicate func(event *storage.ItemUpdatedEvent) bool,
+) (*storage.ItemUpdatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeItemUpdated(nil), predicate)
 }
 
 /*
@@ -237,8 +378,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/DOMStorage/#event-domStor
 */
 func (protocol *DOMStorageProtocol) OnItemsCleared(
 	callback func(event *storage.ItemsClearedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newItemsClearedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newItemsClearedHandler returns a DOMStorage.domStorageItemsCleared event handler
+that has not been registered with the socket.
+*/
+func (protocol *DOMStorageProtocol) newItemsClearedHandler(
+	callback func(event *storage.ItemsClearedEvent),
+) *Handler {
+	return NewEventHandler(
 		"DOMStorage.domStorageItemsCleared",
 		func(response *Response) {
 			event := &storage.ItemsClearedEvent{}
@@ -249,5 +402,34 @@ func (protocol *DOMStorageProtocol) OnItemsCleared(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeItemsCleared returns a subscription that delivers
+DOMStorage.domStorageItemsCleared events on a typed channel until it is
+unsubscribed. See OnItemsCleared().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOMStorage/#event-domStorageItemsCleared
+*/
+func (protocol *DOMStorageProtocol) SubscribeItemsCleared(
+	options *SubscriptionOptions,
+) *Subscription[*storage.ItemsClearedEvent] {
+	sub := NewSubscription[*storage.ItemsClearedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newItemsClearedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForItemsCleared blocks until a DOMStorage.domStorageItemsCleared event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/DOMStorage/#event-domStorageItemsCleared
+*/
+func (protocol *DOMStorageProtocol) WaitForItemsCleared(
+	ctx context.Context,
+	predicate func(event *storage.ItemsClearedEvent) bool,
+) (*storage.ItemsClearedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeItemsCleared(nil), predicate)
 }
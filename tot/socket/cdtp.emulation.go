@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/emulation"
 )
@@ -16,6 +18,22 @@ type EmulationProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *EmulationProtocol) WithContext(ctx context.Context) *EmulationProtocol {
+	return &EmulationProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *EmulationProtocol) WithTimeout(timeout time.Duration) *EmulationProtocol {
+	return &EmulationProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 CanEmulate tells whether emulation is supported.
 
@@ -28,8 +46,8 @@ func (protocol *EmulationProtocol) CanEmulate() <-chan *emulation.CanEmulateResu
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -52,8 +70,8 @@ func (protocol *EmulationProtocol) ClearDeviceMetricsOverride() <-chan *emulatio
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -74,8 +92,8 @@ func (protocol *EmulationProtocol) ClearGeolocationOverride() <-chan *emulation.
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -97,8 +115,8 @@ func (protocol *EmulationProtocol) ResetPageScaleFactor() <-chan *emulation.Rese
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -122,8 +140,8 @@ func (protocol *EmulationProtocol) SetCPUThrottlingRate(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -148,8 +166,8 @@ func (protocol *EmulationProtocol) SetDefaultBackgroundColorOverride(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -175,8 +193,8 @@ func (protocol *EmulationProtocol) SetDeviceMetricsOverride(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -200,8 +218,8 @@ func (protocol *EmulationProtocol) SetEmitTouchEventsForMouse(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -224,8 +242,8 @@ func (protocol *EmulationProtocol) SetEmulatedMedia(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -249,8 +267,8 @@ func (protocol *EmulationProtocol) SetGeolocationOverride(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -275,8 +293,8 @@ func (protocol *EmulationProtocol) SetNavigatorOverrides(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -300,8 +318,8 @@ func (protocol *EmulationProtocol) SetPageScaleFactor(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -324,8 +342,8 @@ func (protocol *EmulationProtocol) SetScriptExecutionDisabled(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -348,8 +366,8 @@ func (protocol *EmulationProtocol) SetTouchEmulationEnabled(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -375,8 +393,8 @@ func (protocol *EmulationProtocol) SetVirtualTimePolicy(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -404,8 +422,8 @@ func (protocol *EmulationProtocol) SetVisibleSize(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -423,8 +441,20 @@ EXPERIMENTAL.
 */
 func (protocol *EmulationProtocol) OnVirtualTimeAdvanced(
 	callback func(event *emulation.VirtualTimeAdvancedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newVirtualTimeAdvancedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newVirtualTimeAdvancedHandler returns a Emulation.virtualTimeAdvanced event
+handler that has not been registered with the socket.
+*/
+func (protocol *EmulationProtocol) newVirtualTimeAdvancedHandler(
+	callback func(event *emulation.VirtualTimeAdvancedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Emulation.virtualTimeAdvanced",
 		func(response *Response) {
 			event := &emulation.VirtualTimeAdvancedEvent{}
@@ -435,7 +465,38 @@ func (protocol *EmulationProtocol) OnVirtualTimeAdvanced(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeVirtualTimeAdvanced returns a subscription that delivers
+Emulation.virtualTimeAdvanced events on a typed channel until it is
+unsubscribed. See OnVirtualTimeAdvanced().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#event-virtualTimeAdvanced
+EXPERIMENTAL.
+*/
+func (protocol *EmulationProtocol) SubscribeVirtualTimeAdvanced(
+	options *SubscriptionOptions,
+) *Subscription[*emulation.VirtualTimeAdvancedEvent] {
+	sub := NewSubscription[*emulation.VirtualTimeAdvancedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newVirtualTimeAdvancedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForVirtualTimeAdvanced blocks until an Emulation.virtualTimeAdvanced event
+that satisfies the predicate is received and returns it. A nil predicate matches
+the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#event-virtualTimeAdvanced
+EXPERIMENTAL.
+*/
+func (protocol *EmulationProtocol) WaitForVirtualTimeAdvanced(
+	ctx context.Context,
+	predicate func(event *emulation.VirtualTimeAdvancedEvent) bool,
+) (*emulation.VirtualTimeAdvancedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeVirtualTimeAdvanced(nil), predicate)
 }
 
 /*
@@ -448,8 +509,20 @@ EXPERIMENTAL.
 */
 func (protocol *EmulationProtocol) OnVirtualTimeBudgetExpired(
 	callback func(event *emulation.VirtualTimeBudgetExpiredEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newVirtualTimeBudgetExpiredHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newVirtualTimeBudgetExpiredHandler returns a Emulation.virtualTimeBudgetExpired
+event handler that has not been registered with the socket.
+*/
+func (protocol *EmulationProtocol) newVirtualTimeBudgetExpiredHandler(
+	callback func(event *emulation.VirtualTimeBudgetExpiredEvent),
+) *Handler {
+	return NewEventHandler(
 		"Emulation.virtualTimeBudgetExpired",
 		func(response *Response) {
 			event := &emulation.VirtualTimeBudgetExpiredEvent{}
@@ -460,7 +533,38 @@ func (protocol *EmulationProtocol) OnVirtualTimeBudgetExpired(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeVirtualTimeBudgetExpired returns a subscription that delivers
+Emulation.virtualTimeBudgetExpired events on a typed channel until it is
+unsubscribed. See OnVirtualTimeBudgetExpired().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#event-virtualTimeBudgetExpired
+EXPERIMENTAL.
+*/
+func (protocol *EmulationProtocol) SubscribeVirtualTimeBudgetExpired(
+	options *SubscriptionOptions,
+) *Subscription[*emulation.VirtualTimeBudgetExpiredEvent] {
+	sub := NewSubscription[*emulation.VirtualTimeBudgetExpiredEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newVirtualTimeBudgetExpiredHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForVirtualTimeBudgetExpired blocks until an
+Emulation.virtualTimeBudgetExpired event that satisfies the predicate is
+received and returns it. A nil predicate matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#event-virtualTimeBudgetExpired
+EXPERIMENTAL.
+*/
+func (protocol *EmulationProtocol) WaitForVirtualTimeBudgetExpired(
+	ctx context.Context,
+	predicate func(event *emulation.VirtualTimeBudgetExpiredEvent) bool,
+) (*emulation.VirtualTimeBudgetExpiredEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeVirtualTimeBudgetExpired(nil), predicate)
 }
 
 /*
@@ -472,8 +576,20 @@ EXPERIMENTAL.
 */
 func (protocol *EmulationProtocol) OnVirtualTimePaused(
 	callback func(event *emulation.VirtualTimePausedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newVirtualTimePausedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newVirtualTimePausedHandler returns a Emulation.virtualTimePaused event handler
+that has not been registered with the socket.
+*/
+func (protocol *EmulationProtocol) newVirtualTimePausedHandler(
+	callback func(event *emulation.VirtualTimePausedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Emulation.virtualTimePaused",
 		func(response *Response) {
 			event := &emulation.VirtualTimePausedEvent{}
@@ -484,5 +600,36 @@ func (protocol *EmulationProtocol) OnVirtualTimePaused(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeVirtualTimePaused returns a subscription that delivers
+Emulation.virtualTimePaused events on a typed channel until it is unsubscribed.
+See OnVirtualTimePaused().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#event-virtualTimePaused
+EXPERIMENTAL.
+*/
+func (protocol *EmulationProtocol) SubscribeVirtualTimePaused(
+	options *SubscriptionOptions,
+) *Subscription[*emulation.VirtualTimePausedEvent] {
+	sub := NewSubscription[*emulation.VirtualTimePausedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newVirtualTimePausedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForVirtualTimePaused blocks until an Emulation.virtualTimePaused event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#event-virtualTimePaused
+EXPERIMENTAL.
+*/
+func (protocol *EmulationProtocol) WaitForVirtualTimePaused(
+	ctx context.Context,
+	predicate func(event *emulation.VirtualTimePausedEvent) bool,
+) (*emulation.VirtualTimePausedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeVirtualTimePaused(nil), predicate)
 }
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/headless/experimental"
 )
@@ -17,6 +19,22 @@ type HeadlessExperimentalProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *HeadlessExperimentalProtocol) WithContext(ctx context.Context) *HeadlessExperimentalProtocol {
+	return &HeadlessExperimentalProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *HeadlessExperimentalProtocol) WithTimeout(timeout time.Duration) *HeadlessExperimentalProtocol {
+	return &HeadlessExperimentalProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 BeginFrame sends a BeginFrame to the target and returns when the frame was
 completed. Optionally captures a screenshot from the resulting frame. Requires
@@ -33,8 +51,8 @@ func (protocol *HeadlessExperimentalProtocol) BeginFrame(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -57,8 +75,8 @@ func (protocol *HeadlessExperimentalProtocol) Disable() <-chan *experimental.Dis
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -79,8 +97,8 @@ func (protocol *HeadlessExperimentalProtocol) Enable() <-chan *experimental.Enab
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -99,8 +117,21 @@ https://chromedevtools.github.io/devtools-protocol/tot/HeadlessExperimental/#eve
 */
 func (protocol *HeadlessExperimentalProtocol) OnMainFrameReadyForScreenshots(
 	callback func(event *experimental.MainFrameReadyForScreenshotsEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newMainFrameReadyForScreenshotsHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newMainFrameReadyForScreenshotsHandler returns a
+HeadlessExperimental.mainFrameReadyForScreenshots event handler that has not
+been registered with the socket.
+*/
+func (protocol *HeadlessExperimentalProtocol) newMainFrameReadyForScreenshotsHandler(
+	callback func(event *experimental.MainFrameReadyForScreenshotsEvent),
+) *Handler {
+	return NewEventHandler(
 		"HeadlessExperimental.mainFrameReadyForScreenshots",
 		func(response *Response) {
 			event := &experimental.MainFrameReadyForScreenshotsEvent{}
@@ -111,7 +142,37 @@ func (protocol *HeadlessExperimentalProtocol) OnMainFrameReadyForScreenshots(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeMainFrameReadyForScreenshots returns a subscription that delivers
+HeadlessExperimental.mainFrameReadyForScreenshots events on a typed channel
+until it is unsubscribed. See OnMainFrameReadyForScreenshots().
+
+https://chromedevtools.github.io/devtools-protocol/tot/HeadlessExperimental/#event-mainFrameReadyForScreenshots
+*/
+func (protocol *HeadlessExperimentalProtocol) SubscribeMainFrameReadyForScreenshots(
+	options *SubscriptionOptions,
+) *Subscription[*experimental.MainFrameReadyForScreenshotsEvent] {
+	sub := NewSubscription[*experimental.MainFrameReadyForScreenshotsEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newMainFrameReadyForScreenshotsHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForMainFrameReadyForScreenshots blocks until a
+HeadlessExperimental.mainFrameReadyForScreenshots event that satisfies the
+predicate is received and returns it. A nil predicate matches the first event.
+See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/HeadlessExperimental/#event-mainFrameReadyForScreenshots
+*/
+func (protocol *HeadlessExperimentalProtocol) WaitForMainFrameReadyForScreenshots(
+	ctx context.Context,
+	predicate func(event *experimental.MainFrameReadyForScreenshotsEvent) bool,
+) (*experimental.MainFrameReadyForScreenshotsEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeMainFrameReadyForScreenshots(nil), predicate)
 }
 
 /*
@@ -123,8 +184,21 @@ https://chromedevtools.github.io/devtools-protocol/tot/HeadlessExperimental/#eve
 */
 func (protocol *HeadlessExperimentalProtocol) OnNeedsBeginFramesChanged(
 	callback func(event *experimental.NeedsBeginFramesChangedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newNeedsBeginFramesChangedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newNeedsBeginFramesChangedHandler returns a
+HeadlessExperimental.needsBeginFramesChanged event handler that has not been
+registered with the socket.
+*/
+func (protocol *HeadlessExperimentalProtocol) newNeedsBeginFramesChangedHandler(
+	callback func(event *experimental.NeedsBeginFramesChangedEvent),
+) *Handler {
+	return NewEventHandler(
 		"HeadlessExperimental.needsBeginFramesChanged",
 		func(response *Response) {
 			event := &experimental.NeedsBeginFramesChangedEvent{}
@@ -135,5 +209,35 @@ func (protocol *HeadlessExperimentalProtocol) OnNeedsBeginFramesChanged(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeNeedsBeginFramesChanged returns a subscription that delivers
+HeadlessExperimental.needsBeginFramesChanged events on a typed channel until it
+is unsubscribed. See OnNeedsBeginFramesChanged().
+
+https://chromedevtools.github.io/devtools-protocol/tot/HeadlessExperimental/#event-needsBeginFramesChanged
+*/
+func (protocol *HeadlessExperimentalProtocol) SubscribeNeedsBeginFramesChanged(
+	options *SubscriptionOptions,
+) *Subscription[*experimental.NeedsBeginFramesChangedEvent] {
+	sub := NewSubscription[*experimental.NeedsBeginFramesChangedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newNeedsBeginFramesChangedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForNeedsBeginFramesChanged blocks until a
+HeadlessExperimental.needsBeginFramesChanged event that satisfies the predicate
+is received and returns it. A nil predicate matches the first event. See
+WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/HeadlessExperimental/#event-needsBeginFramesChanged
+*/
+func (protocol *HeadlessExperimentalProtocol) WaitForNeedsBeginFramesChanged(
+	ctx context.Context,
+	predicate func(event *experimental.NeedsBeginFramesChangedEvent) bool,
+) (*experimental.NeedsBeginFramesChangedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeNeedsBeginFramesChanged(nil), predicate)
 }
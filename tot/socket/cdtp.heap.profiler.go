@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/heap/profiler"
 )
@@ -16,6 +18,22 @@ type HeapProfilerProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *HeapProfilerProtocol) WithContext(ctx context.Context) *HeapProfilerProtocol {
+	return &HeapProfilerProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *HeapProfilerProtocol) WithTimeout(timeout time.Duration) *HeapProfilerProtocol {
+	return &HeapProfilerProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 AddInspectedHeapObject enables console to refer to the node with given id via $x
 (see Command Line API for more details $x functions).
@@ -31,8 +49,8 @@ func (protocol *HeapProfilerProtocol) AddInspectedHeapObject(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -54,8 +72,8 @@ func (protocol *HeapProfilerProtocol) CollectGarbage() <-chan *profiler.CollectG
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -76,8 +94,8 @@ func (protocol *HeapProfilerProtocol) Disable() <-chan *profiler.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -98,8 +116,8 @@ func (protocol *HeapProfilerProtocol) Enable() <-chan *profiler.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -123,8 +141,8 @@ func (protocol *HeapProfilerProtocol) GetHeapObjectID(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -150,8 +168,8 @@ func (protocol *HeapProfilerProtocol) GetObjectByHeapObjectID(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -177,8 +195,8 @@ func (protocol *HeapProfilerProtocol) GetSamplingProfile(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -202,8 +220,8 @@ func (protocol *HeapProfilerProtocol) StartSampling(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -227,8 +245,8 @@ func (protocol *HeapProfilerProtocol) StartTrackingHeapObjects(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -252,8 +270,8 @@ func (protocol *HeapProfilerProtocol) StopSampling(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -277,8 +295,8 @@ func (protocol *HeapProfilerProtocol) StopTrackingHeapObjects(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -302,8 +320,8 @@ func (protocol *HeapProfilerProtocol) TakeHeapSnapshot(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -321,8 +339,20 @@ EXPERIMENTAL.
 */
 func (protocol *HeapProfilerProtocol) OnAddHeapSnapshotChunk(
 	callback func(event *profiler.AddHeapSnapshotChunkEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newAddHeapSnapshotChunkHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newAddHeapSnapshotChunkHandler returns a HeapProfiler.addHeapSnapshotChunk event
+handler that has not been registered with the socket.
+*/
+func (protocol *HeapProfilerProtocol) newAddHeapSnapshotChunkHandler(
+	callback func(event *profiler.AddHeapSnapshotChunkEvent),
+) *Handler {
+	return NewEventHandler(
 		"HeapProfiler.addHeapSnapshotChunk",
 		func(response *Response) {
 			event := &profiler.AddHeapSnapshotChunkEvent{}
@@ -333,7 +363,38 @@ func (protocol *HeapProfilerProtocol) OnAddHeapSnapshotChunk(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeAddHeapSnapshotChunk returns a subscription that delivers
+HeapProfiler.addHeapSnapshotChunk events on a typed channel until it is
+unsubscribed. See OnAddHeapSnapshotChunk().
+
+https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#event-addHeapSnapshotChunk
+EXPERIMENTAL.
+*/
+func (protocol *HeapProfilerProtocol) SubscribeAddHeapSnapshotChunk(
+	options *SubscriptionOptions,
+) *Subscription[*profiler.AddHeapSnapshotChunkEvent] {
+	sub := NewSubscription[*profiler.AddHeapSnapshotChunkEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newAddHeapSnapshotChunkHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForAddHeapSnapshotChunk blocks until a HeapProfiler.addHeapSnapshotChunk
+event that satisfies the predicate is received and returns it. A nil predicate
+matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#event-addHeapSnapshotChunk
+EXPERIMENTAL.
+*/
+func (protocol *HeapProfilerProtocol) WaitForAddHeapSnapshotChunk(
+	ctx context.Context,
+	predicate func(event *profiler.AddHeapSnapshotChunkEvent) bool,
+) (*profiler.AddHeapSnapshotChunkEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeAddHeapSnapshotChunk(nil), predicate)
 }
 
 /*
@@ -345,8 +406,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#event-heapS
 */
 func (protocol *HeapProfilerProtocol) OnHeapStatsUpdate(
 	callback func(event *profiler.HeapStatsUpdateEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newHeapStatsUpdateHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newHeapStatsUpdateHandler returns a HeapProfiler.heapStatsUpdate event handler
+that has not been registered with the socket.
+*/
+func (protocol *HeapProfilerProtocol) newHeapStatsUpdateHandler(
+	callback func(event *profiler.HeapStatsUpdateEvent),
+) *Handler {
+	return NewEventHandler(
 		"HeapProfiler.heapStatsUpdate",
 		func(response *Response) {
 			event := &profiler.HeapStatsUpdateEvent{}
@@ -357,7 +430,36 @@ func (protocol *HeapProfilerProtocol) OnHeapStatsUpdate(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeHeapStatsUpdate returns a subscription that delivers
+HeapProfiler.heapStatsUpdate events on a typed channel until it is unsubscribed.
+See OnHeapStatsUpdate().
+
+https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#event-heapStatsUpdate
+*/
+func (protocol *HeapProfilerProtocol) SubscribeHeapStatsUpdate(
+	options *SubscriptionOptions,
+) *Subscription[*profiler.HeapStatsUpdateEvent] {
+	sub := NewSubscription[*profiler.HeapStatsUpdateEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newHeapStatsUpdateHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForHeapStatsUpdate blocks until a HeapProfiler.heapStatsUpdate event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#event-heapStatsUpdate
+*/
+func (protocol *HeapProfilerProtocol) WaitForHeapStatsUpdate(
+	ctx context.Context,
+	predicate func(event *profiler.HeapStatsUpdateEvent) bool,
+) (*profiler.HeapStatsUpdateEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeHeapStatsUpdate(nil), predicate)
 }
 
 /*
@@ -371,8 +473,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#event-lastS
 */
 func (protocol *HeapProfilerProtocol) OnLastSeenObjectID(
 	callback func(event *profiler.LastSeenObjectIDEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newLastSeenObjectIDHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newLastSeenObjectIDHandler returns a HeapProfiler.lastSeenObjectID event handler
+that has not been registered with the socket.
+*/
+func (protocol *HeapProfilerProtocol) newLastSeenObjectIDHandler(
+	callback func(event *profiler.LastSeenObjectIDEvent),
+) *Handler {
+	return NewEventHandler(
 		"HeapProfiler.lastSeenObjectID",
 		func(response *Response) {
 			event := &profiler.LastSeenObjectIDEvent{}
@@ -383,7 +497,36 @@ func (protocol *HeapProfilerProtocol) OnLastSeenObjectID(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeLastSeenObjectID returns a subscription that delivers
+HeapProfiler.lastSeenObjectID events on a typed channel until it is
+unsubscribed. See OnLastSeenObjectID().
+
+https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#event-lastSeenObjectId
+*/
+func (protocol *HeapProfilerProtocol) SubscribeLastSeenObjectID(
+	options *SubscriptionOptions,
+) *Subscription[*profiler.LastSeenObjectIDEvent] {
+	sub := NewSubscription[*profiler.LastSeenObjectIDEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newLastSeenObjectIDHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForLastSeenObjectID blocks until a HeapProfiler.lastSeenObjectID event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#event-lastSeenObjectId
+*/
+func (protocol *HeapProfilerProtocol) WaitForLastSeenObjectID(
+	ctx context.Context,
+	predicate func(event *profiler.LastSeenObjectIDEvent) bool,
+) (*profiler.LastSeenObjectIDEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeLastSeenObjectID(nil), predicate)
 }
 
 /*
@@ -396,8 +539,21 @@ EXPERIMENTAL.
 */
 func (protocol *HeapProfilerProtocol) OnReportHeapSnapshotProgress(
 	callback func(event *profiler.ReportHeapSnapshotProgressEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newReportHeapSnapshotProgressHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newReportHeapSnapshotProgressHandler returns a
+HeapProfiler.reportHeapSnapshotProgress event handler that has not been
+registered with the socket.
+*/
+func (protocol *HeapProfilerProtocol) newReportHeapSnapshotProgressHandler(
+	callback func(event *profiler.ReportHeapSnapshotProgressEvent),
+) *Handler {
+	return NewEventHandler(
 		"HeapProfiler.reportHeapSnapshotProgress",
 		func(response *Response) {
 			event := &profiler.ReportHeapSnapshotProgressEvent{}
@@ -408,7 +564,38 @@ func (protocol *HeapProfilerProtocol) OnReportHeapSnapshotProgress(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeReportHeapSnapshotProgress returns a subscription that delivers
+HeapProfiler.reportHeapSnapshotProgress events on a typed channel until it is
+unsubscribed. See OnReportHeapSnapshotProgress().
+
+https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#event-reportHeapSnapshotProgress
+EXPERIMENTAL.
+*/
+func (protocol *HeapProfilerProtocol) SubscribeReportHeapSnapshotProgress(
+	options *SubscriptionOptions,
+) *Subscription[*profiler.ReportHeapSnapshotProgressEvent] {
+	sub := NewSubscription[*profiler.ReportHeapSnapshotProgressEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newReportHeapSnapshotProgressHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForReportHeapSnapshotProgress blocks until a
+HeapProfiler.reportHeapSnapshotProgress event that satisfies the predicate is
+received and returns it. A nil predicate matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#event-reportHeapSnapshotProgress
+EXPERIMENTAL.
+*/
+func (protocol *HeapProfilerProtocol) WaitForReportHeapSnapshotProgress(
+	ctx context.Context,
+	predicate func(event *profiler.ReportHeapSnapshotProgressEvent) bool,
+) (*profiler.ReportHeapSnapshotProgressEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeReportHeapSnapshotProgress(nil), predicate)
 }
 
 /*
@@ -419,8 +606,20 @@ EXPERIMENTAL.
 */
 func (protocol *HeapProfilerProtocol) OnResetProfiles(
 	callback func(event *profiler.ResetProfilesEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newResetProfilesHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newResetProfilesHandler returns a HeapProfiler.resetProfiles event handler that
+has not been registered with the socket.
+*/
+func (protocol *HeapProfilerProtocol) newResetProfilesHandler(
+	callback func(event *profiler.ResetProfilesEvent),
+) *Handler {
+	return NewEventHandler(
 		"HeapProfiler.resetProfiles",
 		func(response *Response) {
 			event := &profiler.ResetProfilesEvent{}
@@ -431,5 +630,36 @@ func (protocol *HeapProfilerProtocol) OnResetProfiles(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeResetProfiles returns a subscription that delivers
+HeapProfiler.resetProfiles events on a typed channel until it is unsubscribed.
+See OnResetProfiles().
+
+https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#event-resetProfiles
+EXPERIMENTAL.
+*/
+func (protocol *HeapProfilerProtocol) SubscribeResetProfiles(
+	options *SubscriptionOptions,
+) *Subscription[*profiler.ResetProfilesEvent] {
+	sub := NewSubscription[*profiler.ResetProfilesEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newResetProfilesHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForResetProfiles blocks until a HeapProfiler.resetProfiles event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#event-resetProfiles
+EXPERIMENTAL.
+*/
+func (protocol *HeapProfilerProtocol) WaitForResetProfiles(
+	ctx context.Context,
+	predicate func(event *profiler.ResetProfilesEvent) bool,
+) (*profiler.ResetProfilesEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeResetProfiles(nil), predicate)
 }
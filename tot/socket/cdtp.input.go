@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"github.com/mkenney/go-chrome/tot/cdtp/input"
+	"time"
 )
 
 /*
@@ -13,6 +15,22 @@ type InputProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *InputProtocol) WithContext(ctx context.Context) *InputProtocol {
+	return &InputProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *InputProtocol) WithTimeout(timeout time.Duration) *InputProtocol {
+	return &InputProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 DispatchKeyEvent dispatches a key event to the page.
 
@@ -27,8 +45,8 @@ func (protocol *InputProtocol) DispatchKeyEvent(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -51,8 +69,8 @@ func (protocol *InputProtocol) DispatchMouseEvent(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -75,8 +93,8 @@ func (protocol *InputProtocol) DispatchTouchEvent(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -100,8 +118,8 @@ func (protocol *InputProtocol) EmulateTouchFromMouseEvent(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -124,8 +142,8 @@ func (protocol *InputProtocol) SetIgnoreEvents(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -150,8 +168,8 @@ func (protocol *InputProtocol) SynthesizePinchGesture(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -176,8 +194,8 @@ func (protocol *InputProtocol) SynthesizeScrollGesture(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -202,8 +220,8 @@ func (protocol *InputProtocol) SynthesizeTapGesture(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
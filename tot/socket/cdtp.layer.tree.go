@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/layer/tree"
 )
@@ -16,6 +18,22 @@ type LayerTreeProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *LayerTreeProtocol) WithContext(ctx context.Context) *LayerTreeProtocol {
+	return &LayerTreeProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *LayerTreeProtocol) WithTimeout(timeout time.Duration) *LayerTreeProtocol {
+	return &LayerTreeProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 CompositingReasons provides the reasons why the given layer was composited.
 
@@ -30,8 +48,8 @@ func (protocol *LayerTreeProtocol) CompositingReasons(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -54,8 +72,8 @@ func (protocol *LayerTreeProtocol) Disable() <-chan *tree.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -76,8 +94,8 @@ func (protocol *LayerTreeProtocol) Enable() <-chan *tree.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -100,8 +118,8 @@ func (protocol *LayerTreeProtocol) LoadSnapshot(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -126,8 +144,8 @@ func (protocol *LayerTreeProtocol) MakeSnapshot(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -152,8 +170,8 @@ func (protocol *LayerTreeProtocol) ProfileSnapshot(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -178,8 +196,8 @@ func (protocol *LayerTreeProtocol) ReleaseSnapshot(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -202,8 +220,8 @@ func (protocol *LayerTreeProtocol) ReplaySnapshot(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -228,8 +246,8 @@ func (protocol *LayerTreeProtocol) SnapshotCommandLog(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -248,8 +266,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/LayerTree/#event-layerPai
 */
 func (protocol *LayerTreeProtocol) OnLayerPainted(
 	callback func(event *tree.LayerPaintedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newLayerPaintedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newLayerPaintedHandler returns a LayerTree.layerPainted event handler that has
+not been registered with the socket.
+*/
+func (protocol *LayerTreeProtocol) newLayerPaintedHandler(
+	callback func(event *tree.LayerPaintedEvent),
+) *Handler {
+	return NewEventHandler(
 		"LayerTree.layerPainted",
 		func(response *Response) {
 			event := &tree.LayerPaintedEvent{}
@@ -260,7 +290,36 @@ func (protocol *LayerTreeProtocol) OnLayerPainted(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeLayerPainted returns a subscription that delivers
+LayerTree.layerPainted events on a typed channel until it is unsubscribed. See
+OnLayerPainted().
+
+https://chromedevtools.github.io/devtools-protocol/tot/LayerTree/#event-layerPainted
+*/
+func (protocol *LayerTreeProtocol) SubscribeLayerPainted(
+	options *SubscriptionOptions,
+) *Subscription[*tree.LayerPaintedEvent] {
+	sub := NewSubscription[*tree.LayerPaintedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newLayerPaintedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForLayerPainted blocks until a LayerTree.layerPainted event that satisfies
+the predicate is received and returns it. A nil predicate matches the first
+event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/LayerTree/#event-layerPainted
+*/
+func (protocol *LayerTreeProtocol) WaitForLayerPainted(
+	ctx context.Context,
+	predicate func(event *tree.LayerPaintedEvent) bool,
+) (*tree.LayerPaintedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeLayerPainted(nil), predicate)
 }
 
 /*
@@ -271,8 +330,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/LayerTree/#event-layerTre
 */
 func (protocol *LayerTreeProtocol) OnLayerTreeDidChange(
 	callback func(event *tree.DidChangeEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newLayerTreeDidChangeHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newLayerTreeDidChangeHandler returns a LayerTree.layerTreeDidChange event
+handler that has not been registered with the socket.
+*/
+func (protocol *LayerTreeProtocol) newLayerTreeDidChangeHandler(
+	callback func(event *tree.DidChangeEvent),
+) *Handler {
+	return NewEventHandler(
 		"LayerTree.layerTreeDidChange",
 		func(response *Response) {
 			event := &tree.DidChangeEvent{}
@@ -283,5 +354,34 @@ func (protocol *LayerTreeProtocol) OnLayerTreeDidChange(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeLayerTreeDidChange returns a subscription that delivers
+LayerTree.layerTreeDidChange events on a typed channel until it is unsubscribed.
+See OnLayerTreeDidChange().
+
+https://chromedevtools.github.io/devtools-protocol/tot/LayerTree/#event-layerTreeDidChange
+*/
+func (protocol *LayerTreeProtocol) SubscribeLayerTreeDidChange(
+	options *SubscriptionOptions,
+) *Subscription[*tree.DidChangeEvent] {
+	sub := NewSubscription[*tree.DidChangeEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newLayerTreeDidChangeHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForLayerTreeDidChange blocks until a LayerTree.layerTreeDidChange event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/LayerTree/#event-layerTreeDidChange
+*/
+func (protocol *LayerTreeProtocol) WaitForLayerTreeDidChange(
+	ctx context.Context,
+	predicate func(event *tree.DidChangeEvent) bool,
+) (*tree.DidChangeEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeLayerTreeDidChange(nil), predicate)
 }
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/log"
 )
@@ -16,6 +18,22 @@ type LogProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *LogProtocol) WithContext(ctx context.Context) *LogProtocol {
+	return &LogProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *LogProtocol) WithTimeout(timeout time.Duration) *LogProtocol {
+	return &LogProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 Clear clears the log.
 
@@ -28,8 +46,8 @@ func (protocol *LogProtocol) Clear() <-chan *log.ClearResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -51,8 +69,8 @@ func (protocol *LogProtocol) Disable() <-chan *log.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -74,8 +92,8 @@ func (protocol *LogProtocol) Enable() <-chan *log.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -98,8 +116,8 @@ func (protocol *LogProtocol) StartViolationsReport(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -120,8 +138,8 @@ func (protocol *LogProtocol) StopViolationsReport() <-chan *log.StopViolationsRe
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -138,8 +156,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Log/#event-entryAdded
 */
 func (protocol *LogProtocol) OnEntryAdded(
 	callback func(event *log.EntryAddedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newEntryAddedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newEntryAddedHandler returns a Log.entryAdded event handler that has not been
+registered with the socket.
+*/
+func (protocol *LogProtocol) newEntryAddedHandler(
+	callback func(event *log.EntryAddedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Log.entryAdded",
 		func(response *Response) {
 			event := &log.EntryAddedEvent{}
@@ -150,5 +180,33 @@ func (protocol *LogProtocol) OnEntryAdded(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeEntryAdded returns a subscription that delivers Log.entryAdded events
+on a typed channel until it is unsubscribed. See OnEntryAdded().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Log/#event-entryAdded
+*/
+func (protocol *LogProtocol) SubscribeEntryAdded(
+	options *SubscriptionOptions,
+) *Subscription[*log.EntryAddedEvent] {
+	sub := NewSubscription[*log.EntryAddedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newEntryAddedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForEntryAdded blocks until a Log.entryAdded event that satisfies the
+predicate is received and returns it. A nil predicate matches the first event.
+See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Log/#event-entryAdded
+*/
+func (protocol *LogProtocol) WaitForEntryAdded(
+	ctx context.Context,
+	predicate func(event *log.EntryAddedEvent) bool,
+) (*log.EntryAddedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeEntryAdded(nil), predicate)
 }
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"github.com/mkenney/go-chrome/tot/cdtp/memory"
+	"time"
 )
 
 /*
@@ -13,6 +15,22 @@ type MemoryProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *MemoryProtocol) WithContext(ctx context.Context) *MemoryProtocol {
+	return &MemoryProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *MemoryProtocol) WithTimeout(timeout time.Duration) *MemoryProtocol {
+	return &MemoryProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 GetDOMCounters is experimental.
 
@@ -28,8 +46,8 @@ func (protocol *MemoryProtocol) GetDOMCounters(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -51,8 +69,8 @@ func (protocol *MemoryProtocol) PrepareForLeakDetection() <-chan *memory.Prepare
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -76,8 +94,8 @@ func (protocol *MemoryProtocol) SetPressureNotificationsSuppressed(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -101,8 +119,8 @@ func (protocol *MemoryProtocol) SimulatePressureNotification(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
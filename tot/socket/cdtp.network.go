@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/network"
 )
@@ -18,6 +20,22 @@ type NetworkProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *NetworkProtocol) WithContext(ctx context.Context) *NetworkProtocol {
+	return &NetworkProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *NetworkProtocol) WithTimeout(timeout time.Duration) *NetworkProtocol {
+	return &NetworkProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 CanClearBrowserCache tells whether clearing browser cache is supported.
 
@@ -31,8 +49,8 @@ func (protocol *NetworkProtocol) CanClearBrowserCache() <-chan *network.CanClear
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -56,8 +74,8 @@ func (protocol *NetworkProtocol) CanClearBrowserCookies() <-chan *network.CanCle
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -81,8 +99,8 @@ func (protocol *NetworkProtocol) CanEmulateConditions() <-chan *network.CanEmula
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -105,8 +123,8 @@ func (protocol *NetworkProtocol) ClearBrowserCache() <-chan *network.ClearBrowse
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -127,8 +145,8 @@ func (protocol *NetworkProtocol) ClearBrowserCookies() <-chan *network.ClearBrow
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -156,8 +174,8 @@ func (protocol *NetworkProtocol) ContinueInterceptedRequest(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -181,8 +199,8 @@ func (protocol *NetworkProtocol) DeleteCookies(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -204,8 +222,8 @@ func (protocol *NetworkProtocol) Disable() <-chan *network.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -228,8 +246,8 @@ func (protocol *NetworkProtocol) EmulateConditions(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -253,8 +271,8 @@ func (protocol *NetworkProtocol) Enable(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -276,8 +294,8 @@ func (protocol *NetworkProtocol) GetAllCookies() <-chan *network.GetAllCookiesRe
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -303,8 +321,8 @@ func (protocol *NetworkProtocol) GetCertificate(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -330,8 +348,8 @@ func (protocol *NetworkProtocol) GetCookies(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -356,8 +374,8 @@ func (protocol *NetworkProtocol) GetResponseBody(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -384,8 +402,8 @@ func (protocol *NetworkProtocol) GetResponseBodyForInterception(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -412,8 +430,8 @@ func (protocol *NetworkProtocol) ReplayXHR(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -437,8 +455,8 @@ func (protocol *NetworkProtocol) SearchInResponseBody(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -464,8 +482,8 @@ func (protocol *NetworkProtocol) SetBlockedURLs(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -489,8 +507,8 @@ func (protocol *NetworkProtocol) SetBypassServiceWorker(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -514,8 +532,8 @@ func (protocol *NetworkProtocol) SetCacheDisabled(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -539,8 +557,8 @@ func (protocol *NetworkProtocol) SetCookie(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -565,8 +583,8 @@ func (protocol *NetworkProtocol) SetCookies(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -590,8 +608,8 @@ func (protocol *NetworkProtocol) SetDataSizeLimitsForTest(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -615,8 +633,8 @@ func (protocol *NetworkProtocol) SetExtraHTTPHeaders(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -641,8 +659,8 @@ func (protocol *NetworkProtocol) SetRequestInterception(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -665,8 +683,8 @@ func (protocol *NetworkProtocol) SetUserAgentOverride(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -683,8 +701,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-dataReceiv
 */
 func (protocol *NetworkProtocol) OnDataReceived(
 	callback func(event *network.DataReceivedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newDataReceivedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newDataReceivedHandler returns a Network.dataReceived event handler that has not
+been registered with the socket.
+*/
+func (protocol *NetworkProtocol) newDataReceivedHandler(
+	callback func(event *network.DataReceivedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Network.dataReceived",
 		func(response *Response) {
 			event := &network.DataReceivedEvent{}
@@ -695,7 +725,35 @@ func (protocol *NetworkProtocol) OnDataReceived(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeDataReceived returns a subscription that delivers Network.dataReceived
+events on a typed channel until it is unsubscribed. See OnDataReceived().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-dataReceived
+*/
+func (protocol *NetworkProtocol) SubscribeDataReceived(
+	options *SubscriptionOptions,
+) *Subscription[*network.DataReceivedEvent] {
+	sub := NewSubscription[*network.DataReceivedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newDataReceivedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForDataReceived blocks until a Network.dataReceived event that satisfies the
+predicate is received and returns it. A nil predicate matches the first event.
+See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-dataReceived
+*/
+func (protocol *NetworkProtocol) WaitForDataReceived(
+	ctx context.Context,
+	predicate func(event *network.DataReceivedEvent) bool,
+) (*network.DataReceivedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeDataReceived(nil), predicate)
 }
 
 /*
@@ -707,8 +765,21 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-eventSourc
 */
 func (protocol *NetworkProtocol) OnEventSourceMessageReceived(
 	callback func(event *network.EventSourceMessageReceivedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newEventSourceMessageReceivedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newEventSourceMessageReceivedHandler returns a
+Network.eventSourceMessageReceived event handler that has not been registered
+with the socket.
+*/
+func (protocol *NetworkProtocol) newEventSourceMessageReceivedHandler(
+	callback func(event *network.EventSourceMessageReceivedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Network.eventSourceMessageReceived",
 		func(response *Response) {
 			event := &network.EventSourceMessageReceivedEvent{}
@@ -719,7 +790,36 @@ func (protocol *NetworkProtocol) OnEventSourceMessageReceived(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeEventSourceMessageReceived returns a subscription that delivers
+Network.eventSourceMessageReceived events on a typed channel until it is
+unsubscribed. See OnEventSourceMessageReceived().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-eventSourceMessageReceived
+*/
+func (protocol *NetworkProtocol) SubscribeEventSourceMessageReceived(
+	options *SubscriptionOptions,
+) *Subscription[*network.EventSourceMessageReceivedEvent] {
+	sub := NewSubscription[*network.EventSourceMessageReceivedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newEventSourceMessageReceivedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForEventSourceMessageReceived blocks until a
+Network.eventSourceMessageReceived event that satisfies the predicate is
+received and returns it. A nil predicate matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-eventSourceMessageReceived
+*/
+func (protocol *NetworkProtocol) WaitForEventSourceMessageReceived(
+	ctx context.Context,
+	predicate func(event *network.EventSourceMessageReceivedEvent) bool,
+) (*network.EventSourceMessageReceivedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeEventSourceMessageReceived(nil), predicate)
 }
 
 /*
@@ -730,8 +830,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-loadingFai
 */
 func (protocol *NetworkProtocol) OnLoadingFailed(
 	callback func(event *network.LoadingFailedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newLoadingFailedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newLoadingFailedHandler returns a Network.loadingFailed event handler that has
+not been registered with the socket.
+*/
+func (protocol *NetworkProtocol) newLoadingFailedHandler(
+	callback func(event *network.LoadingFailedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Network.loadingFailed",
 		func(response *Response) {
 			event := &network.LoadingFailedEvent{}
@@ -742,7 +854,36 @@ func (protocol *NetworkProtocol) OnLoadingFailed(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeLoadingFailed returns a subscription that delivers
+Network.loadingFailed events on a typed channel until it is unsubscribed. See
+OnLoadingFailed().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-loadingFailed
+*/
+func (protocol *NetworkProtocol) SubscribeLoadingFailed(
+	options *SubscriptionOptions,
+) *Subscription[*network.LoadingFailedEvent] {
+	sub := NewSubscription[*network.LoadingFailedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newLoadingFailedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForLoadingFailed blocks until a Network.loadingFailed event that satisfies
+the predicate is received and returns it. A nil predicate matches the first
+event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-loadingFailed
+*/
+func (protocol *NetworkProtocol) WaitForLoadingFailed(
+	ctx context.Context,
+	predicate func(event *network.LoadingFailedEvent) bool,
+) (*network.LoadingFailedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeLoadingFailed(nil), predicate)
 }
 
 /*
@@ -753,8 +894,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-loadingFin
 */
 func (protocol *NetworkProtocol) OnLoadingFinished(
 	callback func(event *network.LoadingFinishedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newLoadingFinishedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newLoadingFinishedHandler returns a Network.loadingFinished event handler that
+has not been registered with the socket.
+*/
+func (protocol *NetworkProtocol) newLoadingFinishedHandler(
+	callback func(event *network.LoadingFinishedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Network.loadingFinished",
 		func(response *Response) {
 			event := &network.LoadingFinishedEvent{}
@@ -765,7 +918,36 @@ func (protocol *NetworkProtocol) OnLoadingFinished(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeLoadingFinished returns a subscription that delivers
+Network.loadingFinished events on a typed channel until it is unsubscribed. See
+OnLoadingFinished().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-loadingFinished
+*/
+func (protocol *NetworkProtocol) SubscribeLoadingFinished(
+	options *SubscriptionOptions,
+) *Subscription[*network.LoadingFinishedEvent] {
+	sub := NewSubscription[*network.LoadingFinishedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newLoadingFinishedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForLoadingFinished blocks until a Network.loadingFinished event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-loadingFinished
+*/
+func (protocol *NetworkProtocol) WaitForLoadingFinished(
+	ctx context.Context,
+	predicate func(event *network.LoadingFinishedEvent) bool,
+) (*network.LoadingFinishedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeLoadingFinished(nil), predicate)
 }
 
 /*
@@ -778,8 +960,20 @@ EXPERIMENTAL.
 */
 func (protocol *NetworkProtocol) OnRequestIntercepted(
 	callback func(event *network.RequestInterceptedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newRequestInterceptedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newRequestInterceptedHandler returns a Network.requestIntercepted event handler
+that has not been registered with the socket.
+*/
+func (protocol *NetworkProtocol) newRequestInterceptedHandler(
+	callback func(event *network.RequestInterceptedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Network.requestIntercepted",
 		func(response *Response) {
 			event := &network.RequestInterceptedEvent{}
@@ -790,7 +984,38 @@ func (protocol *NetworkProtocol) OnRequestIntercepted(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeRequestIntercepted returns a subscription that delivers
+Network.requestIntercepted events on a typed channel until it is unsubscribed.
+See OnRequestIntercepted().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-requestIntercepted
+EXPERIMENTAL.
+*/
+func (protocol *NetworkProtocol) SubscribeRequestIntercepted(
+	options *SubscriptionOptions,
+) *Subscription[*network.RequestInterceptedEvent] {
+	sub := NewSubscription[*network.RequestInterceptedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newRequestInterceptedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForRequestIntercepted blocks until a Network.requestIntercepted event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-requestIntercepted
+EXPERIMENTAL.
+*/
+func (protocol *NetworkProtocol) WaitForRequestIntercepted(
+	ctx context.Context,
+	predicate func(event *network.RequestInterceptedEvent) bool,
+) (*network.RequestInterceptedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeRequestIntercepted(nil), predicate)
 }
 
 /*
@@ -802,8 +1027,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-requestSer
 */
 func (protocol *NetworkProtocol) OnRequestServedFromCache(
 	callback func(event *network.RequestServedFromCacheEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newRequestServedFromCacheHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newRequestServedFromCacheHandler returns a Network.requestServedFromCache event
+handler that has not been registered with the socket.
+*/
+func (protocol *NetworkProtocol) newRequestServedFromCacheHandler(
+	callback func(event *network.RequestServedFromCacheEvent),
+) *Handler {
+	return NewEventHandler(
 		"Network.requestServedFromCache",
 		func(response *Response) {
 			event := &network.RequestServedFromCacheEvent{}
@@ -814,7 +1051,36 @@ func (protocol *NetworkProtocol) OnRequestServedFromCache(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeRequestServedFromCache returns a subscription that delivers
+Network.requestServedFromCache events on a typed channel until it is
+unsubscribed. See OnRequestServedFromCache().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-requestServedFromCache
+*/
+func (protocol *NetworkProtocol) SubscribeRequestServedFromCache(
+	options *SubscriptionOptions,
+) *Subscription[*network.RequestServedFromCacheEvent] {
+	sub := NewSubscription[*network.RequestServedFromCacheEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newRequestServedFromCacheHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForRequestServedFromCache blocks until a Network.requestServedFromCache
+event that satisfies the predicate is received and returns it. A nil predicate
+matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-requestServedFromCache
+*/
+func (protocol *NetworkProtocol) WaitForRequestServedFromCache(
+	ctx context.Context,
+	predicate func(event *network.RequestServedFromCacheEvent) bool,
+) (*network.RequestServedFromCacheEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeRequestServedFromCache(nil), predicate)
 }
 
 /*
@@ -825,8 +1091,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-requestWil
 */
 func (protocol *NetworkProtocol) OnRequestWillBeSent(
 	callback func(event *network.RequestWillBeSentEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newRequestWillBeSentHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newRequestWillBeSentHandler returns a Network.requestWillBeSent event handler
+that has not been registered with the socket.
+*/
+func (protocol *NetworkProtocol) newRequestWillBeSentHandler(
+	callback func(event *network.RequestWillBeSentEvent),
+) *Handler {
+	return NewEventHandler(
 		"Network.requestWillBeSent",
 		func(response *Response) {
 			event := &network.RequestWillBeSentEvent{}
@@ -837,7 +1115,36 @@ func (protocol *NetworkProtocol) OnRequestWillBeSent(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeRequestWillBeSent returns a subscription that delivers
+Network.requestWillBeSent events on a typed channel until it is unsubscribed.
+See OnRequestWillBeSent().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-requestWillBeSent
+*/
+func (protocol *NetworkProtocol) SubscribeRequestWillBeSent(
+	options *SubscriptionOptions,
+) *Subscription[*network.RequestWillBeSentEvent] {
+	sub := NewSubscription[*network.RequestWillBeSentEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newRequestWillBeSentHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForRequestWillBeSent blocks until a Network.requestWillBeSent event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-requestWillBeSent
+*/
+func (protocol *NetworkProtocol) WaitForRequestWillBeSent(
+	ctx context.Context,
+	predicate func(event *network.RequestWillBeSentEvent) bool,
+) (*network.RequestWillBeSentEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeRequestWillBeSent(nil), predicate)
 }
 
 /*
@@ -850,8 +1157,20 @@ EXPERIMENTAL.
 */
 func (protocol *NetworkProtocol) OnResourceChangedPriority(
 	callback func(event *network.ResourceChangedPriorityEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newResourceChangedPriorityHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newResourceChangedPriorityHandler returns a Network.resourceChangedPriority
+event handler that has not been registered with the socket.
+*/
+func (protocol *NetworkProtocol) newResourceChangedPriorityHandler(
+	callback func(event *network.ResourceChangedPriorityEvent),
+) *Handler {
+	return NewEventHandler(
 		"Network.resourceChangedPriority",
 		func(response *Response) {
 			event := &network.ResourceChangedPriorityEvent{}
@@ -862,7 +1181,38 @@ func (protocol *NetworkProtocol) OnResourceChangedPriority(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeResourceChangedPriority returns a subscription that delivers
+Network.resourceChangedPriority events on a typed channel until it is
+unsubscribed. See OnResourceChangedPriority().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-resourceChangedPriority
+EXPERIMENTAL.
+*/
+func (protocol *NetworkProtocol) SubscribeResourceChangedPriority(
+	options *SubscriptionOptions,
+) *Subscription[*network.ResourceChangedPriorityEvent] {
+	sub := NewSubscription[*network.ResourceChangedPriorityEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newResourceChangedPriorityHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForResourceChangedPriority blocks until a Network.resourceChangedPriority
+event that satisfies the predicate is received and returns it. A nil predicate
+matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-resourceChangedPriority
+EXPERIMENTAL.
+*/
+func (protocol *NetworkProtocol) WaitForResourceChangedPriority(
+	ctx context.Context,
+	predicate func(event *network.ResourceChangedPriorityEvent) bool,
+) (*network.ResourceChangedPriorityEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeResourceChangedPriority(nil), predicate)
 }
 
 /*
@@ -873,8 +1223,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-responseRe
 */
 func (protocol *NetworkProtocol) OnResponseReceived(
 	callback func(event *network.ResponseReceivedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newResponseReceivedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newResponseReceivedHandler returns a Network.responseReceived event handler that
+has not been registered with the socket.
+*/
+func (protocol *NetworkProtocol) newResponseReceivedHandler(
+	callback func(event *network.ResponseReceivedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Network.responseReceived",
 		func(response *Response) {
 			event := &network.ResponseReceivedEvent{}
@@ -885,7 +1247,36 @@ func (protocol *NetworkProtocol) OnResponseReceived(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeResponseReceived returns a subscription that delivers
+Network.responseReceived events on a typed channel until it is unsubscribed. See
+OnResponseReceived().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-responseReceived
+*/
+func (protocol *NetworkProtocol) SubscribeResponseReceived(
+	options *SubscriptionOptions,
+) *Subscription[*network.ResponseReceivedEvent] {
+	sub := NewSubscription[*network.ResponseReceivedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newResponseReceivedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForResponseReceived blocks until a Network.responseReceived event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-responseReceived
+*/
+func (protocol *NetworkProtocol) WaitForResponseReceived(
+	ctx context.Context,
+	predicate func(event *network.ResponseReceivedEvent) bool,
+) (*network.ResponseReceivedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeResponseReceived(nil), predicate)
 }
 
 /*
@@ -896,8 +1287,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketC
 */
 func (protocol *NetworkProtocol) OnWebSocketClosed(
 	callback func(event *network.WebSocketClosedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newWebSocketClosedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newWebSocketClosedHandler returns a Network.webSocketClosed event handler that
+has not been registered with the socket.
+*/
+func (protocol *NetworkProtocol) newWebSocketClosedHandler(
+	callback func(event *network.WebSocketClosedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Network.webSocketClosed",
 		func(response *Response) {
 			event := &network.WebSocketClosedEvent{}
@@ -908,7 +1311,36 @@ func (protocol *NetworkProtocol) OnWebSocketClosed(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeWebSocketClosed returns a subscription that delivers
+Network.webSocketClosed events on a typed channel until it is unsubscribed. See
+OnWebSocketClosed().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketClosed
+*/
+func (protocol *NetworkProtocol) SubscribeWebSocketClosed(
+	options *SubscriptionOptions,
+) *Subscription[*network.WebSocketClosedEvent] {
+	sub := NewSubscription[*network.WebSocketClosedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newWebSocketClosedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForWebSocketClosed blocks until a Network.webSocketClosed event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketClosed
+*/
+func (protocol *NetworkProtocol) WaitForWebSocketClosed(
+	ctx context.Context,
+	predicate func(event *network.WebSocketClosedEvent) bool,
+) (*network.WebSocketClosedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeWebSocketClosed(nil), predicate)
 }
 
 /*
@@ -919,8 +1351,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketC
 */
 func (protocol *NetworkProtocol) OnWebSocketCreated(
 	callback func(event *network.WebSocketCreatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newWebSocketCreatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newWebSocketCreatedHandler returns a Network.webSocketCreated event handler that
+has not been registered with the socket.
+*/
+func (protocol *NetworkProtocol) newWebSocketCreatedHandler(
+	callback func(event *network.WebSocketCreatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Network.webSocketCreated",
 		func(response *Response) {
 			event := &network.WebSocketCreatedEvent{}
@@ -931,7 +1375,36 @@ func (protocol *NetworkProtocol) OnWebSocketCreated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeWebSocketCreated returns a subscription that delivers
+Network.webSocketCreated events on a typed channel until it is unsubscribed. See
+OnWebSocketCreated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketCreated
+*/
+func (protocol *NetworkProtocol) SubscribeWebSocketCreated(
+	options *SubscriptionOptions,
+) *Subscription[*network.WebSocketCreatedEvent] {
+	sub := NewSubscription[*network.WebSocketCreatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newWebSocketCreatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForWebSocketCreated blocks until a Network.webSocketCreated event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketCreated
+*/
+func (protocol *NetworkProtocol) WaitForWebSocketCreated(
+	ctx context.Context,
+	predicate func(event *network.WebSocketCreatedEvent) bool,
+) (*network.WebSocketCreatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeWebSocketCreated(nil), predicate)
 }
 
 /*
@@ -942,8 +1415,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketF
 */
 func (protocol *NetworkProtocol) OnWebSocketFrameError(
 	callback func(event *network.WebSocketFrameErrorEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newWebSocketFrameErrorHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newWebSocketFrameErrorHandler returns a Network.webSocketFrameError event
+handler that has not been registered with the socket.
+*/
+func (protocol *NetworkProtocol) newWebSocketFrameErrorHandler(
+	callback func(event *network.WebSocketFrameErrorEvent),
+) *Handler {
+	return NewEventHandler(
 		"Network.webSocketFrameError",
 		func(response *Response) {
 			event := &network.WebSocketFrameErrorEvent{}
@@ -954,7 +1439,36 @@ func (protocol *NetworkProtocol) OnWebSocketFrameError(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeWebSocketFrameError returns a subscription that delivers
+Network.webSocketFrameError events on a typed channel until it is unsubscribed.
+See OnWebSocketFrameError().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketFrameError
+*/
+func (protocol *NetworkProtocol) SubscribeWebSocketFrameError(
+	options *SubscriptionOptions,
+) *Subscription[*network.WebSocketFrameErrorEvent] {
+	sub := NewSubscription[*network.WebSocketFrameErrorEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newWebSocketFrameErrorHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForWebSocketFrameError blocks until a Network.webSocketFrameError event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketFrameError
+*/
+func (protocol *NetworkProtocol) WaitForWebSocketFrameError(
+	ctx context.Context,
+	predicate func(event *network.WebSocketFrameErrorEvent) bool,
+) (*network.WebSocketFrameErrorEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeWebSocketFrameError(nil), predicate)
 }
 
 /*
@@ -965,8 +1479,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketF
 */
 func (protocol *NetworkProtocol) OnWebSocketFrameReceived(
 	callback func(event *network.WebSocketFrameReceivedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newWebSocketFrameReceivedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newWebSocketFrameReceivedHandler returns a Network.webSocketFrameReceived event
+handler that has not been registered with the socket.
+*/
+func (protocol *NetworkProtocol) newWebSocketFrameReceivedHandler(
+	callback func(event *network.WebSocketFrameReceivedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Network.webSocketFrameReceived",
 		func(response *Response) {
 			event := &network.WebSocketFrameReceivedEvent{}
@@ -977,7 +1503,36 @@ func (protocol *NetworkProtocol) OnWebSocketFrameReceived(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeWebSocketFrameReceived returns a subscription that delivers
+Network.webSocketFrameReceived events on a typed channel until it is
+unsubscribed. See OnWebSocketFrameReceived().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketFrameReceived
+*/
+func (protocol *NetworkProtocol) SubscribeWebSocketFrameReceived(
+	options *SubscriptionOptions,
+) *Subscription[*network.WebSocketFrameReceivedEvent] {
+	sub := NewSubscription[*network.WebSocketFrameReceivedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newWebSocketFrameReceivedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForWebSocketFrameReceived blocks until a Network.webSocketFrameReceived
+event that satisfies the predicate is received and returns it. A nil predicate
+matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketFrameReceived
+*/
+func (protocol *NetworkProtocol) WaitForWebSocketFrameReceived(
+	ctx context.Context,
+	predicate func(event *network.WebSocketFrameReceivedEvent) bool,
+) (*network.WebSocketFrameReceivedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeWebSocketFrameReceived(nil), predicate)
 }
 
 /*
@@ -988,8 +1543,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketF
 */
 func (protocol *NetworkProtocol) OnWebSocketFrameSent(
 	callback func(event *network.WebSocketFrameSentEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newWebSocketFrameSentHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newWebSocketFrameSentHandler returns a Network.webSocketFrameSent event handler
+that has not been registered with the socket.
+*/
+func (protocol *NetworkProtocol) newWebSocketFrameSentHandler(
+	callback func(event *network.WebSocketFrameSentEvent),
+) *Handler {
+	return NewEventHandler(
 		"Network.webSocketFrameSent",
 		func(response *Response) {
 			event := &network.WebSocketFrameSentEvent{}
@@ -1000,7 +1567,36 @@ func (protocol *NetworkProtocol) OnWebSocketFrameSent(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeWebSocketFrameSent returns a subscription that delivers
+Network.webSocketFrameSent events on a typed channel until it is unsubscribed.
+See OnWebSocketFrameSent().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketFrameSent
+*/
+func (protocol *NetworkProtocol) SubscribeWebSocketFrameSent(
+	options *SubscriptionOptions,
+) *Subscription[*network.WebSocketFrameSentEvent] {
+	sub := NewSubscription[*network.WebSocketFrameSentEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newWebSocketFrameSentHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForWebSocketFrameSent blocks until a Network.webSocketFrameSent event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketFrameSent
+*/
+func (protocol *NetworkProtocol) WaitForWebSocketFrameSent(
+	ctx context.Context,
+	predicate func(event *network.WebSocketFrameSentEvent) bool,
+) (*network.WebSocketFrameSentEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeWebSocketFrameSent(nil), predicate)
 }
 
 /*
@@ -1012,8 +1608,21 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketH
 */
 func (protocol *NetworkProtocol) OnWebSocketHandshakeResponseReceived(
 	callback func(event *network.WebSocketHandshakeResponseReceivedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newWebSocketHandshakeResponseReceivedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newWebSocketHandshakeResponseReceivedHandler returns a
+Network.webSocketHandshakeResponseReceived event handler that has not been
+registered with the socket.
+*/
+func (protocol *NetworkProtocol) newWebSocketHandshakeResponseReceivedHandler(
+	callback func(event *network.WebSocketHandshakeResponseReceivedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Network.webSocketHandshakeResponseReceived",
 		func(response *Response) {
 			event := &network.WebSocketHandshakeResponseReceivedEvent{}
@@ -1024,7 +1633,36 @@ func (protocol *NetworkProtocol) OnWebSocketHandshakeResponseReceived(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeWebSocketHandshakeResponseReceived returns a subscription that delivers
+Network.webSocketHandshakeResponseReceived events on a typed channel until it is
+unsubscribed. See OnWebSocketHandshakeResponseReceived().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketHandshakeResponseReceived
+*/
+func (protocol *NetworkProtocol) SubscribeWebSocketHandshakeResponseReceived(
+	options *SubscriptionOptions,
+) *Subscription[*network.WebSocketHandshakeResponseReceivedEvent] {
+	sub := NewSubscription[*network.WebSocketHandshakeResponseReceivedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newWebSocketHandshakeResponseReceivedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForWebSocketHandshakeResponseReceived blocks until a
+Network.webSocketHandshakeResponseReceived event that satisfies the predicate is
+received and returns it. A nil predicate matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketHandshakeResponseReceived
+*/
+func (protocol *NetworkProtocol) WaitForWebSocketHandshakeResponseReceived(
+	ctx context.Context,
+	predicate func(event *network.WebSocketHandshakeResponseReceivedEvent) bool,
+) (*network.WebSocketHandshakeResponseReceivedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeWebSocketHandshakeResponseReceived(nil), predicate)
 }
 
 /*
@@ -1036,8 +1674,21 @@ https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketW
 */
 func (protocol *NetworkProtocol) OnWebSocketWillSendHandshakeRequest(
 	callback func(event *network.WebSocketWillSendHandshakeRequestEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newWebSocketWillSendHandshakeRequestHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newWebSocketWillSendHandshakeRequestHandler returns a
+Network.webSocketWillSendHandshakeRequest event handler that has not been
+registered with the socket.
+*/
+func (protocol *NetworkProtocol) newWebSocketWillSendHandshakeRequestHandler(
+	callback func(event *network.WebSocketWillSendHandshakeRequestEvent),
+) *Handler {
+	return NewEventHandler(
 		"Network.webSocketWillSendHandshakeRequest",
 		func(response *Response) {
 			event := &network.WebSocketWillSendHandshakeRequestEvent{}
@@ -1048,5 +1699,34 @@ func (protocol *NetworkProtocol) OnWebSocketWillSendHandshakeRequest(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeWebSocketWillSendHandshakeRequest returns a subscription that delivers
+Network.webSocketWillSendHandshakeRequest events on a typed channel until it is
+unsubscribed. See OnWebSocketWillSendHandshakeRequest().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketWillSendHandshakeRequest
+*/
+func (protocol *NetworkProtocol) SubscribeWebSocketWillSendHandshakeRequest(
+	options *SubscriptionOptions,
+) *Subscription[*network.WebSocketWillSendHandshakeRequestEvent] {
+	sub := NewSubscription[*network.WebSocketWillSendHandshakeRequestEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newWebSocketWillSendHandshakeRequestHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForWebSocketWillSendHandshakeRequest blocks until a
+Network.webSocketWillSendHandshakeRequest event that satisfies the predicate is
+received and returns it. A nil predicate matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-webSocketWillSendHandshakeRequest
+*/
+func (protocol *NetworkProtocol) WaitForWebSocketWillSendHandshakeRequest(
+	ctx context.Context,
+	predicate func(event *network.WebSocketWillSendHandshakeRequestEvent) bool,
+) (*network.WebSocketWillSendHandshakeRequestEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeWebSocketWillSendHandshakeRequest(nil), predicate)
 }
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/overlay"
 )
@@ -17,6 +19,22 @@ type OverlayProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *OverlayProtocol) WithContext(ctx context.Context) *OverlayProtocol {
+	return &OverlayProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *OverlayProtocol) WithTimeout(timeout time.Duration) *OverlayProtocol {
+	return &OverlayProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 Disable disables domain notifications.
 
@@ -29,8 +47,8 @@ func (protocol *OverlayProtocol) Disable() <-chan *overlay.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -51,8 +69,8 @@ func (protocol *OverlayProtocol) Enable() <-chan *overlay.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -75,8 +93,8 @@ func (protocol *OverlayProtocol) GetHighlightObjectForTest(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -99,8 +117,8 @@ func (protocol *OverlayProtocol) HideHighlight() <-chan *overlay.HideHighlightRe
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -123,8 +141,8 @@ func (protocol *OverlayProtocol) HighlightFrame(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -148,8 +166,8 @@ func (protocol *OverlayProtocol) HighlightNode(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -173,8 +191,8 @@ func (protocol *OverlayProtocol) HighlightQuad(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -198,8 +216,8 @@ func (protocol *OverlayProtocol) HighlightRect(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -224,8 +242,8 @@ func (protocol *OverlayProtocol) SetInspectMode(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -248,8 +266,8 @@ func (protocol *OverlayProtocol) SetPausedInDebuggerMessage(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -272,8 +290,8 @@ func (protocol *OverlayProtocol) SetShowDebugBorders(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -296,8 +314,8 @@ func (protocol *OverlayProtocol) SetShowFPSCounter(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -320,8 +338,8 @@ func (protocol *OverlayProtocol) SetShowPaintRects(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -344,8 +362,8 @@ func (protocol *OverlayProtocol) SetShowScrollBottleneckRects(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -368,8 +386,8 @@ func (protocol *OverlayProtocol) SetShowViewportSizeOnResize(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -392,8 +410,8 @@ func (protocol *OverlayProtocol) SetSuspended(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -412,8 +430,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#event-inspectNod
 */
 func (protocol *OverlayProtocol) OnInspectNodeRequested(
 	callback func(event *overlay.InspectNodeRequestedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newInspectNodeRequestedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newInspectNodeRequestedHandler returns a Overlay.inspectNodeRequested event
+handler that has not been registered with the socket.
+*/
+func (protocol *OverlayProtocol) newInspectNodeRequestedHandler(
+	callback func(event *overlay.InspectNodeRequestedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Overlay.inspectNodeRequested",
 		func(response *Response) {
 			event := &overlay.InspectNodeRequestedEvent{}
@@ -424,7 +454,36 @@ func (protocol *OverlayProtocol) OnInspectNodeRequested(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeInspectNodeRequested returns a subscription that delivers
+Overlay.inspectNodeRequested events on a typed channel until it is unsubscribed.
+See OnInspectNodeRequested().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#event-inspectNodeRequested
+*/
+func (protocol *OverlayProtocol) SubscribeInspectNodeRequested(
+	options *SubscriptionOptions,
+) *Subscription[*overlay.InspectNodeRequestedEvent] {
+	sub := NewSubscription[*overlay.InspectNodeRequestedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newInspectNodeRequestedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForInspectNodeRequested blocks until an Overlay.inspectNodeRequested event
+that satisfies the predicate is received and returns it. A nil predicate matches
+the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#event-inspectNodeRequested
+*/
+func (protocol *OverlayProtocol) WaitForInspectNodeRequested(
+	ctx context.Context,
+	predicate func(event *overlay.InspectNodeRequestedEvent) bool,
+) (*overlay.InspectNodeRequestedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeInspectNodeRequested(nil), predicate)
 }
 
 /*
@@ -436,8 +495,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#event-nodeHighli
 */
 func (protocol *OverlayProtocol) OnNodeHighlightRequested(
 	callback func(event *overlay.NodeHighlightRequestedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newNodeHighlightRequestedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newNodeHighlightRequestedHandler returns a Overlay.nodeHighlightRequested event
+handler that has not been registered with the socket.
+*/
+func (protocol *OverlayProtocol) newNodeHighlightRequestedHandler(
+	callback func(event *overlay.NodeHighlightRequestedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Overlay.nodeHighlightRequested",
 		func(response *Response) {
 			event := &overlay.NodeHighlightRequestedEvent{}
@@ -448,7 +519,36 @@ func (protocol *OverlayProtocol) OnNodeHighlightRequested(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeNodeHighlightRequested returns a subscription that delivers
+Overlay.nodeHighlightRequested events on a typed channel until it is
+unsubscribed. See OnNodeHighlightRequested().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#event-nodeHighlightRequested
+*/
+func (protocol *OverlayProtocol) SubscribeNodeHighlightRequested(
+	options *SubscriptionOptions,
+) *Subscription[*overlay.NodeHighlightRequestedEvent] {
+	sub := NewSubscription[*overlay.NodeHighlightRequestedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newNodeHighlightRequestedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForNodeHighlightRequested blocks until an Overlay.nodeHighlightRequested
+event that satisfies the predicate is received and returns it. A nil predicate
+matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#event-nodeHighlightRequested
+*/
+func (protocol *OverlayProtocol) WaitForNodeHighlightRequested(
+	ctx context.Context,
+	predicate func(event *overlay.NodeHighlightRequestedEvent) bool,
+) (*overlay.NodeHighlightRequestedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeNodeHighlightRequested(nil), predicate)
 }
 
 /*
@@ -460,8 +560,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#event-screenshot
 */
 func (protocol *OverlayProtocol) OnScreenshotRequested(
 	callback func(event *overlay.ScreenshotRequestedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newScreenshotRequestedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newScreenshotRequestedHandler returns a Overlay.screenshotRequested event
+handler that has not been registered with the socket.
+*/
+func (protocol *OverlayProtocol) newScreenshotRequestedHandler(
+	callback func(event *overlay.ScreenshotRequestedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Overlay.screenshotRequested",
 		func(response *Response) {
 			event := &overlay.ScreenshotRequestedEvent{}
@@ -472,5 +584,34 @@ func (protocol *OverlayProtocol) OnScreenshotRequested(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeScreenshotRequested returns a subscription that delivers
+Overlay.screenshotRequested events on a typed channel until it is unsubscribed.
+See OnScreenshotRequested().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#event-screenshotRequested
+*/
+func (protocol *OverlayProtocol) SubscribeScreenshotRequested(
+	options *SubscriptionOptions,
+) *Subscription[*overlay.ScreenshotRequestedEvent] {
+	sub := NewSubscription[*overlay.ScreenshotRequestedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newScreenshotRequestedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForScreenshotRequested blocks until an Overlay.screenshotRequested event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#event-screenshotRequested
+*/
+func (protocol *OverlayProtocol) WaitForScreenshotRequested(
+	ctx context.Context,
+	predicate func(event *overlay.ScreenshotRequestedEvent) bool,
+) (*overlay.ScreenshotRequestedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeScreenshotRequested(nil), predicate)
 }
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/page"
 )
@@ -17,6 +19,22 @@ type PageProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *PageProtocol) WithContext(ctx context.Context) *PageProtocol {
+	return &PageProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *PageProtocol) WithTimeout(timeout time.Duration) *PageProtocol {
+	return &PageProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 AddScriptToEvaluateOnLoad is eprecated, please use addScriptToEvaluateOnNewDocument
 instead.
@@ -33,8 +51,8 @@ func (protocol *PageProtocol) AddScriptToEvaluateOnLoad(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -60,8 +78,8 @@ func (protocol *PageProtocol) AddScriptToEvaluateOnNewDocument(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -84,8 +102,8 @@ func (protocol *PageProtocol) BringToFront() <-chan *page.BringToFrontResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -108,8 +126,8 @@ func (protocol *PageProtocol) CaptureScreenshot(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -134,8 +152,8 @@ func (protocol *PageProtocol) CreateIsolatedWorld(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -158,8 +176,8 @@ func (protocol *PageProtocol) Disable() <-chan *page.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -180,8 +198,8 @@ func (protocol *PageProtocol) Enable() <-chan *page.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -204,8 +222,8 @@ func (protocol *PageProtocol) GetAppManifest(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -226,8 +244,8 @@ func (protocol *PageProtocol) GetFrameTree() <-chan *page.GetFrameTreeResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -251,8 +269,8 @@ func (protocol *PageProtocol) GetLayoutMetrics() <-chan *page.GetLayoutMetricsRe
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -275,8 +293,8 @@ func (protocol *PageProtocol) GetNavigationHistory() <-chan *page.GetNavigationH
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -302,8 +320,8 @@ func (protocol *PageProtocol) GetResourceContent(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -327,8 +345,8 @@ func (protocol *PageProtocol) GetResourceTree() <-chan *page.GetResourceTreeResu
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -354,8 +372,8 @@ func (protocol *PageProtocol) HandleJavaScriptDialog(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -378,8 +396,8 @@ func (protocol *PageProtocol) Navigate(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -404,8 +422,8 @@ func (protocol *PageProtocol) NavigateToHistoryEntry(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -428,8 +446,8 @@ func (protocol *PageProtocol) PrintToPDF(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -454,8 +472,8 @@ func (protocol *PageProtocol) Reload(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -480,8 +498,8 @@ func (protocol *PageProtocol) RemoveScriptToEvaluateOnLoad(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -504,8 +522,8 @@ func (protocol *PageProtocol) RemoveScriptToEvaluateOnNewDocument(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -526,8 +544,8 @@ func (protocol *PageProtocol) RequestAppBanner() <-chan *page.RequestAppBannerRe
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -551,8 +569,8 @@ func (protocol *PageProtocol) ScreencastFrameAck(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -576,8 +594,8 @@ func (protocol *PageProtocol) SearchInResource(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -603,8 +621,8 @@ func (protocol *PageProtocol) SetAdBlockingEnabled(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -629,8 +647,8 @@ func (protocol *PageProtocol) SetAutoAttachToCreatedPages(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -653,8 +671,8 @@ func (protocol *PageProtocol) SetDocumentContent(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -678,8 +696,8 @@ func (protocol *PageProtocol) SetDownloadBehavior(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -703,8 +721,8 @@ func (protocol *PageProtocol) SetLifecycleEventsEnabled(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -728,8 +746,8 @@ func (protocol *PageProtocol) StartScreencast(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -750,8 +768,8 @@ func (protocol *PageProtocol) StopLoading() <-chan *page.StopLoadingResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -773,8 +791,8 @@ func (protocol *PageProtocol) StopScreencast() <-chan *page.StopScreencastResult
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -791,8 +809,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-domContentEve
 */
 func (protocol *PageProtocol) OnDOMContentEventFired(
 	callback func(event *page.DOMContentEventFiredEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newDOMContentEventFiredHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newDOMContentEventFiredHandler returns a Page.domContentEventFired event handler
+that has not been registered with the socket.
+*/
+func (protocol *PageProtocol) newDOMContentEventFiredHandler(
+	callback func(event *page.DOMContentEventFiredEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.domContentEventFired",
 		func(response *Response) {
 			event := &page.DOMContentEventFiredEvent{}
@@ -803,7 +833,36 @@ func (protocol *PageProtocol) OnDOMContentEventFired(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeDOMContentEventFired returns a subscription that delivers
+Page.domContentEventFired events on a typed channel until it is unsubscribed.
+See OnDOMContentEventFired().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-domContentEventFired
+*/
+func (protocol *PageProtocol) SubscribeDOMContentEventFired(
+	options *SubscriptionOptions,
+) *Subscription[*page.DOMContentEventFiredEvent] {
+	sub := NewSubscription[*page.DOMContentEventFiredEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newDOMContentEventFiredHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForDOMContentEventFired blocks until a Page.domContentEventFired event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-domContentEventFired
+*/
+func (protocol *PageProtocol) WaitForDOMContentEventFired(
+	ctx context.Context,
+	predicate func(event *page.DOMContentEventFiredEvent) bool,
+) (*page.DOMContentEventFiredEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeDOMContentEventFired(nil), predicate)
 }
 
 /*
@@ -814,8 +873,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameAttached
 */
 func (protocol *PageProtocol) OnFrameAttached(
 	callback func(event *page.FrameAttachedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newFrameAttachedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newFrameAttachedHandler returns a Page.frameAttached event handler that has not
+been registered with the socket.
+*/
+func (protocol *PageProtocol) newFrameAttachedHandler(
+	callback func(event *page.FrameAttachedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.frameAttached",
 		func(response *Response) {
 			event := &page.FrameAttachedEvent{}
@@ -826,7 +897,35 @@ func (protocol *PageProtocol) OnFrameAttached(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeFrameAttached returns a subscription that delivers Page.frameAttached
+events on a typed channel until it is unsubscribed. See OnFrameAttached().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameAttached
+*/
+func (protocol *PageProtocol) SubscribeFrameAttached(
+	options *SubscriptionOptions,
+) *Subscription[*page.FrameAttachedEvent] {
+	sub := NewSubscription[*page.FrameAttachedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newFrameAttachedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForFrameAttached blocks until a Page.frameAttached event that satisfies the
+predicate is received and returns it. A nil predicate matches the first event.
+See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameAttached
+*/
+func (protocol *PageProtocol) WaitForFrameAttached(
+	ctx context.Context,
+	predicate func(event *page.FrameAttachedEvent) bool,
+) (*page.FrameAttachedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeFrameAttached(nil), predicate)
 }
 
 /*
@@ -839,8 +938,21 @@ EXPERIMENTAL.
 */
 func (protocol *PageProtocol) OnFrameClearedScheduledNavigation(
 	callback func(event *page.FrameClearedScheduledNavigationEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newFrameClearedScheduledNavigationHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newFrameClearedScheduledNavigationHandler returns a
+Page.frameClearedScheduledNavigation event handler that has not been registered
+with the socket.
+*/
+func (protocol *PageProtocol) newFrameClearedScheduledNavigationHandler(
+	callback func(event *page.FrameClearedScheduledNavigationEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.frameClearedScheduledNavigation",
 		func(response *Response) {
 			event := &page.FrameClearedScheduledNavigationEvent{}
@@ -851,7 +963,38 @@ func (protocol *PageProtocol) OnFrameClearedScheduledNavigation(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeFrameClearedScheduledNavigation returns a subscription that delivers
+Page.frameClearedScheduledNavigation events on a typed channel until it is
+unsubscribed. See OnFrameClearedScheduledNavigation().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameClearedScheduledNavigation
+EXPERIMENTAL.
+*/
+func (protocol *PageProtocol) SubscribeFrameClearedScheduledNavigation(
+	options *SubscriptionOptions,
+) *Subscription[*page.FrameClearedScheduledNavigationEvent] {
+	sub := NewSubscription[*page.FrameClearedScheduledNavigationEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newFrameClearedScheduledNavigationHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForFrameClearedScheduledNavigation blocks until a
+Page.frameClearedScheduledNavigation event that satisfies the predicate is
+received and returns it. A nil predicate matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameClearedScheduledNavigation
+EXPERIMENTAL.
+*/
+func (protocol *PageProtocol) WaitForFrameClearedScheduledNavigation(
+	ctx context.Context,
+	predicate func(event *page.FrameClearedScheduledNavigationEvent) bool,
+) (*page.FrameClearedScheduledNavigationEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeFrameClearedScheduledNavigation(nil), predicate)
 }
 
 /*
@@ -862,8 +1005,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameDetached
 */
 func (protocol *PageProtocol) OnFrameDetached(
 	callback func(event *page.FrameDetachedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newFrameDetachedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newFrameDetachedHandler returns a Page.frameDetached event handler that has not
+been registered with the socket.
+*/
+func (protocol *PageProtocol) newFrameDetachedHandler(
+	callback func(event *page.FrameDetachedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.frameDetached",
 		func(response *Response) {
 			event := &page.FrameDetachedEvent{}
@@ -874,7 +1029,35 @@ func (protocol *PageProtocol) OnFrameDetached(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeFrameDetached returns a subscription that delivers Page.frameDetached
+events on a typed channel until it is unsubscribed. See OnFrameDetached().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameDetached
+*/
+func (protocol *PageProtocol) SubscribeFrameDetached(
+	options *SubscriptionOptions,
+) *Subscription[*page.FrameDetachedEvent] {
+	sub := NewSubscription[*page.FrameDetachedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newFrameDetachedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForFrameDetached blocks until a Page.frameDetached event that satisfies the
+predicate is received and returns it. A nil predicate matches the first event.
+See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameDetached
+*/
+func (protocol *PageProtocol) WaitForFrameDetached(
+	ctx context.Context,
+	predicate func(event *page.FrameDetachedEvent) bool,
+) (*page.FrameDetachedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeFrameDetached(nil), predicate)
 }
 
 /*
@@ -886,8 +1069,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameNavigate
 */
 func (protocol *PageProtocol) OnFrameNavigated(
 	callback func(event *page.FrameNavigatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newFrameNavigatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newFrameNavigatedHandler returns a Page.frameNavigated event handler that has
+not been registered with the socket.
+*/
+func (protocol *PageProtocol) newFrameNavigatedHandler(
+	callback func(event *page.FrameNavigatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.frameNavigated",
 		func(response *Response) {
 			event := &page.FrameNavigatedEvent{}
@@ -898,7 +1093,35 @@ func (protocol *PageProtocol) OnFrameNavigated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeFrameNavigated returns a subscription that delivers Page.frameNavigated
+events on a typed channel until it is unsubscribed. See OnFrameNavigated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameNavigated
+*/
+func (protocol *PageProtocol) SubscribeFrameNavigated(
+	options *SubscriptionOptions,
+) *Subscription[*page.FrameNavigatedEvent] {
+	sub := NewSubscription[*page.FrameNavigatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newFrameNavigatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForFrameNavigated blocks until a Page.frameNavigated event that satisfies
+the predicate is received and returns it. A nil predicate matches the first
+event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameNavigated
+*/
+func (protocol *PageProtocol) WaitForFrameNavigated(
+	ctx context.Context,
+	predicate func(event *page.FrameNavigatedEvent) bool,
+) (*page.FrameNavigatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeFrameNavigated(nil), predicate)
 }
 
 /*
@@ -910,8 +1133,20 @@ EXPERIMENTAL.
 */
 func (protocol *PageProtocol) OnFrameResized(
 	callback func(event *page.FrameResizedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newFrameResizedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newFrameResizedHandler returns a Page.frameResized event handler that has not
+been registered with the socket.
+*/
+func (protocol *PageProtocol) newFrameResizedHandler(
+	callback func(event *page.FrameResizedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.frameResized",
 		func(response *Response) {
 			event := &page.FrameResizedEvent{}
@@ -922,7 +1157,37 @@ func (protocol *PageProtocol) OnFrameResized(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeFrameResized returns a subscription that delivers Page.frameResized
+events on a typed channel until it is unsubscribed. See OnFrameResized().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameResized
+EXPERIMENTAL.
+*/
+func (protocol *PageProtocol) SubscribeFrameResized(
+	options *SubscriptionOptions,
+) *Subscription[*page.FrameResizedEvent] {
+	sub := NewSubscription[*page.FrameResizedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newFrameResizedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForFrameResized blocks until a Page.frameResized event that satisfies the
+predicate is received and returns it. A nil predicate matches the first event.
+See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameResized
+EXPERIMENTAL.
+*/
+func (protocol *PageProtocol) WaitForFrameResized(
+	ctx context.Context,
+	predicate func(event *page.FrameResizedEvent) bool,
+) (*page.FrameResizedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeFrameResized(nil), predicate)
 }
 
 /*
@@ -935,8 +1200,20 @@ EXPERIMENTAL.
 */
 func (protocol *PageProtocol) OnFrameScheduledNavigation(
 	callback func(event *page.FrameScheduledNavigationEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newFrameScheduledNavigationHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newFrameScheduledNavigationHandler returns a Page.frameScheduledNavigation event
+handler that has not been registered with the socket.
+*/
+func (protocol *PageProtocol) newFrameScheduledNavigationHandler(
+	callback func(event *page.FrameScheduledNavigationEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.frameScheduledNavigation",
 		func(response *Response) {
 			event := &page.FrameScheduledNavigationEvent{}
@@ -947,7 +1224,38 @@ func (protocol *PageProtocol) OnFrameScheduledNavigation(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeFrameScheduledNavigation returns a subscription that delivers
+Page.frameScheduledNavigation events on a typed channel until it is
+unsubscribed. See OnFrameScheduledNavigation().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameScheduledNavigation
+EXPERIMENTAL.
+*/
+func (protocol *PageProtocol) SubscribeFrameScheduledNavigation(
+	options *SubscriptionOptions,
+) *Subscription[*page.FrameScheduledNavigationEvent] {
+	sub := NewSubscription[*page.FrameScheduledNavigationEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newFrameScheduledNavigationHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForFrameScheduledNavigation blocks until a Page.frameScheduledNavigation
+event that satisfies the predicate is received and returns it. A nil predicate
+matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameScheduledNavigation
+EXPERIMENTAL.
+*/
+func (protocol *PageProtocol) WaitForFrameScheduledNavigation(
+	ctx context.Context,
+	predicate func(event *page.FrameScheduledNavigationEvent) bool,
+) (*page.FrameScheduledNavigationEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeFrameScheduledNavigation(nil), predicate)
 }
 
 /*
@@ -959,8 +1267,20 @@ EXPERIMENTAL.
 */
 func (protocol *PageProtocol) OnFrameStartedLoading(
 	callback func(event *page.FrameStartedLoadingEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newFrameStartedLoadingHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newFrameStartedLoadingHandler returns a Page.frameStartedLoading event handler
+that has not been registered with the socket.
+*/
+func (protocol *PageProtocol) newFrameStartedLoadingHandler(
+	callback func(event *page.FrameStartedLoadingEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.frameStartedLoading",
 		func(response *Response) {
 			event := &page.FrameStartedLoadingEvent{}
@@ -971,7 +1291,38 @@ func (protocol *PageProtocol) OnFrameStartedLoading(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeFrameStartedLoading returns a subscription that delivers
+Page.frameStartedLoading events on a typed channel until it is unsubscribed. See
+OnFrameStartedLoading().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameStartedLoading
+EXPERIMENTAL.
+*/
+func (protocol *PageProtocol) SubscribeFrameStartedLoading(
+	options *SubscriptionOptions,
+) *Subscription[*page.FrameStartedLoadingEvent] {
+	sub := NewSubscription[*page.FrameStartedLoadingEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newFrameStartedLoadingHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForFrameStartedLoading blocks until a Page.frameStartedLoading event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameStartedLoading
+EXPERIMENTAL.
+*/
+func (protocol *PageProtocol) WaitForFrameStartedLoading(
+	ctx context.Context,
+	predicate func(event *page.FrameStartedLoadingEvent) bool,
+) (*page.FrameStartedLoadingEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeFrameStartedLoading(nil), predicate)
 }
 
 /*
@@ -983,8 +1334,20 @@ EXPERIMENTAL.
 */
 func (protocol *PageProtocol) OnFrameStoppedLoading(
 	callback func(event *page.FrameStoppedLoadingEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newFrameStoppedLoadingHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newFrameStoppedLoadingHandler returns a Page.frameStoppedLoading event handler
+that has not been registered with the socket.
+*/
+func (protocol *PageProtocol) newFrameStoppedLoadingHandler(
+	callback func(event *page.FrameStoppedLoadingEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.frameStoppedLoading",
 		func(response *Response) {
 			event := &page.FrameStoppedLoadingEvent{}
@@ -995,7 +1358,38 @@ func (protocol *PageProtocol) OnFrameStoppedLoading(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeFrameStoppedLoading returns a subscription that delivers
+Page.frameStoppedLoading events on a typed channel until it is unsubscribed. See
+OnFrameStoppedLoading().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameStoppedLoading
+EXPERIMENTAL.
+*/
+func (protocol *PageProtocol) SubscribeFrameStoppedLoading(
+	options *SubscriptionOptions,
+) *Subscription[*page.FrameStoppedLoadingEvent] {
+	sub := NewSubscription[*page.FrameStoppedLoadingEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newFrameStoppedLoadingHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForFrameStoppedLoading blocks until a Page.frameStoppedLoading event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-frameStoppedLoading
+EXPERIMENTAL.
+*/
+func (protocol *PageProtocol) WaitForFrameStoppedLoading(
+	ctx context.Context,
+	predicate func(event *page.FrameStoppedLoadingEvent) bool,
+) (*page.FrameStoppedLoadingEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeFrameStoppedLoading(nil), predicate)
 }
 
 /*
@@ -1006,8 +1400,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-interstitialH
 */
 func (protocol *PageProtocol) OnInterstitialHidden(
 	callback func(event *page.InterstitialHiddenEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newInterstitialHiddenHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newInterstitialHiddenHandler returns a Page.interstitialHidden event handler
+that has not been registered with the socket.
+*/
+func (protocol *PageProtocol) newInterstitialHiddenHandler(
+	callback func(event *page.InterstitialHiddenEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.interstitialHidden",
 		func(response *Response) {
 			event := &page.InterstitialHiddenEvent{}
@@ -1018,7 +1424,36 @@ func (protocol *PageProtocol) OnInterstitialHidden(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeInterstitialHidden returns a subscription that delivers
+Page.interstitialHidden events on a typed channel until it is unsubscribed. See
+OnInterstitialHidden().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-interstitialHidden
+*/
+func (protocol *PageProtocol) SubscribeInterstitialHidden(
+	options *SubscriptionOptions,
+) *Subscription[*page.InterstitialHiddenEvent] {
+	sub := NewSubscription[*page.InterstitialHiddenEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newInterstitialHiddenHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForInterstitialHidden blocks until a Page.interstitialHidden event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-interstitialHidden
+*/
+func (protocol *PageProtocol) WaitForInterstitialHidden(
+	ctx context.Context,
+	predicate func(event *page.InterstitialHiddenEvent) bool,
+) (*page.InterstitialHiddenEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeInterstitialHidden(nil), predicate)
 }
 
 /*
@@ -1029,8 +1464,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-interstitialS
 */
 func (protocol *PageProtocol) OnInterstitialShown(
 	callback func(event *page.InterstitialShownEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newInterstitialShownHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newInterstitialShownHandler returns a Page.interstitialShown event handler that
+has not been registered with the socket.
+*/
+func (protocol *PageProtocol) newInterstitialShownHandler(
+	callback func(event *page.InterstitialShownEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.interstitialShown",
 		func(response *Response) {
 			event := &page.InterstitialShownEvent{}
@@ -1041,7 +1488,36 @@ func (protocol *PageProtocol) OnInterstitialShown(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeInterstitialShown returns a subscription that delivers
+Page.interstitialShown events on a typed channel until it is unsubscribed. See
+OnInterstitialShown().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-interstitialShown
+*/
+func (protocol *PageProtocol) SubscribeInterstitialShown(
+	options *SubscriptionOptions,
+) *Subscription[*page.InterstitialShownEvent] {
+	sub := NewSubscription[*page.InterstitialShownEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newInterstitialShownHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForInterstitialShown blocks until a Page.interstitialShown event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-interstitialShown
+*/
+func (protocol *PageProtocol) WaitForInterstitialShown(
+	ctx context.Context,
+	predicate func(event *page.InterstitialShownEvent) bool,
+) (*page.InterstitialShownEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeInterstitialShown(nil), predicate)
 }
 
 /*
@@ -1053,8 +1529,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-javascriptDia
 */
 func (protocol *PageProtocol) OnJavascriptDialogClosed(
 	callback func(event *page.JavascriptDialogClosedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newJavascriptDialogClosedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newJavascriptDialogClosedHandler returns a Page.javascriptDialogClosed event
+handler that has not been registered with the socket.
+*/
+func (protocol *PageProtocol) newJavascriptDialogClosedHandler(
+	callback func(event *page.JavascriptDialogClosedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.javascriptDialogClosed",
 		func(response *Response) {
 			event := &page.JavascriptDialogClosedEvent{}
@@ -1065,7 +1553,36 @@ func (protocol *PageProtocol) OnJavascriptDialogClosed(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeJavascriptDialogClosed returns a subscription that delivers
+Page.javascriptDialogClosed events on a typed channel until it is unsubscribed.
+See OnJavascriptDialogClosed().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-javascriptDialogClosed
+*/
+func (protocol *PageProtocol) SubscribeJavascriptDialogClosed(
+	options *SubscriptionOptions,
+) *Subscription[*page.JavascriptDialogClosedEvent] {
+	sub := NewSubscription[*page.JavascriptDialogClosedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newJavascriptDialogClosedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForJavascriptDialogClosed blocks until a Page.javascriptDialogClosed event
+that satisfies the predicate is received and returns it. A nil predicate matches
+the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-javascriptDialogClosed
+*/
+func (protocol *PageProtocol) WaitForJavascriptDialogClosed(
+	ctx context.Context,
+	predicate func(event *page.JavascriptDialogClosedEvent) bool,
+) (*page.JavascriptDialogClosedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeJavascriptDialogClosed(nil), predicate)
 }
 
 /*
@@ -1077,8 +1594,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-javascriptDia
 */
 func (protocol *PageProtocol) OnJavascriptDialogOpening(
 	callback func(event *page.JavascriptDialogOpeningEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newJavascriptDialogOpeningHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newJavascriptDialogOpeningHandler returns a Page.javascriptDialogOpening event
+handler that has not been registered with the socket.
+*/
+func (protocol *PageProtocol) newJavascriptDialogOpeningHandler(
+	callback func(event *page.JavascriptDialogOpeningEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.javascriptDialogOpening",
 		func(response *Response) {
 			event := &page.JavascriptDialogOpeningEvent{}
@@ -1089,7 +1618,36 @@ func (protocol *PageProtocol) OnJavascriptDialogOpening(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeJavascriptDialogOpening returns a subscription that delivers
+Page.javascriptDialogOpening events on a typed channel until it is unsubscribed.
+See OnJavascriptDialogOpening().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-javascriptDialogOpening
+*/
+func (protocol *PageProtocol) SubscribeJavascriptDialogOpening(
+	options *SubscriptionOptions,
+) *Subscription[*page.JavascriptDialogOpeningEvent] {
+	sub := NewSubscription[*page.JavascriptDialogOpeningEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newJavascriptDialogOpeningHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForJavascriptDialogOpening blocks until a Page.javascriptDialogOpening event
+that satisfies the predicate is received and returns it. A nil predicate matches
+the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-javascriptDialogOpening
+*/
+func (protocol *PageProtocol) WaitForJavascriptDialogOpening(
+	ctx context.Context,
+	predicate func(event *page.JavascriptDialogOpeningEvent) bool,
+) (*page.JavascriptDialogOpeningEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeJavascriptDialogOpening(nil), predicate)
 }
 
 /*
@@ -1100,8 +1658,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-lifecycleEven
 */
 func (protocol *PageProtocol) OnLifecycleEvent(
 	callback func(event *page.LifecycleEventEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newLifecycleEventHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newLifecycleEventHandler returns a Page.lifecycleEvent event handler that has
+not been registered with the socket.
+*/
+func (protocol *PageProtocol) newLifecycleEventHandler(
+	callback func(event *page.LifecycleEventEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.lifecycleEvent",
 		func(response *Response) {
 			event := &page.LifecycleEventEvent{}
@@ -1112,7 +1682,35 @@ func (protocol *PageProtocol) OnLifecycleEvent(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeLifecycleEvent returns a subscription that delivers Page.lifecycleEvent
+events on a typed channel until it is unsubscribed. See OnLifecycleEvent().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-lifecycleEvent
+*/
+func (protocol *PageProtocol) SubscribeLifecycleEvent(
+	options *SubscriptionOptions,
+) *Subscription[*page.LifecycleEventEvent] {
+	sub := NewSubscription[*page.LifecycleEventEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newLifecycleEventHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForLifecycleEvent blocks until a Page.lifecycleEvent event that satisfies
+the predicate is received and returns it. A nil predicate matches the first
+event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-lifecycleEvent
+*/
+func (protocol *PageProtocol) WaitForLifecycleEvent(
+	ctx context.Context,
+	predicate func(event *page.LifecycleEventEvent) bool,
+) (*page.LifecycleEventEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeLifecycleEvent(nil), predicate)
 }
 
 /*
@@ -1123,8 +1721,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-loadEventFire
 */
 func (protocol *PageProtocol) OnLoadEventFired(
 	callback func(event *page.LoadEventFiredEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newLoadEventFiredHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newLoadEventFiredHandler returns a Page.loadEventFired event handler that has
+not been registered with the socket.
+*/
+func (protocol *PageProtocol) newLoadEventFiredHandler(
+	callback func(event *page.LoadEventFiredEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.loadEventFired",
 		func(response *Response) {
 			event := &page.LoadEventFiredEvent{}
@@ -1135,7 +1745,35 @@ func (protocol *PageProtocol) OnLoadEventFired(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeLoadEventFired returns a subscription that delivers Page.loadEventFired
+events on a typed channel until it is unsubscribed. See OnLoadEventFired().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-loadEventFired
+*/
+func (protocol *PageProtocol) SubscribeLoadEventFired(
+	options *SubscriptionOptions,
+) *Subscription[*page.LoadEventFiredEvent] {
+	sub := NewSubscription[*page.LoadEventFiredEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newLoadEventFiredHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForLoadEventFired blocks until a Page.loadEventFired event that satisfies
+the predicate is received and returns it. A nil predicate matches the first
+event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-loadEventFired
+*/
+func (protocol *PageProtocol) WaitForLoadEventFired(
+	ctx context.Context,
+	predicate func(event *page.LoadEventFiredEvent) bool,
+) (*page.LoadEventFiredEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeLoadEventFired(nil), predicate)
 }
 
 /*
@@ -1147,8 +1785,20 @@ EXPERIMENTAL.
 */
 func (protocol *PageProtocol) OnScreencastFrame(
 	callback func(event *page.ScreencastFrameEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newScreencastFrameHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newScreencastFrameHandler returns a Page.screencastFrame event handler that has
+not been registered with the socket.
+*/
+func (protocol *PageProtocol) newScreencastFrameHandler(
+	callback func(event *page.ScreencastFrameEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.screencastFrame",
 		func(response *Response) {
 			event := &page.ScreencastFrameEvent{}
@@ -1159,7 +1809,38 @@ func (protocol *PageProtocol) OnScreencastFrame(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeScreencastFrame returns a subscription that delivers
+Page.screencastFrame events on a typed channel until it is unsubscribed. See
+OnScreencastFrame().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-screencastFrame
+EXPERIMENTAL.
+*/
+func (protocol *PageProtocol) SubscribeScreencastFrame(
+	options *SubscriptionOptions,
+) *Subscription[*page.ScreencastFrameEvent] {
+	sub := NewSubscription[*page.ScreencastFrameEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newScreencastFrameHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForScreencastFrame blocks until a Page.screencastFrame event that satisfies
+the predicate is received and returns it. A nil predicate matches the first
+event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-screencastFrame
+EXPERIMENTAL.
+*/
+func (protocol *PageProtocol) WaitForScreencastFrame(
+	ctx context.Context,
+	predicate func(event *page.ScreencastFrameEvent) bool,
+) (*page.ScreencastFrameEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeScreencastFrame(nil), predicate)
 }
 
 /*
@@ -1172,8 +1853,20 @@ EXPERIMENTAL.
 */
 func (protocol *PageProtocol) OnScreencastVisibilityChanged(
 	callback func(event *page.ScreencastVisibilityChangedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newScreencastVisibilityChangedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newScreencastVisibilityChangedHandler returns a Page.screencastVisibilityChanged
+event handler that has not been registered with the socket.
+*/
+func (protocol *PageProtocol) newScreencastVisibilityChangedHandler(
+	callback func(event *page.ScreencastVisibilityChangedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.screencastVisibilityChanged",
 		func(response *Response) {
 			event := &page.ScreencastVisibilityChangedEvent{}
@@ -1184,7 +1877,38 @@ func (protocol *PageProtocol) OnScreencastVisibilityChanged(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeScreencastVisibilityChanged returns a subscription that delivers
+Page.screencastVisibilityChanged events on a typed channel until it is
+unsubscribed. See OnScreencastVisibilityChanged().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-screencastVisibilityChanged
+EXPERIMENTAL.
+*/
+func (protocol *PageProtocol) SubscribeScreencastVisibilityChanged(
+	options *SubscriptionOptions,
+) *Subscription[*page.ScreencastVisibilityChangedEvent] {
+	sub := NewSubscription[*page.ScreencastVisibilityChangedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newScreencastVisibilityChangedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForScreencastVisibilityChanged blocks until a
+Page.screencastVisibilityChanged event that satisfies the predicate is received
+and returns it. A nil predicate matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-screencastVisibilityChanged
+EXPERIMENTAL.
+*/
+func (protocol *PageProtocol) WaitForScreencastVisibilityChanged(
+	ctx context.Context,
+	predicate func(event *page.ScreencastVisibilityChangedEvent) bool,
+) (*page.ScreencastVisibilityChangedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeScreencastVisibilityChanged(nil), predicate)
 }
 
 /*
@@ -1196,8 +1920,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-windowOpen
 */
 func (protocol *PageProtocol) OnWindowOpen(
 	callback func(event *page.WindowOpenEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newWindowOpenHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newWindowOpenHandler returns a Page.windowOpen event handler that has not been
+registered with the socket.
+*/
+func (protocol *PageProtocol) newWindowOpenHandler(
+	callback func(event *page.WindowOpenEvent),
+) *Handler {
+	return NewEventHandler(
 		"Page.windowOpen",
 		func(response *Response) {
 			event := &page.WindowOpenEvent{}
@@ -1208,5 +1944,33 @@ func (protocol *PageProtocol) OnWindowOpen(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeWindowOpen returns a subscription that delivers Page.windowOpen events
+on a typed channel until it is unsubscribed. See OnWindowOpen().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-windowOpen
+*/
+func (protocol *PageProtocol) SubscribeWindowOpen(
+	options *SubscriptionOptions,
+) *Subscription[*page.WindowOpenEvent] {
+	sub := NewSubscription[*page.WindowOpenEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newWindowOpenHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForWindowOpen blocks until a Page.windowOpen event that satisfies the
+predicate is received and returns it. A nil predicate matches the first event.
+See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-windowOpen
+*/
+func (protocol *PageProtocol) WaitForWindowOpen(
+	ctx context.Context,
+	predicate func(event *page.WindowOpenEvent) bool,
+) (*page.WindowOpenEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeWindowOpen(nil), predicate)
 }
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/performance"
 )
@@ -16,6 +18,22 @@ type PerformanceProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *PerformanceProtocol) WithContext(ctx context.Context) *PerformanceProtocol {
+	return &PerformanceProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *PerformanceProtocol) WithTimeout(timeout time.Duration) *PerformanceProtocol {
+	return &PerformanceProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 Disable disables collecting and reporting metrics.
 
@@ -28,8 +46,8 @@ func (protocol *PerformanceProtocol) Disable() <-chan *performance.DisableResult
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -50,8 +68,8 @@ func (protocol *PerformanceProtocol) Enable() <-chan *performance.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -72,8 +90,8 @@ func (protocol *PerformanceProtocol) GetMetrics() <-chan *performance.GetMetrics
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -92,8 +110,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Performance/#event-metric
 */
 func (protocol *PerformanceProtocol) OnMetrics(
 	callback func(event *performance.MetricsEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newMetricsHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newMetricsHandler returns a Performance.metrics event handler that has not been
+registered with the socket.
+*/
+func (protocol *PerformanceProtocol) newMetricsHandler(
+	callback func(event *performance.MetricsEvent),
+) *Handler {
+	return NewEventHandler(
 		"Performance.metrics",
 		func(response *Response) {
 			event := &performance.MetricsEvent{}
@@ -104,5 +134,33 @@ func (protocol *PerformanceProtocol) OnMetrics(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeMetrics returns a subscription that delivers Performance.metrics events
+on a typed channel until it is unsubscribed. See OnMetrics().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Performance/#event-metrics
+*/
+func (protocol *PerformanceProtocol) SubscribeMetrics(
+	options *SubscriptionOptions,
+) *Subscription[*performance.MetricsEvent] {
+	sub := NewSubscription[*performance.MetricsEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newMetricsHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForMetrics blocks until a Performance.metrics event that satisfies the
+predicate is received and returns it. A nil predicate matches the first event.
+See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Performance/#event-metrics
+*/
+func (protocol *PerformanceProtocol) WaitForMetrics(
+	ctx context.Context,
+	predicate func(event *performance.MetricsEvent) bool,
+) (*performance.MetricsEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeMetrics(nil), predicate)
 }
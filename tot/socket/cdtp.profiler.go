@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/profiler"
 )
@@ -15,6 +17,22 @@ type ProfilerProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *ProfilerProtocol) WithContext(ctx context.Context) *ProfilerProtocol {
+	return &ProfilerProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *ProfilerProtocol) WithTimeout(timeout time.Duration) *ProfilerProtocol {
+	return &ProfilerProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 Disable disables profiling.
 
@@ -27,8 +45,8 @@ func (protocol *ProfilerProtocol) Disable() <-chan *profiler.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -49,8 +67,8 @@ func (protocol *ProfilerProtocol) Enable() <-chan *profiler.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -72,8 +90,8 @@ func (protocol *ProfilerProtocol) GetBestEffortCoverage() <-chan *profiler.GetBe
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -99,8 +117,8 @@ func (protocol *ProfilerProtocol) SetSamplingInterval(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -121,8 +139,8 @@ func (protocol *ProfilerProtocol) Start() <-chan *profiler.StartResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -147,8 +165,8 @@ func (protocol *ProfilerProtocol) StartPreciseCoverage(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -170,8 +188,8 @@ func (protocol *ProfilerProtocol) StartTypeProfile() <-chan *profiler.StartTypeP
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -192,8 +210,8 @@ func (protocol *ProfilerProtocol) Stop() <-chan *profiler.StopResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -217,8 +235,8 @@ func (protocol *ProfilerProtocol) StopPreciseCoverage() <-chan *profiler.StopPre
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -241,8 +259,8 @@ func (protocol *ProfilerProtocol) StopTypeProfile() <-chan *profiler.StopTypePro
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -264,8 +282,8 @@ func (protocol *ProfilerProtocol) TakePreciseCoverage() <-chan *profiler.TakePre
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -289,8 +307,8 @@ func (protocol *ProfilerProtocol) TakeTypeProfile() <-chan *profiler.TakeTypePro
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -309,8 +327,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Profiler/#event-consolePr
 */
 func (protocol *ProfilerProtocol) OnConsoleProfileFinished(
 	callback func(event *profiler.ConsoleProfileFinishedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newConsoleProfileFinishedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newConsoleProfileFinishedHandler returns a Profiler.consoleProfileFinished event
+handler that has not been registered with the socket.
+*/
+func (protocol *ProfilerProtocol) newConsoleProfileFinishedHandler(
+	callback func(event *profiler.ConsoleProfileFinishedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Profiler.consoleProfileFinished",
 		func(response *Response) {
 			event := &profiler.ConsoleProfileFinishedEvent{}
@@ -321,7 +351,36 @@ func (protocol *ProfilerProtocol) OnConsoleProfileFinished(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeConsoleProfileFinished returns a subscription that delivers
+Profiler.consoleProfileFinished events on a typed channel until it is
+unsubscribed. See OnConsoleProfileFinished().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Profiler/#event-consoleProfileFinished
+*/
+func (protocol *ProfilerProtocol) SubscribeConsoleProfileFinished(
+	options *SubscriptionOptions,
+) *Subscription[*profiler.ConsoleProfileFinishedEvent] {
+	sub := NewSubscription[*profiler.ConsoleProfileFinishedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newConsoleProfileFinishedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForConsoleProfileFinished blocks until a Profiler.consoleProfileFinished
+event that satisfies the predicate is received and returns it. A nil predicate
+matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Profiler/#event-consoleProfileFinished
+*/
+func (protocol *ProfilerProtocol) WaitForConsoleProfileFinished(
+	ctx context.Context,
+	predicate func(event *profiler.ConsoleProfileFinishedEvent) bool,
+) (*profiler.ConsoleProfileFinishedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeConsoleProfileFinished(nil), predicate)
 }
 
 /*
@@ -333,8 +392,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Profiler/#event-consolePr
 */
 func (protocol *ProfilerProtocol) OnConsoleProfileStarted(
 	callback func(event *profiler.ConsoleProfileStartedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newConsoleProfileStartedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newConsoleProfileStartedHandler returns a Profiler.consoleProfileStarted event
+handler that has not been registered with the socket.
+*/
+func (protocol *ProfilerProtocol) newConsoleProfileStartedHandler(
+	callback func(event *profiler.ConsoleProfileStartedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Profiler.consoleProfileStarted",
 		func(response *Response) {
 			event := &profiler.ConsoleProfileStartedEvent{}
@@ -345,5 +416,34 @@ func (protocol *ProfilerProtocol) OnConsoleProfileStarted(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeConsoleProfileStarted returns a subscription that delivers
+Profiler.consoleProfileStarted events on a typed channel until it is
+unsubscribed. See OnConsoleProfileStarted().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Profiler/#event-consoleProfileStarted
+*/
+func (protocol *ProfilerProtocol) SubscribeConsoleProfileStarted(
+	options *SubscriptionOptions,
+) *Subscription[*profiler.ConsoleProfileStartedEvent] {
+	sub := NewSubscription[*profiler.ConsoleProfileStartedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newConsoleProfileStartedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForConsoleProfileStarted blocks until a Profiler.consoleProfileStarted event
+that satisfies the predicate is received and returns it. A nil predicate matches
+the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Profiler/#event-consoleProfileStarted
+*/
+func (protocol *ProfilerProtocol) WaitForConsoleProfileStarted(
+	ctx context.Context,
+	predicate func(event *profiler.ConsoleProfileStartedEvent) bool,
+) (*profiler.ConsoleProfileStartedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeConsoleProfileStarted(nil), predicate)
 }
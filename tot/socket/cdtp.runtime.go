@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/runtime"
 )
@@ -21,6 +23,22 @@ type RuntimeProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *RuntimeProtocol) WithContext(ctx context.Context) *RuntimeProtocol {
+	return &RuntimeProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *RuntimeProtocol) WithTimeout(timeout time.Duration) *RuntimeProtocol {
+	return &RuntimeProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 AwaitPromise adds handler to promise with given promise object ID.
 
@@ -35,8 +53,8 @@ func (protocol *RuntimeProtocol) AwaitPromise(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -62,8 +80,8 @@ func (protocol *RuntimeProtocol) CallFunctionOn(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -88,8 +106,8 @@ func (protocol *RuntimeProtocol) CompileScript(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -112,8 +130,8 @@ func (protocol *RuntimeProtocol) Disable() <-chan *runtime.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -134,8 +152,8 @@ func (protocol *RuntimeProtocol) DiscardConsoleEntries() <-chan *runtime.Discard
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -158,8 +176,8 @@ func (protocol *RuntimeProtocol) Enable() <-chan *runtime.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -182,8 +200,8 @@ func (protocol *RuntimeProtocol) Evaluate(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -209,8 +227,8 @@ func (protocol *RuntimeProtocol) GetProperties(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -236,8 +254,8 @@ func (protocol *RuntimeProtocol) GlobalLexicalScopeNames(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -262,8 +280,8 @@ func (protocol *RuntimeProtocol) QueryObjects(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -288,8 +306,8 @@ func (protocol *RuntimeProtocol) ReleaseObject(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -312,8 +330,8 @@ func (protocol *RuntimeProtocol) ReleaseObjectGroup(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -335,8 +353,8 @@ func (protocol *RuntimeProtocol) RunIfWaitingForDebugger() <-chan *runtime.RunIf
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -359,8 +377,8 @@ func (protocol *RuntimeProtocol) RunScript(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -386,8 +404,8 @@ func (protocol *RuntimeProtocol) SetCustomObjectFormatterEnabled(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -404,8 +422,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-consoleAPI
 */
 func (protocol *RuntimeProtocol) OnConsoleAPICalled(
 	callback func(event *runtime.ConsoleAPICalledEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newConsoleAPICalledHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newConsoleAPICalledHandler returns a Runtime.consoleAPICalled event handler that
+has not been registered with the socket.
+*/
+func (protocol *RuntimeProtocol) newConsoleAPICalledHandler(
+	callback func(event *runtime.ConsoleAPICalledEvent),
+) *Handler {
+	return NewEventHandler(
 		"Runtime.consoleAPICalled",
 		func(response *Response) {
 			event := &runtime.ConsoleAPICalledEvent{}
@@ -416,7 +446,36 @@ func (protocol *RuntimeProtocol) OnConsoleAPICalled(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeConsoleAPICalled returns a subscription that delivers
+Runtime.consoleAPICalled events on a typed channel until it is unsubscribed. See
+OnConsoleAPICalled().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-consoleAPICalled
+*/
+func (protocol *RuntimeProtocol) SubscribeConsoleAPICalled(
+	options *SubscriptionOptions,
+) *Subscription[*runtime.ConsoleAPICalledEvent] {
+	sub := NewSubscription[*runtime.ConsoleAPICalledEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newConsoleAPICalledHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForConsoleAPICalled blocks until a Runtime.consoleAPICalled event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-consoleAPICalled
+*/
+func (protocol *RuntimeProtocol) WaitForConsoleAPICalled(
+	ctx context.Context,
+	predicate func(event *runtime.ConsoleAPICalledEvent) bool,
+) (*runtime.ConsoleAPICalledEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeConsoleAPICalled(nil), predicate)
 }
 
 /*
@@ -427,8 +486,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-exceptionR
 */
 func (protocol *RuntimeProtocol) OnExceptionRevoked(
 	callback func(event *runtime.ExceptionRevokedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newExceptionRevokedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newExceptionRevokedHandler returns a Runtime.exceptionRevoked event handler that
+has not been registered with the socket.
+*/
+func (protocol *RuntimeProtocol) newExceptionRevokedHandler(
+	callback func(event *runtime.ExceptionRevokedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Runtime.exceptionRevoked",
 		func(response *Response) {
 			event := &runtime.ExceptionRevokedEvent{}
@@ -439,7 +510,36 @@ func (protocol *RuntimeProtocol) OnExceptionRevoked(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeExceptionRevoked returns a subscription that delivers
+Runtime.exceptionRevoked events on a typed channel until it is unsubscribed. See
+OnExceptionRevoked().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-exceptionRevoked
+*/
+func (protocol *RuntimeProtocol) SubscribeExceptionRevoked(
+	options *SubscriptionOptions,
+) *Subscription[*runtime.ExceptionRevokedEvent] {
+	sub := NewSubscription[*runtime.ExceptionRevokedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newExceptionRevokedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForExceptionRevoked blocks until a Runtime.exceptionRevoked event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-exceptionRevoked
+*/
+func (protocol *RuntimeProtocol) WaitForExceptionRevoked(
+	ctx context.Context,
+	predicate func(event *runtime.ExceptionRevokedEvent) bool,
+) (*runtime.ExceptionRevokedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeExceptionRevoked(nil), predicate)
 }
 
 /*
@@ -450,8 +550,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-exceptionT
 */
 func (protocol *RuntimeProtocol) OnExceptionThrown(
 	callback func(event *runtime.ExceptionThrownEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newExceptionThrownHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newExceptionThrownHandler returns a Runtime.exceptionThrown event handler that
+has not been registered with the socket.
+*/
+func (protocol *RuntimeProtocol) newExceptionThrownHandler(
+	callback func(event *runtime.ExceptionThrownEvent),
+) *Handler {
+	return NewEventHandler(
 		"Runtime.exceptionThrown",
 		func(response *Response) {
 			event := &runtime.ExceptionThrownEvent{}
@@ -462,7 +574,36 @@ func (protocol *RuntimeProtocol) OnExceptionThrown(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeExceptionThrown returns a subscription that delivers
+Runtime.exceptionThrown events on a typed channel until it is unsubscribed. See
+OnExceptionThrown().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-exceptionThrown
+*/
+func (protocol *RuntimeProtocol) SubscribeExceptionThrown(
+	options *SubscriptionOptions,
+) *Subscription[*runtime.ExceptionThrownEvent] {
+	sub := NewSubscription[*runtime.ExceptionThrownEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newExceptionThrownHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForExceptionThrown blocks until a Runtime.exceptionThrown event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-exceptionThrown
+*/
+func (protocol *RuntimeProtocol) WaitForExceptionThrown(
+	ctx context.Context,
+	predicate func(event *runtime.ExceptionThrownEvent) bool,
+) (*runtime.ExceptionThrownEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeExceptionThrown(nil), predicate)
 }
 
 /*
@@ -474,8 +615,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-executionC
 */
 func (protocol *RuntimeProtocol) OnExecutionContextCreated(
 	callback func(event *runtime.ExecutionContextCreatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newExecutionContextCreatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newExecutionContextCreatedHandler returns a Runtime.executionContextCreated
+event handler that has not been registered with the socket.
+*/
+func (protocol *RuntimeProtocol) newExecutionContextCreatedHandler(
+	callback func(event *runtime.ExecutionContextCreatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Runtime.executionContextCreated",
 		func(response *Response) {
 			event := &runtime.ExecutionContextCreatedEvent{}
@@ -486,7 +639,36 @@ func (protocol *RuntimeProtocol) OnExecutionContextCreated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeExecutionContextCreated returns a subscription that delivers
+Runtime.executionContextCreated events on a typed channel until it is
+unsubscribed. See OnExecutionContextCreated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-executionContextCreated
+*/
+func (protocol *RuntimeProtocol) SubscribeExecutionContextCreated(
+	options *SubscriptionOptions,
+) *Subscription[*runtime.ExecutionContextCreatedEvent] {
+	sub := NewSubscription[*runtime.ExecutionContextCreatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newExecutionContextCreatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForExecutionContextCreated blocks until a Runtime.executionContextCreated
+event that satisfies the predicate is received and returns it. A nil predicate
+matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-executionContextCreated
+*/
+func (protocol *RuntimeProtocol) WaitForExecutionContextCreated(
+	ctx context.Context,
+	predicate func(event *runtime.ExecutionContextCreatedEvent) bool,
+) (*runtime.ExecutionContextCreatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeExecutionContextCreated(nil), predicate)
 }
 
 /*
@@ -498,8 +680,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-executionC
 */
 func (protocol *RuntimeProtocol) OnExecutionContextDestroyed(
 	callback func(event *runtime.ExecutionContextDestroyedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newExecutionContextDestroyedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newExecutionContextDestroyedHandler returns a Runtime.executionContextDestroyed
+event handler that has not been registered with the socket.
+*/
+func (protocol *RuntimeProtocol) newExecutionContextDestroyedHandler(
+	callback func(event *runtime.ExecutionContextDestroyedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Runtime.executionContextDestroyed",
 		func(response *Response) {
 			event := &runtime.ExecutionContextDestroyedEvent{}
@@ -510,7 +704,36 @@ func (protocol *RuntimeProtocol) OnExecutionContextDestroyed(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeExecutionContextDestroyed returns a subscription that delivers
+Runtime.executionContextDestroyed events on a typed channel until it is
+unsubscribed. See OnExecutionContextDestroyed().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-executionContextDestroyed
+*/
+func (protocol *RuntimeProtocol) SubscribeExecutionContextDestroyed(
+	options *SubscriptionOptions,
+) *Subscription[*runtime.ExecutionContextDestroyedEvent] {
+	sub := NewSubscription[*runtime.ExecutionContextDestroyedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newExecutionContextDestroyedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForExecutionContextDestroyed blocks until a
+Runtime.executionContextDestroyed event that satisfies the predicate is received
+and returns it. A nil predicate matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-executionContextDestroyed
+*/
+func (protocol *RuntimeProtocol) WaitForExecutionContextDestroyed(
+	ctx context.Context,
+	predicate func(event *runtime.ExecutionContextDestroyedEvent) bool,
+) (*runtime.ExecutionContextDestroyedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeExecutionContextDestroyed(nil), predicate)
 }
 
 /*
@@ -522,8 +745,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-executionC
 */
 func (protocol *RuntimeProtocol) OnExecutionContextsCleared(
 	callback func(event *runtime.ExecutionContextsClearedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newExecutionContextsClearedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newExecutionContextsClearedHandler returns a Runtime.executionContextsCleared
+event handler that has not been registered with the socket.
+*/
+func (protocol *RuntimeProtocol) newExecutionContextsClearedHandler(
+	callback func(event *runtime.ExecutionContextsClearedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Runtime.executionContextsCleared",
 		func(response *Response) {
 			event := &runtime.ExecutionContextsClearedEvent{}
@@ -534,7 +769,36 @@ func (protocol *RuntimeProtocol) OnExecutionContextsCleared(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeExecutionContextsCleared returns a subscription that delivers
+Runtime.executionContextsCleared events on a typed channel until it is
+unsubscribed. See OnExecutionContextsCleared().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-executionContextsCleared
+*/
+func (protocol *RuntimeProtocol) SubscribeExecutionContextsCleared(
+	options *SubscriptionOptions,
+) *Subscription[*runtime.ExecutionContextsClearedEvent] {
+	sub := NewSubscription[*runtime.ExecutionContextsClearedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newExecutionContextsClearedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForExecutionContextsCleared blocks until a Runtime.executionContextsCleared
+event that satisfies the predicate is received and returns it. A nil predicate
+matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-executionContextsCleared
+*/
+func (protocol *RuntimeProtocol) WaitForExecutionContextsCleared(
+	ctx context.Context,
+	predicate func(event *runtime.ExecutionContextsClearedEvent) bool,
+) (*runtime.ExecutionContextsClearedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeExecutionContextsCleared(nil), predicate)
 }
 
 /*
@@ -546,8 +810,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-inspectReq
 */
 func (protocol *RuntimeProtocol) OnInspectRequested(
 	callback func(event *runtime.InspectRequestedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newInspectRequestedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newInspectRequestedHandler returns a Runtime.inspectRequested event handler that
+has not been registered with the socket.
+*/
+func (protocol *RuntimeProtocol) newInspectRequestedHandler(
+	callback func(event *runtime.InspectRequestedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Runtime.inspectRequested",
 		func(response *Response) {
 			event := &runtime.InspectRequestedEvent{}
@@ -558,5 +834,34 @@ func (protocol *RuntimeProtocol) OnInspectRequested(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeInspectRequested returns a subscription that delivers
+Runtime.inspectRequested events on a typed channel until it is unsubscribed. See
+OnInspectRequested().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-inspectRequested
+*/
+func (protocol *RuntimeProtocol) SubscribeInspectRequested(
+	options *SubscriptionOptions,
+) *Subscription[*runtime.InspectRequestedEvent] {
+	sub := NewSubscription[*runtime.InspectRequestedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newInspectRequestedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForInspectRequested blocks until a Runtime.inspectRequested event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#event-inspectRequested
+*/
+func (protocol *RuntimeProtocol) WaitForInspectRequested(
+	ctx context.Context,
+	predicate func(event *runtime.InspectRequestedEvent) bool,
+) (*runtime.InspectRequestedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeInspectRequested(nil), predicate)
 }
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/schema"
 )
@@ -16,6 +18,22 @@ type SchemaProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *SchemaProtocol) WithContext(ctx context.Context) *SchemaProtocol {
+	return &SchemaProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *SchemaProtocol) WithTimeout(timeout time.Duration) *SchemaProtocol {
+	return &SchemaProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 GetDomains returns supported domains.
 
@@ -28,8 +46,8 @@ func (protocol *SchemaProtocol) GetDomains() <-chan *schema.GetDomainsResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
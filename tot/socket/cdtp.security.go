@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/security"
 )
@@ -15,6 +17,22 @@ type SecurityProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *SecurityProtocol) WithContext(ctx context.Context) *SecurityProtocol {
+	return &SecurityProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *SecurityProtocol) WithTimeout(timeout time.Duration) *SecurityProtocol {
+	return &SecurityProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 Disable disables tracking security state changes.
 
@@ -27,8 +45,8 @@ func (protocol *SecurityProtocol) Disable() <-chan *security.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -49,8 +67,8 @@ func (protocol *SecurityProtocol) Enable() <-chan *security.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -74,8 +92,8 @@ func (protocol *SecurityProtocol) HandleCertificateError(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -100,8 +118,8 @@ func (protocol *SecurityProtocol) SetIgnoreCertificateErrors(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -126,8 +144,8 @@ func (protocol *SecurityProtocol) SetOverrideCertificateErrors(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -147,8 +165,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Security/#event-certifica
 */
 func (protocol *SecurityProtocol) OnCertificateError(
 	callback func(event *security.CertificateErrorEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newCertificateErrorHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newCertificateErrorHandler returns a Security.certificateError event handler
+that has not been registered with the socket.
+*/
+func (protocol *SecurityProtocol) newCertificateErrorHandler(
+	callback func(event *security.CertificateErrorEvent),
+) *Handler {
+	return NewEventHandler(
 		"Security.certificateError",
 		func(response *Response) {
 			event := &security.CertificateErrorEvent{}
@@ -159,7 +189,36 @@ func (protocol *SecurityProtocol) OnCertificateError(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeCertificateError returns a subscription that delivers
+Security.certificateError events on a typed channel until it is unsubscribed.
+See OnCertificateError().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Security/#event-certificateError
+*/
+func (protocol *SecurityProtocol) SubscribeCertificateError(
+	options *SubscriptionOptions,
+) *Subscription[*security.CertificateErrorEvent] {
+	sub := NewSubscription[*security.CertificateErrorEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newCertificateErrorHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForCertificateError blocks until a Security.certificateError event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Security/#event-certificateError
+*/
+func (protocol *SecurityProtocol) WaitForCertificateError(
+	ctx context.Context,
+	predicate func(event *security.CertificateErrorEvent) bool,
+) (*security.CertificateErrorEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeCertificateError(nil), predicate)
 }
 
 /*
@@ -170,8 +229,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Security/#event-securityS
 */
 func (protocol *SecurityProtocol) OnSecurityStateChanged(
 	callback func(event *security.StateChangedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newSecurityStateChangedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newSecurityStateChangedHandler returns a Security.securityStateChanged event
+handler that has not been registered with the socket.
+*/
+func (protocol *SecurityProtocol) newSecurityStateChangedHandler(
+	callback func(event *security.StateChangedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Security.securityStateChanged",
 		func(response *Response) {
 			event := &security.StateChangedEvent{}
@@ -182,5 +253,34 @@ func (protocol *SecurityProtocol) OnSecurityStateChanged(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeSecurityStateChanged returns a subscription that delivers
+Security.securityStateChanged events on a typed channel until it is
+unsubscribed. See OnSecurityStateChanged().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Security/#event-securityStateChanged
+*/
+func (protocol *SecurityProtocol) SubscribeSecurityStateChanged(
+	options *SubscriptionOptions,
+) *Subscription[*security.StateChangedEvent] {
+	sub := NewSubscription[*security.StateChangedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newSecurityStateChangedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForSecurityStateChanged blocks until a Security.securityStateChanged event
+that satisfies the predicate is received and returns it. A nil predicate matches
+the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Security/#event-securityStateChanged
+*/
+func (protocol *SecurityProtocol) WaitForSecurityStateChanged(
+	ctx context.Context,
+	predicate func(event *security.StateChangedEvent) bool,
+) (*security.StateChangedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeSecurityStateChanged(nil), predicate)
 }
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/service/worker"
 )
@@ -17,6 +19,22 @@ type ServiceWorkerProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *ServiceWorkerProtocol) WithContext(ctx context.Context) *ServiceWorkerProtocol {
+	return &ServiceWorkerProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *ServiceWorkerProtocol) WithTimeout(timeout time.Duration) *ServiceWorkerProtocol {
+	return &ServiceWorkerProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 DeliverPushMessage is experimental.
 
@@ -32,8 +50,8 @@ func (protocol *ServiceWorkerProtocol) DeliverPushMessage(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -55,8 +73,8 @@ func (protocol *ServiceWorkerProtocol) Disable() <-chan *worker.DisableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -80,8 +98,8 @@ func (protocol *ServiceWorkerProtocol) DispatchSyncEvent(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -103,8 +121,8 @@ func (protocol *ServiceWorkerProtocol) Enable() <-chan *worker.EnableResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -128,8 +146,8 @@ func (protocol *ServiceWorkerProtocol) InspectWorker(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -153,8 +171,8 @@ func (protocol *ServiceWorkerProtocol) SetForceUpdateOnPageLoad(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -178,8 +196,8 @@ func (protocol *ServiceWorkerProtocol) SkipWaiting(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -203,8 +221,8 @@ func (protocol *ServiceWorkerProtocol) StartWorker(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -226,8 +244,8 @@ func (protocol *ServiceWorkerProtocol) StopAllWorkers() <-chan *worker.StopAllWo
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -251,8 +269,8 @@ func (protocol *ServiceWorkerProtocol) StopWorker(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -276,8 +294,8 @@ func (protocol *ServiceWorkerProtocol) Unregister(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -301,8 +319,8 @@ func (protocol *ServiceWorkerProtocol) UpdateRegistration(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -319,8 +337,20 @@ EXPERIMENTAL.
 */
 func (protocol *ServiceWorkerProtocol) OnWorkerErrorReported(
 	callback func(event *worker.ErrorReportedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newWorkerErrorReportedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newWorkerErrorReportedHandler returns a ServiceWorker.workerErrorReported event
+handler that has not been registered with the socket.
+*/
+func (protocol *ServiceWorkerProtocol) newWorkerErrorReportedHandler(
+	callback func(event *worker.ErrorReportedEvent),
+) *Handler {
+	return NewEventHandler(
 		"ServiceWorker.workerErrorReported",
 		func(response *Response) {
 			event := &worker.ErrorReportedEvent{}
@@ -331,7 +361,38 @@ func (protocol *ServiceWorkerProtocol) OnWorkerErrorReported(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeWorkerErrorReported returns a subscription that delivers
+ServiceWorker.workerErrorReported events on a typed channel until it is
+unsubscribed. See OnWorkerErrorReported().
+
+https://chromedevtools.github.io/devtools-protocol/tot/ServiceWorker/#event-workerErrorReported
+EXPERIMENTAL.
+*/
+func (protocol *ServiceWorkerProtocol) SubscribeWorkerErrorReported(
+	options *SubscriptionOptions,
+) *Subscription[*worker.ErrorReportedEvent] {
+	sub := NewSubscription[*worker.ErrorReportedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newWorkerErrorReportedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForWorkerErrorReported blocks until a ServiceWorker.workerErrorReported
+event that satisfies the predicate is received and returns it. A nil predicate
+matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/ServiceWorker/#event-workerErrorReported
+EXPERIMENTAL.
+*/
+func (protocol *ServiceWorkerProtocol) WaitForWorkerErrorReported(
+	ctx context.Context,
+	predicate func(event *worker.ErrorReportedEvent) bool,
+) (*worker.ErrorReportedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeWorkerErrorReported(nil), predicate)
 }
 
 /*
@@ -342,8 +403,21 @@ EXPERIMENTAL.
 */
 func (protocol *ServiceWorkerProtocol) OnWorkerRegistrationUpdated(
 	callback func(event *worker.RegistrationUpdatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newWorkerRegistrationUpdatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newWorkerRegistrationUpdatedHandler returns a
+ServiceWorker.workerRegistrationUpdated event handler that has not been
+registered with the socket.
+*/
+func (protocol *ServiceWorkerProtocol) newWorkerRegistrationUpdatedHandler(
+	callback func(event *worker.RegistrationUpdatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"ServiceWorker.workerRegistrationUpdated",
 		func(response *Response) {
 			event := &worker.RegistrationUpdatedEvent{}
@@ -354,7 +428,38 @@ func (protocol *ServiceWorkerProtocol) OnWorkerRegistrationUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeWorkerRegistrationUpdated returns a subscription that delivers
+ServiceWorker.workerRegistrationUpdated events on a typed channel until it is
+unsubscribed. See OnWorkerRegistrationUpdated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/ServiceWorker/#event-workerRegistrationUpdated
+EXPERIMENTAL.
+*/
+func (protocol *ServiceWorkerProtocol) SubscribeWorkerRegistrationUpdated(
+	options *SubscriptionOptions,
+) *Subscription[*worker.RegistrationUpdatedEvent] {
+	sub := NewSubscription[*worker.RegistrationUpdatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newWorkerRegistrationUpdatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForWorkerRegistrationUpdated blocks until a
+ServiceWorker.workerRegistrationUpdated event that satisfies the predicate is
+received and returns it. A nil predicate matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/ServiceWorker/#event-workerRegistrationUpdated
+EXPERIMENTAL.
+*/
+func (protocol *ServiceWorkerProtocol) WaitForWorkerRegistrationUpdated(
+	ctx context.Context,
+	predicate func(event *worker.RegistrationUpdatedEvent) bool,
+) (*worker.RegistrationUpdatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeWorkerRegistrationUpdated(nil), predicate)
 }
 
 /*
@@ -365,8 +470,20 @@ EXPERIMENTAL.
 */
 func (protocol *ServiceWorkerProtocol) OnWorkerVersionUpdated(
 	callback func(event *worker.VersionUpdatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newWorkerVersionUpdatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newWorkerVersionUpdatedHandler returns a ServiceWorker.workerVersionUpdated
+event handler that has not been registered with the socket.
+*/
+func (protocol *ServiceWorkerProtocol) newWorkerVersionUpdatedHandler(
+	callback func(event *worker.VersionUpdatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"ServiceWorker.workerVersionUpdated",
 		func(response *Response) {
 			event := &worker.VersionUpdatedEvent{}
@@ -377,5 +494,36 @@ func (protocol *ServiceWorkerProtocol) OnWorkerVersionUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeWorkerVersionUpdated returns a subscription that delivers
+ServiceWorker.workerVersionUpdated events on a typed channel until it is
+unsubscribed. See OnWorkerVersionUpdated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/ServiceWorker/#event-workerVersionUpdated
+EXPERIMENTAL.
+*/
+func (protocol *ServiceWorkerProtocol) SubscribeWorkerVersionUpdated(
+	options *SubscriptionOptions,
+) *Subscription[*worker.VersionUpdatedEvent] {
+	sub := NewSubscription[*worker.VersionUpdatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newWorkerVersionUpdatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForWorkerVersionUpdated blocks until a ServiceWorker.workerVersionUpdated
+event that satisfies the predicate is received and returns it. A nil predicate
+matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/ServiceWorker/#event-workerVersionUpdated
+EXPERIMENTAL.
+*/
+func (protocol *ServiceWorkerProtocol) WaitForWorkerVersionUpdated(
+	ctx context.Context,
+	predicate func(event *worker.VersionUpdatedEvent) bool,
+) (*worker.VersionUpdatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeWorkerVersionUpdated(nil), predicate)
 }
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/storage"
 )
@@ -15,6 +17,22 @@ type StorageProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *StorageProtocol) WithContext(ctx context.Context) *StorageProtocol {
+	return &StorageProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *StorageProtocol) WithTimeout(timeout time.Duration) *StorageProtocol {
+	return &StorageProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 ClearDataForOrigin clears storage for origin.
 
@@ -29,8 +47,8 @@ func (protocol *StorageProtocol) ClearDataForOrigin(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -53,8 +71,8 @@ func (protocol *StorageProtocol) GetUsageAndQuota(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -80,8 +98,8 @@ func (protocol *StorageProtocol) TrackCacheStorageForOrigin(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -105,8 +123,8 @@ func (protocol *StorageProtocol) TrackIndexedDBForOrigin(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -130,8 +148,8 @@ func (protocol *StorageProtocol) UntrackCacheStorageForOrigin(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -155,8 +173,8 @@ func (protocol *StorageProtocol) UntrackIndexedDBForOrigin(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -174,8 +192,21 @@ https://chromedevtools.github.io/devtools-protocol/tot/Storage/#event-cacheStora
 */
 func (protocol *StorageProtocol) OnCacheStorageContentUpdated(
 	callback func(event *storage.CacheStorageContentUpdatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newCacheStorageContentUpdatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newCacheStorageContentUpdatedHandler returns a
+Storage.cacheStorageContentUpdated event handler that has not been registered
+with the socket.
+*/
+func (protocol *StorageProtocol) newCacheStorageContentUpdatedHandler(
+	callback func(event *storage.CacheStorageContentUpdatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Storage.cacheStorageContentUpdated",
 		func(response *Response) {
 			event := &storage.CacheStorageContentUpdatedEvent{}
@@ -186,7 +217,36 @@ func (protocol *StorageProtocol) OnCacheStorageContentUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeCacheStorageContentUpdated returns a subscription that delivers
+Storage.cacheStorageContentUpdated events on a typed channel until it is
+unsubscribed. See OnCacheStorageContentUpdated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#event-cacheStorageContentUpdated
+*/
+func (protocol *StorageProtocol) SubscribeCacheStorageContentUpdated(
+	options *SubscriptionOptions,
+) *Subscription[*storage.CacheStorageContentUpdatedEvent] {
+	sub := NewSubscription[*storage.CacheStorageContentUpdatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newCacheStorageContentUpdatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForCacheStorageContentUpdated blocks until a
+Storage.cacheStorageContentUpdated event that satisfies the predicate is
+received and returns it. A nil predicate matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#event-cacheStorageContentUpdated
+*/
+func (protocol *StorageProtocol) WaitForCacheStorageContentUpdated(
+	ctx context.Context,
+	predicate func(event *storage.CacheStorageContentUpdatedEvent) bool,
+) (*storage.CacheStorageContentUpdatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeCacheStorageContentUpdated(nil), predicate)
 }
 
 /*
@@ -197,8 +257,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Storage/#event-cacheStora
 */
 func (protocol *StorageProtocol) OnCacheStorageListUpdated(
 	callback func(event *storage.CacheStorageListUpdatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newCacheStorageListUpdatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newCacheStorageListUpdatedHandler returns a Storage.cacheStorageListUpdated
+event handler that has not been registered with the socket.
+*/
+func (protocol *StorageProtocol) newCacheStorageListUpdatedHandler(
+	callback func(event *storage.CacheStorageListUpdatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Storage.cacheStorageListUpdated",
 		func(response *Response) {
 			event := &storage.CacheStorageListUpdatedEvent{}
@@ -209,7 +281,36 @@ func (protocol *StorageProtocol) OnCacheStorageListUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeCacheStorageListUpdated returns a subscription that delivers
+Storage.cacheStorageListUpdated events on a typed channel until it is
+unsubscribed. See OnCacheStorageListUpdated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#event-cacheStorageListUpdated
+*/
+func (protocol *StorageProtocol) SubscribeCacheStorageListUpdated(
+	options *SubscriptionOptions,
+) *Subscription[*storage.CacheStorageListUpdatedEvent] {
+	sub := NewSubscription[*storage.CacheStorageListUpdatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newCacheStorageListUpdatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForCacheStorageListUpdated blocks until a Storage.cacheStorageListUpdated
+event that satisfies the predicate is received and returns it. A nil predicate
+matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#event-cacheStorageListUpdated
+*/
+func (protocol *StorageProtocol) WaitForCacheStorageListUpdated(
+	ctx context.Context,
+	predicate func(event *storage.CacheStorageListUpdatedEvent) bool,
+) (*storage.CacheStorageListUpdatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeCacheStorageListUpdated(nil), predicate)
 }
 
 /*
@@ -221,8 +322,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Storage/#event-indexedDBC
 */
 func (protocol *StorageProtocol) OnIndexedDBContentUpdated(
 	callback func(event *storage.IndexedDBContentUpdatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newIndexedDBContentUpdatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newIndexedDBContentUpdatedHandler returns a Storage.indexedDBContentUpdated
+event handler that has not been registered with the socket.
+*/
+func (protocol *StorageProtocol) newIndexedDBContentUpdatedHandler(
+	callback func(event *storage.IndexedDBContentUpdatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Storage.indexedDBContentUpdated",
 		func(response *Response) {
 			event := &storage.IndexedDBContentUpdatedEvent{}
@@ -233,7 +346,36 @@ func (protocol *StorageProtocol) OnIndexedDBContentUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeIndexedDBContentUpdated returns a subscription that delivers
+Storage.indexedDBContentUpdated events on a typed channel until it is
+unsubscribed. See OnIndexedDBContentUpdated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#event-indexedDBContentUpdated
+*/
+func (protocol *StorageProtocol) SubscribeIndexedDBContentUpdated(
+	options *SubscriptionOptions,
+) *Subscription[*storage.IndexedDBContentUpdatedEvent] {
+	sub := NewSubscription[*storage.IndexedDBContentUpdatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newIndexedDBContentUpdatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForIndexedDBContentUpdated blocks until a Storage.indexedDBContentUpdated
+event that satisfies the predicate is received and returns it. A nil predicate
+matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#event-indexedDBContentUpdated
+*/
+func (protocol *StorageProtocol) WaitForIndexedDBContentUpdated(
+	ctx context.Context,
+	predicate func(event *storage.IndexedDBContentUpdatedEvent) bool,
+) (*storage.IndexedDBContentUpdatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeIndexedDBContentUpdated(nil), predicate)
 }
 
 /*
@@ -245,8 +387,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Storage/#event-indexedDBL
 */
 func (protocol *StorageProtocol) OnIndexedDBListUpdated(
 	callback func(event *storage.IndexedDBListUpdatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newIndexedDBListUpdatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newIndexedDBListUpdatedHandler returns a Storage.indexedDBListUpdated event
+handler that has not been registered with the socket.
+*/
+func (protocol *StorageProtocol) newIndexedDBListUpdatedHandler(
+	callback func(event *storage.IndexedDBListUpdatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Storage.indexedDBListUpdated",
 		func(response *Response) {
 			event := &storage.IndexedDBListUpdatedEvent{}
@@ -257,5 +411,34 @@ func (protocol *StorageProtocol) OnIndexedDBListUpdated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeIndexedDBListUpdated returns a subscription that delivers
+Storage.indexedDBListUpdated events on a typed channel until it is unsubscribed.
+See OnIndexedDBListUpdated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#event-indexedDBListUpdated
+*/
+func (protocol *StorageProtocol) SubscribeIndexedDBListUpdated(
+	options *SubscriptionOptions,
+) *Subscription[*storage.IndexedDBListUpdatedEvent] {
+	sub := NewSubscription[*storage.IndexedDBListUpdatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newIndexedDBListUpdatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForIndexedDBListUpdated blocks until a Storage.indexedDBListUpdated event
+that satisfies the predicate is received and returns it. A nil predicate matches
+the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Storage/#event-indexedDBListUpdated
+*/
+func (protocol *StorageProtocol) WaitForIndexedDBListUpdated(
+	ctx context.Context,
+	predicate func(event *storage.IndexedDBListUpdatedEvent) bool,
+) (*storage.IndexedDBListUpdatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeIndexedDBListUpdated(nil), predicate)
 }
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/system/info"
 )
@@ -17,6 +19,22 @@ type SystemInfoProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *SystemInfoProtocol) WithContext(ctx context.Context) *SystemInfoProtocol {
+	return &SystemInfoProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *SystemInfoProtocol) WithTimeout(timeout time.Duration) *SystemInfoProtocol {
+	return &SystemInfoProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 GetInfo returns information about the system.
 
@@ -29,8 +47,8 @@ func (protocol *SystemInfoProtocol) GetInfo() <-chan *info.GetInfoResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
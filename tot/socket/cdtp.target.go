@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/target"
 )
@@ -16,6 +18,22 @@ type TargetProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *TargetProtocol) WithContext(ctx context.Context) *TargetProtocol {
+	return &TargetProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *TargetProtocol) WithTimeout(timeout time.Duration) *TargetProtocol {
+	return &TargetProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 ActivateTarget activates (focuses) the target.
 
@@ -30,8 +48,8 @@ func (protocol *TargetProtocol) ActivateTarget(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -54,8 +72,8 @@ func (protocol *TargetProtocol) AttachToTarget(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -80,8 +98,8 @@ func (protocol *TargetProtocol) CloseTarget(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -106,8 +124,8 @@ func (protocol *TargetProtocol) CreateBrowserContext() <-chan *target.CreateBrow
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -132,8 +150,8 @@ func (protocol *TargetProtocol) CreateTarget(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -158,8 +176,8 @@ func (protocol *TargetProtocol) DetachFromTarget(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -184,8 +202,8 @@ func (protocol *TargetProtocol) DisposeBrowserContext(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -211,8 +229,8 @@ func (protocol *TargetProtocol) GetTargetInfo(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -237,8 +255,10 @@ func (protocol *TargetProtocol) GetTargets(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
+		} else {
+			result.Err = json.Unmarshal(response.Result, &result)
 		}
 		resultChan <- result
 		close(resultChan)
@@ -261,8 +281,8 @@ func (protocol *TargetProtocol) SendMessageToTarget(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -286,8 +306,8 @@ func (protocol *TargetProtocol) SetAttachToFrames(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -313,8 +333,8 @@ func (protocol *TargetProtocol) SetAutoAttach(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -338,8 +358,8 @@ func (protocol *TargetProtocol) SetDiscoverTargets(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -364,8 +384,8 @@ func (protocol *TargetProtocol) SetRemoteLocations(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -383,8 +403,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-attachedToT
 */
 func (protocol *TargetProtocol) OnAttachedToTarget(
 	callback func(event *target.AttachedToTargetEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newAttachedToTargetHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newAttachedToTargetHandler returns a Target.attachedToTarget event handler that
+has not been registered with the socket.
+*/
+func (protocol *TargetProtocol) newAttachedToTargetHandler(
+	callback func(event *target.AttachedToTargetEvent),
+) *Handler {
+	return NewEventHandler(
 		"Target.attachedToTarget",
 		func(response *Response) {
 			event := &target.AttachedToTargetEvent{}
@@ -395,7 +427,36 @@ func (protocol *TargetProtocol) OnAttachedToTarget(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeAttachedToTarget returns a subscription that delivers
+Target.attachedToTarget events on a typed channel until it is unsubscribed. See
+OnAttachedToTarget().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-attachedToTarget EXPERIMENTAL.
+*/
+func (protocol *TargetProtocol) SubscribeAttachedToTarget(
+	options *SubscriptionOptions,
+) *Subscription[*target.AttachedToTargetEvent] {
+	sub := NewSubscription[*target.AttachedToTargetEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newAttachedToTargetHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForAttachedToTarget blocks until a Target.attachedToTarget event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-attachedToTarget EXPERIMENTAL.
+*/
+func (protocol *TargetProtocol) WaitForAttachedToTarget(
+	ctx context.Context,
+	predicate func(event *target.AttachedToTargetEvent) bool,
+) (*target.AttachedToTargetEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeAttachedToTarget(nil), predicate)
 }
 
 /*
@@ -409,8 +470,20 @@ EXPERIMENTAL.
 */
 func (protocol *TargetProtocol) OnDetachedFromTarget(
 	callback func(event *target.DetachedFromTargetEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newDetachedFromTargetHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newDetachedFromTargetHandler returns a Target.detachedFromTarget event handler
+that has not been registered with the socket.
+*/
+func (protocol *TargetProtocol) newDetachedFromTargetHandler(
+	callback func(event *target.DetachedFromTargetEvent),
+) *Handler {
+	return NewEventHandler(
 		"Target.detachedFromTarget",
 		func(response *Response) {
 			event := &target.DetachedFromTargetEvent{}
@@ -421,7 +494,38 @@ func (protocol *TargetProtocol) OnDetachedFromTarget(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeDetachedFromTarget returns a subscription that delivers
+Target.detachedFromTarget events on a typed channel until it is unsubscribed.
+See OnDetachedFromTarget().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-detachedFromTarget
+EXPERIMENTAL.
+*/
+func (protocol *TargetProtocol) SubscribeDetachedFromTarget(
+	options *SubscriptionOptions,
+) *Subscription[*target.DetachedFromTargetEvent] {
+	sub := NewSubscription[*target.DetachedFromTargetEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newDetachedFromTargetHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForDetachedFromTarget blocks until a Target.detachedFromTarget event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-detachedFromTarget
+EXPERIMENTAL.
+*/
+func (protocol *TargetProtocol) WaitForDetachedFromTarget(
+	ctx context.Context,
+	predicate func(event *target.DetachedFromTargetEvent) bool,
+) (*target.DetachedFromTargetEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeDetachedFromTarget(nil), predicate)
 }
 
 /*
@@ -433,8 +537,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-receivedMes
 */
 func (protocol *TargetProtocol) OnReceivedMessageFromTarget(
 	callback func(event *target.ReceivedMessageFromTargetEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newReceivedMessageFromTargetHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newReceivedMessageFromTargetHandler returns a Target.receivedMessageFromTarget
+event handler that has not been registered with the socket.
+*/
+func (protocol *TargetProtocol) newReceivedMessageFromTargetHandler(
+	callback func(event *target.ReceivedMessageFromTargetEvent),
+) *Handler {
+	return NewEventHandler(
 		"Target.receivedMessageFromTarget",
 		func(response *Response) {
 			event := &target.ReceivedMessageFromTargetEvent{}
@@ -445,7 +561,36 @@ func (protocol *TargetProtocol) OnReceivedMessageFromTarget(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeReceivedMessageFromTarget returns a subscription that delivers
+Target.receivedMessageFromTarget events on a typed channel until it is
+unsubscribed. See OnReceivedMessageFromTarget().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-receivedMessageFromTarget
+*/
+func (protocol *TargetProtocol) SubscribeReceivedMessageFromTarget(
+	options *SubscriptionOptions,
+) *Subscription[*target.ReceivedMessageFromTargetEvent] {
+	sub := NewSubscription[*target.ReceivedMessageFromTargetEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newReceivedMessageFromTargetHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForReceivedMessageFromTarget blocks until a Target.receivedMessageFromTarget
+event that satisfies the predicate is received and returns it. A nil predicate
+matches the first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-receivedMessageFromTarget
+*/
+func (protocol *TargetProtocol) WaitForReceivedMessageFromTarget(
+	ctx context.Context,
+	predicate func(event *target.ReceivedMessageFromTargetEvent) bool,
+) (*target.ReceivedMessageFromTargetEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeReceivedMessageFromTarget(nil), predicate)
 }
 
 /*
@@ -456,8 +601,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-targetCreat
 */
 func (protocol *TargetProtocol) OnTargetCreated(
 	callback func(event *target.CreatedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newTargetCreatedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newTargetCreatedHandler returns a Target.targetCreated event handler that has
+not been registered with the socket.
+*/
+func (protocol *TargetProtocol) newTargetCreatedHandler(
+	callback func(event *target.CreatedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Target.targetCreated",
 		func(response *Response) {
 			event := &target.CreatedEvent{}
@@ -468,7 +625,35 @@ func (protocol *TargetProtocol) OnTargetCreated(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeTargetCreated returns a subscription that delivers Target.targetCreated
+events on a typed channel until it is unsubscribed. See OnTargetCreated().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-targetCreated
+*/
+func (protocol *TargetProtocol) SubscribeTargetCreated(
+	options *SubscriptionOptions,
+) *Subscription[*target.CreatedEvent] {
+	sub := NewSubscription[*target.CreatedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newTargetCreatedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForTargetCreated blocks until a Target.targetCreated event that satisfies
+the predicate is received and returns it. A nil predicate matches the first
+event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-targetCreated
+*/
+func (protocol *TargetProtocol) WaitForTargetCreated(
+	ctx context.Context,
+	predicate func(event *target.CreatedEvent) bool,
+) (*target.CreatedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeTargetCreated(nil), predicate)
 }
 
 /*
@@ -479,8 +664,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-targetDestr
 */
 func (protocol *TargetProtocol) OnTargetDestroyed(
 	callback func(event *target.DestroyedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newTargetDestroyedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newTargetDestroyedHandler returns a Target.targetDestroyed event handler that
+has not been registered with the socket.
+*/
+func (protocol *TargetProtocol) newTargetDestroyedHandler(
+	callback func(event *target.DestroyedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Target.targetDestroyed",
 		func(response *Response) {
 			event := &target.DestroyedEvent{}
@@ -491,7 +688,36 @@ func (protocol *TargetProtocol) OnTargetDestroyed(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeTargetDestroyed returns a subscription that delivers
+Target.targetDestroyed events on a typed channel until it is unsubscribed. See
+OnTargetDestroyed().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-targetDestroyed
+*/
+func (protocol *TargetProtocol) SubscribeTargetDestroyed(
+	options *SubscriptionOptions,
+) *Subscription[*target.DestroyedEvent] {
+	sub := NewSubscription[*target.DestroyedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newTargetDestroyedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForTargetDestroyed blocks until a Target.targetDestroyed event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-targetDestroyed
+*/
+func (protocol *TargetProtocol) WaitForTargetDestroyed(
+	ctx context.Context,
+	predicate func(event *target.DestroyedEvent) bool,
+) (*target.DestroyedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeTargetDestroyed(nil), predicate)
 }
 
 /*
@@ -503,8 +729,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-targetInfoC
 */
 func (protocol *TargetProtocol) OnTargetInfoChanged(
 	callback func(event *target.InfoChangedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newTargetInfoChangedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newTargetInfoChangedHandler returns a Target.targetInfoChanged event handler
+that has not been registered with the socket.
+*/
+func (protocol *TargetProtocol) newTargetInfoChangedHandler(
+	callback func(event *target.InfoChangedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Target.targetInfoChanged",
 		func(response *Response) {
 			event := &target.InfoChangedEvent{}
@@ -515,5 +753,34 @@ func (protocol *TargetProtocol) OnTargetInfoChanged(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeTargetInfoChanged returns a subscription that delivers
+Target.targetInfoChanged events on a typed channel until it is unsubscribed. See
+OnTargetInfoChanged().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-targetInfoChanged
+*/
+func (protocol *TargetProtocol) SubscribeTargetInfoChanged(
+	options *SubscriptionOptions,
+) *Subscription[*target.InfoChangedEvent] {
+	sub := NewSubscription[*target.InfoChangedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newTargetInfoChangedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForTargetInfoChanged blocks until a Target.targetInfoChanged event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Target/#event-targetInfoChanged
+*/
+func (protocol *TargetProtocol) WaitForTargetInfoChanged(
+	ctx context.Context,
+	predicate func(event *target.InfoChangedEvent) bool,
+) (*target.InfoChangedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeTargetInfoChanged(nil), predicate)
 }
@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/tethering"
 )
@@ -17,6 +19,22 @@ type TetheringProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *TetheringProtocol) WithContext(ctx context.Context) *TetheringProtocol {
+	return &TetheringProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *TetheringProtocol) WithTimeout(timeout time.Duration) *TetheringProtocol {
+	return &TetheringProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 Bind requests browser port binding.
 
@@ -31,8 +49,8 @@ func (protocol *TetheringProtocol) Bind(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -55,8 +73,8 @@ func (protocol *TetheringProtocol) Unbind(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -73,8 +91,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Tethering/#event-accepted
 */
 func (protocol *TetheringProtocol) OnAccepted(
 	callback func(event *tethering.AcceptedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newAcceptedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newAcceptedHandler returns a Tethering.accepted event handler that has not been
+registered with the socket.
+*/
+func (protocol *TetheringProtocol) newAcceptedHandler(
+	callback func(event *tethering.AcceptedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Tethering.accepted",
 		func(response *Response) {
 			event := &tethering.AcceptedEvent{}
@@ -85,5 +115,33 @@ func (protocol *TetheringProtocol) OnAccepted(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeAccepted returns a subscription that delivers Tethering.accepted events
+on a typed channel until it is unsubscribed. See OnAccepted().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Tethering/#event-accepted
+*/
+func (protocol *TetheringProtocol) SubscribeAccepted(
+	options *SubscriptionOptions,
+) *Subscription[*tethering.AcceptedEvent] {
+	sub := NewSubscription[*tethering.AcceptedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newAcceptedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForAccepted blocks until a Tethering.accepted event that satisfies the
+predicate is received and returns it. A nil predicate matches the first event.
+See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Tethering/#event-accepted
+*/
+func (protocol *TetheringProtocol) WaitForAccepted(
+	ctx context.Context,
+	predicate func(event *tethering.AcceptedEvent) bool,
+) (*tethering.AcceptedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeAccepted(nil), predicate)
 }
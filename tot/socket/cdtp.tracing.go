@@ -1,7 +1,9 @@
 package socket
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mkenney/go-chrome/tot/cdtp/tracing"
 )
@@ -15,6 +17,22 @@ type TracingProtocol struct {
 	Socket Socketer
 }
 
+/*
+WithContext returns a copy of the protocol that binds all commands to the
+specified context.
+*/
+func (protocol *TracingProtocol) WithContext(ctx context.Context) *TracingProtocol {
+	return &TracingProtocol{Socket: WithContext(ctx, protocol.Socket)}
+}
+
+/*
+WithTimeout returns a copy of the protocol that abandons any command that has
+not received a response within the specified timeout.
+*/
+func (protocol *TracingProtocol) WithTimeout(timeout time.Duration) *TracingProtocol {
+	return &TracingProtocol{Socket: WithTimeout(timeout, protocol.Socket)}
+}
+
 /*
 End stops trace events collection.
 
@@ -27,8 +45,8 @@ func (protocol *TracingProtocol) End() <-chan *tracing.EndResult {
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -49,8 +67,8 @@ func (protocol *TracingProtocol) GetCategories() <-chan *tracing.GetCategoriesRe
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -74,8 +92,8 @@ func (protocol *TracingProtocol) RecordClockSyncMarker(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -96,8 +114,8 @@ func (protocol *TracingProtocol) RequestMemoryDump() <-chan *tracing.RequestMemo
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		} else {
 			result.Err = json.Unmarshal(response.Result, &result)
 		}
@@ -121,8 +139,8 @@ func (protocol *TracingProtocol) Start(
 
 	go func() {
 		response := <-protocol.Socket.SendCommand(command)
-		if nil != response.Error && 0 != response.Error.Code {
-			result.Err = response.Error
+		if err := response.Err(); nil != err {
+			result.Err = err
 		}
 		resultChan <- result
 		close(resultChan)
@@ -139,8 +157,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Tracing/#event-bufferUsag
 */
 func (protocol *TracingProtocol) OnBufferUsage(
 	callback func(event *tracing.BufferUsageEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newBufferUsageHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newBufferUsageHandler returns a Tracing.bufferUsage event handler that has not
+been registered with the socket.
+*/
+func (protocol *TracingProtocol) newBufferUsageHandler(
+	callback func(event *tracing.BufferUsageEvent),
+) *Handler {
+	return NewEventHandler(
 		"Tracing.bufferUsage",
 		func(response *Response) {
 			event := &tracing.BufferUsageEvent{}
@@ -151,7 +181,35 @@ func (protocol *TracingProtocol) OnBufferUsage(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeBufferUsage returns a subscription that delivers Tracing.bufferUsage
+events on a typed channel until it is unsubscribed. See OnBufferUsage().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Tracing/#event-bufferUsage
+*/
+func (protocol *TracingProtocol) SubscribeBufferUsage(
+	options *SubscriptionOptions,
+) *Subscription[*tracing.BufferUsageEvent] {
+	sub := NewSubscription[*tracing.BufferUsageEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newBufferUsageHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForBufferUsage blocks until a Tracing.bufferUsage event that satisfies the
+predicate is received and returns it. A nil predicate matches the first event.
+See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Tracing/#event-bufferUsage
+*/
+func (protocol *TracingProtocol) WaitForBufferUsage(
+	ctx context.Context,
+	predicate func(event *tracing.BufferUsageEvent) bool,
+) (*tracing.BufferUsageEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeBufferUsage(nil), predicate)
 }
 
 /*
@@ -164,8 +222,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Tracing/#event-dataCollec
 */
 func (protocol *TracingProtocol) OnDataCollected(
 	callback func(event *tracing.DataCollectedEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newDataCollectedHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newDataCollectedHandler returns a Tracing.dataCollected event handler that has
+not been registered with the socket.
+*/
+func (protocol *TracingProtocol) newDataCollectedHandler(
+	callback func(event *tracing.DataCollectedEvent),
+) *Handler {
+	return NewEventHandler(
 		"Tracing.dataCollected",
 		func(response *Response) {
 			event := &tracing.DataCollectedEvent{}
@@ -176,7 +246,36 @@ func (protocol *TracingProtocol) OnDataCollected(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeDataCollected returns a subscription that delivers
+Tracing.dataCollected events on a typed channel until it is unsubscribed. See
+OnDataCollected().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Tracing/#event-dataCollected
+*/
+func (protocol *TracingProtocol) SubscribeDataCollected(
+	options *SubscriptionOptions,
+) *Subscription[*tracing.DataCollectedEvent] {
+	sub := NewSubscription[*tracing.DataCollectedEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newDataCollectedHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForDataCollected blocks until a Tracing.dataCollected event that satisfies
+the predicate is received and returns it. A nil predicate matches the first
+event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Tracing/#event-dataCollected
+*/
+func (protocol *TracingProtocol) WaitForDataCollected(
+	ctx context.Context,
+	predicate func(event *tracing.DataCollectedEvent) bool,
+) (*tracing.DataCollectedEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeDataCollected(nil), predicate)
 }
 
 /*
@@ -188,8 +287,20 @@ https://chromedevtools.github.io/devtools-protocol/tot/Tracing/#event-tracingCom
 */
 func (protocol *TracingProtocol) OnTracingComplete(
 	callback func(event *tracing.CompleteEvent),
-) {
-	handler := NewEventHandler(
+) *Handler {
+	handler := protocol.newTracingCompleteHandler(callback)
+	protocol.Socket.AddEventHandler(handler)
+	return handler
+}
+
+/*
+newTracingCompleteHandler returns a Tracing.tracingComplete event handler that
+has not been registered with the socket.
+*/
+func (protocol *TracingProtocol) newTracingCompleteHandler(
+	callback func(event *tracing.CompleteEvent),
+) *Handler {
+	return NewEventHandler(
 		"Tracing.tracingComplete",
 		func(response *Response) {
 			event := &tracing.CompleteEvent{}
@@ -200,5 +311,34 @@ func (protocol *TracingProtocol) OnTracingComplete(
 			callback(event)
 		},
 	)
-	protocol.Socket.AddEventHandler(handler)
+}
+
+/*
+SubscribeTracingComplete returns a subscription that delivers
+Tracing.tracingComplete events on a typed channel until it is unsubscribed. See
+OnTracingComplete().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Tracing/#event-tracingComplete
+*/
+func (protocol *TracingProtocol) SubscribeTracingComplete(
+	options *SubscriptionOptions,
+) *Subscription[*tracing.CompleteEvent] {
+	sub := NewSubscription[*tracing.CompleteEvent](protocol.Socket, options)
+	sub.SetHandler(protocol.newTracingCompleteHandler(sub.Deliver))
+	protocol.Socket.AddEventHandler(sub.Handler())
+	return sub
+}
+
+/*
+WaitForTracingComplete blocks until a Tracing.tracingComplete event that
+satisfies the predicate is received and returns it. A nil predicate matches the
+first event. See WaitFor().
+
+https://chromedevtools.github.io/devtools-protocol/tot/Tracing/#event-tracingComplete
+*/
+func (protocol *TracingProtocol) WaitForTracingComplete(
+	ctx context.Context,
+	predicate func(event *tracing.CompleteEvent) bool,
+) (*tracing.CompleteEvent, error) {
+	return WaitFor(ctx, protocol.SubscribeTracingComplete(nil), predicate)
 }
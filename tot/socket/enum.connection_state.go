@@ -0,0 +1,87 @@
+package socket
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type connectionStateEnum struct {
+	Connected    ConnectionStateEnum
+	Disconnected ConnectionStateEnum
+	Reconnecting ConnectionStateEnum
+	Closed       ConnectionStateEnum
+}
+
+/*
+ConnectionState provides named acces to the ConnectionStateEnum values.
+*/
+var ConnectionState = connectionStateEnum{
+	Connected:    connectionStateConnected,
+	Disconnected: connectionStateDisconnected,
+	Reconnecting: connectionStateReconnecting,
+	Closed:       connectionStateClosed,
+}
+
+/*
+ConnectionStateEnum defines the state of a socket connection. Allowed values:
+  - ConnectionState.Connected    "connected"
+  - ConnectionState.Disconnected "disconnected"
+  - ConnectionState.Reconnecting "reconnecting"
+  - ConnectionState.Closed       "closed"
+*/
+type ConnectionStateEnum int
+
+/*
+String implements Stringer
+*/
+func (enum ConnectionStateEnum) String() string {
+	return _connectionStateEnums[enum]
+}
+
+/*
+MarshalJSON implements json.Marshaler
+*/
+func (enum ConnectionStateEnum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(enum.String())
+}
+
+/*
+UnmarshalJSON implements json.Unmarshaler
+*/
+func (enum *ConnectionStateEnum) UnmarshalJSON(bytes []byte) error {
+	var err error
+	var val string
+
+	err = json.Unmarshal(bytes, &val)
+	if nil != err {
+		return err
+	}
+
+	for k, v := range _connectionStateEnums {
+		if v == val {
+			*enum = k
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s is not a valid type value", bytes)
+}
+
+const (
+	// connectionStateConnected represents the "connected" value.
+	connectionStateConnected ConnectionStateEnum = iota + 1
+	// connectionStateDisconnected represents the "disconnected" value.
+	connectionStateDisconnected
+	// connectionStateReconnecting represents the "reconnecting" value.
+	connectionStateReconnecting
+	// connectionStateClosed represents the "closed" value.
+	connectionStateClosed
+)
+
+var _connectionStateEnums = map[ConnectionStateEnum]string{
+	ConnectionStateEnum(0):      "",
+	connectionStateConnected:    "connected",
+	connectionStateDisconnected: "disconnected",
+	connectionStateReconnecting: "reconnecting",
+	connectionStateClosed:       "closed",
+}
@@ -0,0 +1,82 @@
+package socket
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumConnectionState(t *testing.T) {
+	var enum ConnectionStateEnum
+	var err error
+	var result []byte
+
+	err = json.Unmarshal([]byte(`""`), &enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+
+	err = json.Unmarshal([]byte(`"invalid value"`), &enum)
+	if nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `""` != string(result) {
+		t.Errorf("Expected empty JSON string, got '%s'", result)
+	}
+
+	enum = ConnectionState.Connected
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"connected"` != string(result) {
+		t.Errorf("Expected '\"connected\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"connected"`), &enum)
+	if ConnectionState.Connected != enum {
+		t.Errorf("Expcected %d, got %d", ConnectionState.Connected, enum)
+	}
+
+	enum = ConnectionState.Disconnected
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"disconnected"` != string(result) {
+		t.Errorf("Expected '\"disconnected\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"disconnected"`), &enum)
+	if ConnectionState.Disconnected != enum {
+		t.Errorf("Expcected %d, got %d", ConnectionState.Disconnected, enum)
+	}
+
+	enum = ConnectionState.Reconnecting
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"reconnecting"` != string(result) {
+		t.Errorf("Expected '\"reconnecting\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"reconnecting"`), &enum)
+	if ConnectionState.Reconnecting != enum {
+		t.Errorf("Expcected %d, got %d", ConnectionState.Reconnecting, enum)
+	}
+
+	enum = ConnectionState.Closed
+	result, err = json.Marshal(enum)
+	if nil != err {
+		t.Errorf("Expected nil, got error")
+	}
+	if `"closed"` != string(result) {
+		t.Errorf("Expected '\"closed\"', got '%s'", result)
+	}
+	json.Unmarshal([]byte(`"closed"`), &enum)
+	if ConnectionState.Closed != enum {
+		t.Errorf("Expcected %d, got %d", ConnectionState.Closed, enum)
+	}
+}
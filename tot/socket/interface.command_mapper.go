@@ -10,6 +10,9 @@ type CommandMapper interface {
 	// Get retrieves a command from the stack.
 	Get(commandID int) (Commander, error)
 
+	// List returns all commands in the stack ordered by command ID.
+	List() []Commander
+
 	// Set sets a command in the stack.
 	Set(command Commander)
 }
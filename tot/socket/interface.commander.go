@@ -1,9 +1,21 @@
 package socket
 
+import (
+	"context"
+	"time"
+)
+
 /*
 Commander defines the interface for websocket commands.
 */
 type Commander interface {
+	// Context returns the context the command is bound to.
+	Context() context.Context
+
+	// Done returns a channel that is closed once the command has received a
+	// response.
+	Done() <-chan struct{}
+
 	// Error returns the most recent error, if any.
 	Error() error
 
@@ -17,15 +29,26 @@ type Commander interface {
 	// Params returns the command parameters.
 	Params() interface{}
 
-	// Respond sends a response to the command response channel.
+	// Respond sends a response to the command response channel. Only the
+	// first response is delivered, any subsequent responses are discarded.
 	Respond(response *Response)
 
 	// Response returns the command response channel.
 	Response() chan *Response
 
+	// SetContext sets the context the command is bound to.
+	SetContext(ctx context.Context)
+
 	// SetError sets the error value
 	SetError(error)
 
 	// SetID sets the ID value
 	SetID(int)
+
+	// SetTimeout sets the command timeout, overriding the socket default.
+	SetTimeout(timeout time.Duration)
+
+	// Timeout returns the command timeout. A zero value defers to the socket
+	// default timeout and a negative value disables the timeout.
+	Timeout() time.Duration
 }
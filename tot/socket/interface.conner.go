@@ -20,6 +20,10 @@ type Conner interface {
 	// ReadJSON reads data from a websocket connection.
 	ReadJSON(v interface{}) error
 
+	// Reconnect re-establishes a lost websocket connection according to the
+	// reconnect policy.
+	Reconnect() error
+
 	// WriteJSON writes data to a websocket connection.
 	WriteJSON(v interface{}) error
 }
@@ -15,6 +15,10 @@ func NewMock(socketURL *url.URL) *Socket {
 		handlers:     NewEventHandlerMap(),
 		mux:          &sync.Mutex{},
 		newSocket:    NewMockWebsocket,
+		queueMux:     &sync.Mutex{},
+		queues:       make(map[EventHandler]*handlerQueue),
+		sessionMux:   &sync.Mutex{},
+		sessions:     make(map[string]*Session),
 		socketID:     NextSocketID(),
 		url:          socketURL,
 	}
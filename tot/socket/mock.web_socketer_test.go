@@ -23,6 +23,7 @@ func NewMockWebsocket(socketURL *url.URL) (WebSocketer, error) {
 
 type MockChromeWebSocket struct {
 	mockResponses []*Response
+	written       []*Payload
 }
 
 func (socket *MockChromeWebSocket) Close() error { return nil }
@@ -70,5 +71,8 @@ WriteJSON marshalls the provided data as JSON and writes it to the websocket.
 WriteJSON is a WebSocketer implementation.
 */
 func (socket *MockChromeWebSocket) WriteJSON(v interface{}) error {
+	if payload, ok := v.(*Payload); ok {
+		socket.written = append(socket.written, payload)
+	}
 	return nil
 }
@@ -0,0 +1,343 @@
+package socket
+
+/*
+Accessibility returns the AccessibilityProtocol instance.
+
+Accessibility is a Protocoller implementation.
+*/
+func (session *Session) Accessibility() *AccessibilityProtocol {
+	return session.accessibility
+}
+
+/*
+Animation returns the AnimationProtocol instance.
+
+Animation is a Protocoller implementation.
+*/
+func (session *Session) Animation() *AnimationProtocol {
+	return session.animation
+}
+
+/*
+ApplicationCache returns the ApplicationCacheProtocol instance.
+
+ApplicationCache is a Protocoller implementation.
+*/
+func (session *Session) ApplicationCache() *ApplicationCacheProtocol {
+	return session.applicationCache
+}
+
+/*
+Audits returns the AuditsProtocol instance.
+
+Audits is a Protocoller implementation.
+*/
+func (session *Session) Audits() *AuditsProtocol {
+	return session.audits
+}
+
+/*
+Browser returns the BrowserProtocol instance.
+
+Browser is a Protocoller implementation.
+*/
+func (session *Session) Browser() *BrowserProtocol {
+	return session.browser
+}
+
+/*
+CacheStorage returns the CacheStorageProtocol instance.
+
+CacheStorage is a Protocoller implementation.
+*/
+func (session *Session) CacheStorage() *CacheStorageProtocol {
+	return session.cacheStorage
+}
+
+/*
+Console returns the ConsoleProtocol instance.
+
+Console is a Protocoller implementation.
+*/
+func (session *Session) Console() *ConsoleProtocol {
+	return session.console
+}
+
+/*
+CSS returns the CSSProtocol instance.
+
+CSS is a Protocoller implementation.
+*/
+func (session *Session) CSS() *CSSProtocol {
+	return session.css
+}
+
+/*
+Database returns the DatabaseProtocol instance.
+
+Database is a Protocoller implementation.
+*/
+func (session *Session) Database() *DatabaseProtocol {
+	return session.database
+}
+
+/*
+Debugger returns the DebuggerProtocol instance.
+
+Debugger is a Protocoller implementation.
+*/
+func (session *Session) Debugger() *DebuggerProtocol {
+	return session.debugger
+}
+
+/*
+DeviceOrientation returns the DeviceOrientationProtocol instance.
+
+DeviceOrientation is a Protocoller implementation.
+*/
+func (session *Session) DeviceOrientation() *DeviceOrientationProtocol {
+	return session.deviceOrientation
+}
+
+/*
+DOMDebugger returns the DOMDebuggerProtocol instance.
+
+DOMDebugger is a Protocoller implementation.
+*/
+func (session *Session) DOMDebugger() *DOMDebuggerProtocol {
+	return session.domDebugger
+}
+
+/*
+DOMSnapshot returns the DOMSnapshotProtocol instance.
+
+DOMSnapshot is a Protocoller implementation.
+*/
+func (session *Session) DOMSnapshot() *DOMSnapshotProtocol {
+	return session.domSnapshot
+}
+
+/*
+DOMStorage returns the DOMStorageProtocol instance.
+
+DOMStorage is a Protocoller implementation.
+*/
+func (session *Session) DOMStorage() *DOMStorageProtocol {
+	return session.domStorage
+}
+
+/*
+DOM returns the DOMProtocol instance.
+
+DOM is a Protocoller implementation.
+*/
+func (session *Session) DOM() *DOMProtocol {
+	return session.dom
+}
+
+/*
+Emulation returns the EmulationProtocol instance.
+
+Emulation is a Protocoller implementation.
+*/
+func (session *Session) Emulation() *EmulationProtocol {
+	return session.emulation
+}
+
+/*
+HeadlessExperimental returns the HeadlessExperimentalProtocol instance.
+
+HeadlessExperimental is a Protocoller implementation.
+*/
+func (session *Session) HeadlessExperimental() *HeadlessExperimentalProtocol {
+	return session.headlessExperimental
+}
+
+/*
+HeapProfiler returns the HeapProfilerProtocol instance.
+
+HeapProfiler is a Protocoller implementation.
+*/
+func (session *Session) HeapProfiler() *HeapProfilerProtocol {
+	return session.heapProfiler
+}
+
+/*
+IndexedDB returns the IndexedDBProtocol instance.
+
+IndexedDB is a Protocoller implementation.
+*/
+func (session *Session) IndexedDB() *IndexedDBProtocol {
+	return session.indexedDB
+}
+
+/*
+Input returns the InputProtocol instance.
+
+Input is a Protocoller implementation.
+*/
+func (session *Session) Input() *InputProtocol {
+	return session.input
+}
+
+/*
+IO returns the IOProtocol instance.
+
+IO is a Protocoller implementation.
+*/
+func (session *Session) IO() *IOProtocol {
+	return session.io
+}
+
+/*
+LayerTree returns the LayerTreeProtocol instance.
+
+LayerTree is a Protocoller implementation.
+*/
+func (session *Session) LayerTree() *LayerTreeProtocol {
+	return session.layerTree
+}
+
+/*
+Log returns the LogProtocol instance.
+
+Log is a Protocoller implementation.
+*/
+func (session *Session) Log() *LogProtocol {
+	return session.log
+}
+
+/*
+Memory returns the MemoryProtocol instance.
+
+Memory is a Protocoller implementation.
+*/
+func (session *Session) Memory() *MemoryProtocol {
+	return session.memory
+}
+
+/*
+Network returns the NetworkProtocol instance.
+
+Network is a Protocoller implementation.
+*/
+func (session *Session) Network() *NetworkProtocol {
+	return session.network
+}
+
+/*
+Overlay returns the OverlayProtocol instance.
+
+Overlay is a Protocoller implementation.
+*/
+func (session *Session) Overlay() *OverlayProtocol {
+	return session.overlay
+}
+
+/*
+Page returns the PageProtocol instance.
+
+Page is a Protocoller implementation.
+*/
+func (session *Session) Page() *PageProtocol {
+	return session.page
+}
+
+/*
+Performance returns the PerformanceProtocol instance.
+
+Performance is a Protocoller implementation.
+*/
+func (session *Session) Performance() *PerformanceProtocol {
+	return session.performance
+}
+
+/*
+Profiler returns the ProfilerProtocol instance.
+
+Profiler is a Protocoller implementation.
+*/
+func (session *Session) Profiler() *ProfilerProtocol {
+	return session.profiler
+}
+
+/*
+Runtime returns the RuntimeProtocol instance.
+
+Runtime is a Protocoller implementation.
+*/
+func (session *Session) Runtime() *RuntimeProtocol {
+	return session.runtime
+}
+
+/*
+Schema returns the SchemaProtocol instance.
+
+Schema is a Protocoller implementation.
+*/
+func (session *Session) Schema() *SchemaProtocol {
+	return session.schema
+}
+
+/*
+Security returns the SecurityProtocol instance.
+
+Security is a Protocoller implementation.
+*/
+func (session *Session) Security() *SecurityProtocol {
+	return session.security
+}
+
+/*
+ServiceWorker returns the ServiceWorkerProtocol instance.
+
+ServiceWorker is a Protocoller implementation.
+*/
+func (session *Session) ServiceWorker() *ServiceWorkerProtocol {
+	return session.serviceWorker
+}
+
+/*
+Storage returns the StorageProtocol instance.
+
+Storage is a Protocoller implementation.
+*/
+func (session *Session) Storage() *StorageProtocol {
+	return session.storage
+}
+
+/*
+SystemInfo returns the SystemInfoProtocol instance.
+
+SystemInfo is a Protocoller implementation.
+*/
+func (session *Session) SystemInfo() *SystemInfoProtocol {
+	return session.systemInfo
+}
+
+/*
+Target returns the TargetProtocol instance.
+
+Target is a Protocoller implementation.
+*/
+func (session *Session) Target() *TargetProtocol {
+	return session.target
+}
+
+/*
+Tethering returns the TetheringProtocol instance.
+
+Tethering is a Protocoller implementation.
+*/
+func (session *Session) Tethering() *TetheringProtocol {
+	return session.tethering
+}
+
+/*
+Tracing returns the TracingProtocol instance.
+
+Tracing is a Protocoller implementation.
+*/
+func (session *Session) Tracing() *TracingProtocol {
+	return session.tracing
+}
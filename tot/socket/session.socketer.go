@@ -0,0 +1,372 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/target"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+/*
+AttachSession attaches to the specified target using a flattened session and
+returns a Session that routes commands and events for the target through this
+socket.
+*/
+func (socket *Socket) AttachSession(targetID target.ID) (*Session, error) {
+	result := <-socket.Target().AttachToTarget(&target.AttachToTargetParams{
+		ID:      targetID,
+		Flatten: true,
+	})
+	if nil != result.Err {
+		return nil, errors.Wrap(result.Err, "attach to target failed")
+	}
+	session := NewSession(socket, result.SessionID)
+	session.targetID = targetID
+	return session, nil
+}
+
+/*
+NewSession returns a pointer to a Session that implements the Socketer and
+Protocoller interfaces for an attached target session. Commands are sent
+through the parent socket with the session ID and responses and events that
+carry the session ID are routed to the session.
+*/
+func NewSession(socket *Socket, sessionID target.SessionID) *Session {
+	session := &Session{
+		commands: NewCommandMap(),
+		handlers: NewEventHandlerMap(),
+		id:       sessionID,
+		socket:   socket,
+	}
+
+	// Init the protocol interfaces for the API.
+	session.accessibility = &AccessibilityProtocol{Socket: session}
+	session.animation = &AnimationProtocol{Socket: session}
+	session.applicationCache = &ApplicationCacheProtocol{Socket: session}
+	session.audits = &AuditsProtocol{Socket: session}
+	session.browser = &BrowserProtocol{Socket: session}
+	session.cacheStorage = &CacheStorageProtocol{Socket: session}
+	session.console = &ConsoleProtocol{Socket: session}
+	session.css = &CSSProtocol{Socket: session}
+	session.database = &DatabaseProtocol{Socket: session}
+	session.debugger = &DebuggerProtocol{Socket: session}
+	session.deviceOrientation = &DeviceOrientationProtocol{Socket: session}
+	session.domDebugger = &DOMDebuggerProtocol{Socket: session}
+	session.domSnapshot = &DOMSnapshotProtocol{Socket: session}
+	session.domStorage = &DOMStorageProtocol{Socket: session}
+	session.dom = &DOMProtocol{Socket: session}
+	session.emulation = &EmulationProtocol{Socket: session}
+	session.headlessExperimental = &HeadlessExperimentalProtocol{Socket: session}
+	session.heapProfiler = &HeapProfilerProtocol{Socket: session}
+	session.indexedDB = &IndexedDBProtocol{Socket: session}
+	session.input = &InputProtocol{Socket: session}
+	session.io = &IOProtocol{Socket: session}
+	session.layerTree = &LayerTreeProtocol{Socket: session}
+	session.log = &LogProtocol{Socket: session}
+	session.memory = &MemoryProtocol{Socket: session}
+	session.network = &NetworkProtocol{Socket: session}
+	session.overlay = &OverlayProtocol{Socket: session}
+	session.page = &PageProtocol{Socket: session}
+	session.performance = &PerformanceProtocol{Socket: session}
+	session.profiler = &ProfilerProtocol{Socket: session}
+	session.runtime = &RuntimeProtocol{Socket: session}
+	session.schema = &SchemaProtocol{Socket: session}
+	session.security = &SecurityProtocol{Socket: session}
+	session.serviceWorker = &ServiceWorkerProtocol{Socket: session}
+	session.storage = &StorageProtocol{Socket: session}
+	session.systemInfo = &SystemInfoProtocol{Socket: session}
+	session.target = &TargetProtocol{Socket: session}
+	session.tethering = &TetheringProtocol{Socket: session}
+	session.tracing = &TracingProtocol{Socket: session}
+
+	socket.addSession(session)
+	log.Infof("socket #%d - New session %s attached", socket.socketID, sessionID)
+
+	return session
+}
+
+/*
+Session is a Socketer implementation for a target session that is multiplexed
+over a parent socket connection.
+*/
+type Session struct {
+	commands CommandMapper
+	handlers EventHandlerMapper
+	id       target.SessionID
+	socket   *Socket
+	targetID target.ID
+
+	// Protocol interfaces for the API.
+	accessibility        *AccessibilityProtocol
+	animation            *AnimationProtocol
+	applicationCache     *ApplicationCacheProtocol
+	audits               *AuditsProtocol
+	browser              *BrowserProtocol
+	cacheStorage         *CacheStorageProtocol
+	console              *ConsoleProtocol
+	css                  *CSSProtocol
+	database             *DatabaseProtocol
+	debugger             *DebuggerProtocol
+	deviceOrientation    *DeviceOrientationProtocol
+	domDebugger          *DOMDebuggerProtocol
+	domSnapshot          *DOMSnapshotProtocol
+	domStorage           *DOMStorageProtocol
+	dom                  *DOMProtocol
+	emulation            *EmulationProtocol
+	headlessExperimental *HeadlessExperimentalProtocol
+	heapProfiler         *HeapProfilerProtocol
+	indexedDB            *IndexedDBProtocol
+	input                *InputProtocol
+	io                   *IOProtocol
+	layerTree            *LayerTreeProtocol
+	log                  *LogProtocol
+	memory               *MemoryProtocol
+	network              *NetworkProtocol
+	overlay              *OverlayProtocol
+	page                 *PageProtocol
+	performance          *PerformanceProtocol
+	profiler             *ProfilerProtocol
+	runtime              *RuntimeProtocol
+	schema               *SchemaProtocol
+	security             *SecurityProtocol
+	serviceWorker        *ServiceWorkerProtocol
+	storage              *StorageProtocol
+	systemInfo           *SystemInfoProtocol
+	target               *TargetProtocol
+	tethering            *TetheringProtocol
+	tracing              *TracingProtocol
+}
+
+/*
+AddEventHandler adds an event handler to the stack of listeners for an event.
+
+AddEventHandler is a Socketer implementation.
+*/
+func (session *Session) AddEventHandler(
+	handler EventHandler,
+) {
+	session.handlers.Add(handler)
+}
+
+/*
+CurCommandID returns the latest command ID.
+
+CurCommandID is a Socketer implementation.
+*/
+func (session *Session) CurCommandID() int {
+	return session.socket.CurCommandID()
+}
+
+/*
+handleMessage receives the responses and events routed to the session by the
+parent socket.
+*/
+func (session *Session) handleMessage(response *Response) {
+	if response.ID > 0 {
+		command, err := session.commands.Get(response.ID)
+		if nil != err {
+			log.Debugf("socket #%d - session %s: %s", session.socket.socketID, session.id, err.Error())
+			return
+		}
+		command.Respond(response)
+		session.commands.Delete(command.ID())
+		return
+	}
+
+	session.handlers.Lock()
+	handlers, err := session.handlers.Get(response.Method)
+	session.handlers.Unlock()
+	if nil != err {
+		log.Debugf("socket #%d - session %s: %s", session.socket.socketID, session.id, err.Error())
+		return
+	}
+	for _, handler := range handlers {
+		session.socket.dispatch(session.handlers, handler, response)
+	}
+}
+
+/*
+ID returns the target session ID.
+*/
+func (session *Session) ID() target.SessionID {
+	return session.id
+}
+
+/*
+Listen is a no-op, the parent socket read loop delivers messages to the
+session.
+
+Listen is a Socketer implementation.
+*/
+func (session *Session) Listen() error {
+	return nil
+}
+
+/*
+NextCommandID generates and returns the next command ID. Session commands share
+the parent socket's command ID sequence.
+
+NextCommandID is a Socketer implementation.
+*/
+func (session *Session) NextCommandID() int {
+	return session.socket.NextCommandID()
+}
+
+/*
+RemoveEventHandler removes a handler from the stack of listeners for an event.
+
+RemoveEventHandler is a Socketer implementation.
+*/
+func (session *Session) RemoveEventHandler(
+	handler EventHandler,
+) error {
+	err := session.handlers.Remove(handler)
+	if nil == err {
+		session.socket.removeQueue(handler)
+	}
+	return err
+}
+
+/*
+SendCommand delivers a command payload addressed to the target session to the
+parent websocket connection.
+
+SendCommand is a Socketer implementation.
+*/
+func (session *Session) SendCommand(command Commander) chan *Response {
+	log.Debugf(
+		"socket #%d - session.SendCommand(): sending command #%d (%s) payload to session %s",
+		session.socket.socketID,
+		command.ID(),
+		command.Method(),
+		session.id,
+	)
+	return session.socket.sendCommand(session.commands, command, string(session.id))
+}
+
+/*
+Socket returns the parent socket.
+*/
+func (session *Session) Socket() *Socket {
+	return session.socket
+}
+
+/*
+Stop detaches the session from its target. Pending session commands fail with a
+DisconnectedError.
+
+Stop is a Socketer implementation.
+*/
+func (session *Session) Stop() {
+	session.close(errors.New("session detached"))
+	go func() {
+		result := <-session.socket.Target().DetachFromTarget(&target.DetachFromTargetParams{
+			SessionID: session.id,
+		})
+		if nil != result.Err {
+			log.Warnf("socket #%d - session %s: detach failed: %s", session.socket.socketID, session.id, result.Err.Error())
+		}
+	}()
+}
+
+/*
+TargetID returns the ID of the attached target, if known.
+*/
+func (session *Session) TargetID() target.ID {
+	return session.targetID
+}
+
+/*
+URL returns the URL of the parent websocket connection.
+
+URL is a Socketer implementation.
+*/
+func (session *Session) URL() *url.URL {
+	return session.socket.URL()
+}
+
+/*
+close unregisters the session from the parent socket and fails any pending
+commands.
+*/
+func (session *Session) close(err error) {
+	session.socket.removeSession(session.id)
+	session.socket.failCommands(session.commands, err)
+}
+
+/*
+addSession registers a session so messages carrying its session ID are routed
+to it.
+*/
+func (socket *Socket) addSession(session *Session) {
+	socket.sessionMux.Lock()
+	socket.sessions[string(session.id)] = session
+	socket.sessionMux.Unlock()
+}
+
+/*
+closeSessions closes every attached session. Sessions do not survive the loss
+of the parent connection.
+*/
+func (socket *Socket) closeSessions(err error) {
+	socket.sessionMux.Lock()
+	sessions := make([]*Session, 0, len(socket.sessions))
+	for _, session := range socket.sessions {
+		sessions = append(sessions, session)
+	}
+	socket.sessionMux.Unlock()
+
+	for _, session := range sessions {
+		session.close(err)
+	}
+}
+
+/*
+handleDetached closes the session named in a Target.detachedFromTarget event.
+*/
+func (socket *Socket) handleDetached(response *Response) {
+	event := &target.DetachedFromTargetEvent{}
+	if err := json.Unmarshal([]byte(response.Result), event); nil != err {
+		return
+	}
+	if session := socket.session(string(event.SessionID)); nil != session {
+		log.Infof("socket #%d - Session %s detached from target", socket.socketID, event.SessionID)
+		session.close(errors.New("session detached"))
+	}
+}
+
+/*
+removeSession unregisters a session.
+*/
+func (socket *Socket) removeSession(sessionID target.SessionID) {
+	socket.sessionMux.Lock()
+	delete(socket.sessions, string(sessionID))
+	socket.sessionMux.Unlock()
+}
+
+/*
+session returns the attached session with the specified ID, if any.
+*/
+func (socket *Socket) session(sessionID string) *Session {
+	if "" == sessionID {
+		return nil
+	}
+	socket.sessionMux.Lock()
+	defer socket.sessionMux.Unlock()
+	return socket.sessions[sessionID]
+}
+
+/*
+Sessions returns the target sessions attached through this socket.
+*/
+func (socket *Socket) Sessions() []*Session {
+	socket.sessionMux.Lock()
+	defer socket.sessionMux.Unlock()
+	sessions := make([]*Session, 0, len(socket.sessions))
+	for _, session := range socket.sessions {
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
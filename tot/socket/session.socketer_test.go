@@ -0,0 +1,155 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/page"
+	"github.com/mkenney/go-chrome/tot/cdtp/target"
+)
+
+func TestAttachSession(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/session")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	sessionChan := make(chan *Session)
+	go func() {
+		session, err := mockSocket.AttachSession(target.ID("target-id"))
+		if nil != err {
+			t.Errorf("Expected nil, got error: '%s'", err.Error())
+		}
+		sessionChan <- session
+	}()
+	time.Sleep(50 * time.Millisecond)
+	mockResultBytes, _ := json.Marshal(&target.AttachToTargetResult{
+		SessionID: target.SessionID("session-id"),
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: mockResultBytes,
+	})
+
+	session := <-sessionChan
+	if nil == session {
+		t.Fatalf("Expected a session, got nil")
+	}
+	if target.SessionID("session-id") != session.ID() {
+		t.Errorf("Expected session-id, got %s", session.ID())
+	}
+	if target.ID("target-id") != session.TargetID() {
+		t.Errorf("Expected target-id, got %s", session.TargetID())
+	}
+	if session != mockSocket.session("session-id") {
+		t.Errorf("Expected the session to be registered with the socket")
+	}
+}
+
+func TestSessionCommand(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/session")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	session := NewSession(mockSocket, target.SessionID("session-id"))
+	resultChan := session.Page().Navigate(&page.NavigateParams{
+		URL: "https://www.example.com/",
+	})
+	mockResultBytes, _ := json.Marshal(&page.NavigateResult{
+		FrameID: page.FrameID("frame-id"),
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:        mockSocket.CurCommandID(),
+		Error:     &Error{},
+		Result:    mockResultBytes,
+		SessionID: "session-id",
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+	if page.FrameID("frame-id") != result.FrameID {
+		t.Errorf("Expected frame-id, got %s", result.FrameID)
+	}
+
+	written := mockSocket.Conn().(*MockChromeWebSocket).written
+	if 0 == len(written) || "session-id" != written[len(written)-1].SessionID {
+		t.Errorf("Expected the command payload to carry the session ID")
+	}
+}
+
+func TestSessionEvent(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/session")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	session := NewSession(mockSocket, target.SessionID("session-id"))
+	sessionEvents := make(chan *page.LoadEventFiredEvent, 1)
+	session.Page().OnLoadEventFired(func(event *page.LoadEventFiredEvent) {
+		sessionEvents <- event
+	})
+	socketEvents := make(chan *page.LoadEventFiredEvent, 1)
+	mockSocket.Page().OnLoadEventFired(func(event *page.LoadEventFiredEvent) {
+		socketEvents <- event
+	})
+
+	mockResult := &page.LoadEventFiredEvent{
+		Timestamp: page.MonotonicTime(time.Now().Unix()),
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		Method:    "Page.loadEventFired",
+		Params:    mockResultBytes,
+		SessionID: "session-id",
+	})
+
+	select {
+	case event := <-sessionEvents:
+		if mockResult.Timestamp != event.Timestamp {
+			t.Errorf("Expected %d, got %d", mockResult.Timestamp, event.Timestamp)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the session to receive the event")
+	}
+	select {
+	case <-socketEvents:
+		t.Errorf("Expected the parent socket not to receive the session event")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSessionDetached(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/session")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	session := NewSession(mockSocket, target.SessionID("session-id"))
+	pending := NewCommand(session, "Some.method", nil)
+	resultChan := session.SendCommand(pending)
+
+	mockResultBytes, _ := json.Marshal(&target.DetachedFromTargetEvent{
+		SessionID: target.SessionID("session-id"),
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		Method: "Target.detachedFromTarget",
+		Params: mockResultBytes,
+	})
+
+	select {
+	case result := <-resultChan:
+		if _, ok := result.Err().(*DisconnectedError); !ok {
+			t.Errorf("Expected *DisconnectedError, got %T: %v", result.Err(), result.Err())
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the pending session command to fail")
+	}
+	if nil != mockSocket.session("session-id") {
+		t.Errorf("Expected the session to be removed")
+	}
+}
@@ -2,6 +2,7 @@ package socket
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 )
 
@@ -51,6 +52,25 @@ func (stack *CommandMap) Get(id int) (Commander, error) {
 	return command, nil
 }
 
+/*
+List returns all commands in the stack ordered by command ID.
+
+List is a CommandMapper implementation.
+*/
+func (stack *CommandMap) List() []Commander {
+	stack.mux.Lock()
+	commands := make([]Commander, 0, len(stack.stack))
+	for _, command := range stack.stack {
+		commands = append(commands, command)
+	}
+	stack.mux.Unlock()
+
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].ID() < commands[j].ID()
+	})
+	return commands
+}
+
 /*
 Set sets a command in the stack.
 
@@ -1,6 +1,7 @@
 package socket
 
 import (
+	"net/url"
 	"testing"
 )
 
@@ -11,3 +12,27 @@ func TestSocketCommandMapperError(t *testing.T) {
 		t.Errorf("Expected nil, got error: '%s'", err.Error())
 	}
 }
+
+func TestSocketCommandMapperList(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/mock")
+	mockSocket := NewMock(socketURL)
+	commandMap := NewCommandMap()
+	commands := []*Command{
+		NewCommand(mockSocket, "Some.method", nil),
+		NewCommand(mockSocket, "Some.method", nil),
+		NewCommand(mockSocket, "Some.method", nil),
+	}
+	commandMap.Set(commands[2])
+	commandMap.Set(commands[0])
+	commandMap.Set(commands[1])
+
+	list := commandMap.List()
+	if 3 != len(list) {
+		t.Fatalf("Expected 3 commands, got %d", len(list))
+	}
+	for a, command := range commands {
+		if command.ID() != list[a].ID() {
+			t.Errorf("Expected command #%d, got #%d", command.ID(), list[a].ID())
+		}
+	}
+}
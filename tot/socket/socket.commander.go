@@ -1,11 +1,34 @@
 package socket
 
+import (
+	"context"
+	"sync"
+	"time"
+)
+
 /*
 NewCommand creates and returns a pointer to a struct that implements the
 Commander interface.
 */
 func NewCommand(socket Socketer, method string, params interface{}) *Command {
+	return NewCommandWithContext(context.Background(), socket, method, params)
+}
+
+/*
+NewCommandWithContext creates and returns a pointer to a struct that implements
+the Commander interface bound to the specified context. If the context is done
+before the socket responds the command is abandoned and a CanceledError is
+delivered instead.
+*/
+func NewCommandWithContext(
+	ctx context.Context,
+	socket Socketer,
+	method string,
+	params interface{},
+) *Command {
 	return &Command{
+		ctx:      ctx,
+		done:     make(chan struct{}),
 		id:       socket.NextCommandID(),
 		method:   method,
 		params:   params,
@@ -18,6 +41,12 @@ func NewCommand(socket Socketer, method string, params interface{}) *Command {
 Command provides a Commander interface for sending commands to a websocket.
 */
 type Command struct {
+	// ctx is the context the command is bound to.
+	ctx context.Context
+
+	// done is closed once the command has received a response.
+	done chan struct{}
+
 	// err contains any error resulting from executing the command.
 	err error
 
@@ -35,8 +64,35 @@ type Command struct {
 	// command is complete.
 	response chan *Response
 
+	// respondOnce ensures only the first response is delivered.
+	respondOnce sync.Once
+
 	// socket contains the Socketer instance
 	socket Socketer
+
+	// Optional. timeout overrides the socket default timeout for this command.
+	timeout time.Duration
+}
+
+/*
+Context returns the context the command is bound to.
+
+Context is a Commander implementation.
+*/
+func (cmd *Command) Context() context.Context {
+	if nil == cmd.ctx {
+		return context.Background()
+	}
+	return cmd.ctx
+}
+
+/*
+Done returns a channel that is closed once the command has received a response.
+
+Done is a Commander implementation.
+*/
+func (cmd *Command) Done() <-chan struct{} {
+	return cmd.done
 }
 
 /*
@@ -76,12 +132,16 @@ func (cmd *Command) Params() interface{} {
 }
 
 /*
-Respond sends a response to the command response channel.
+Respond sends a response to the command response channel. Only the first
+response is delivered, any subsequent responses are discarded.
 
 Respond is a Commander implementation.
 */
 func (cmd *Command) Respond(response *Response) {
-	cmd.response <- response
+	cmd.respondOnce.Do(func() {
+		cmd.response <- response
+		close(cmd.done)
+	})
 }
 
 /*
@@ -93,6 +153,15 @@ func (cmd *Command) Response() chan *Response {
 	return cmd.response
 }
 
+/*
+SetContext sets the context the command is bound to.
+
+SetContext is a Commander implementation.
+*/
+func (cmd *Command) SetContext(ctx context.Context) {
+	cmd.ctx = ctx
+}
+
 /*
 SetError sets the error value
 
@@ -110,3 +179,22 @@ SetID is a Commander implementation.
 func (cmd *Command) SetID(id int) {
 	cmd.id = id
 }
+
+/*
+SetTimeout sets the command timeout, overriding the socket default.
+
+SetTimeout is a Commander implementation.
+*/
+func (cmd *Command) SetTimeout(timeout time.Duration) {
+	cmd.timeout = timeout
+}
+
+/*
+Timeout returns the command timeout. A zero value defers to the socket default
+timeout and a negative value disables the timeout.
+
+Timeout is a Commander implementation.
+*/
+func (cmd *Command) Timeout() time.Duration {
+	return cmd.timeout
+}
@@ -1,6 +1,7 @@
 package socket
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"testing"
@@ -84,3 +85,33 @@ func TestCommanderError(t *testing.T) {
 		t.Errorf("Expected '%s', got '%s'", err.Error(), cmd.Error().Error())
 	}
 }
+
+func TestCommanderRespondOnce(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/mock")
+	cmd := NewCommand(NewMock(socketURL), "Some.method", nil)
+
+	go func() {
+		cmd.Respond(&Response{ID: 1})
+		cmd.Respond(&Response{ID: 2})
+	}()
+	result := <-cmd.Response()
+	<-cmd.Done()
+	if 1 != result.ID {
+		t.Errorf("Expected 1, got %d", result.ID)
+	}
+}
+
+func TestCommanderContext(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/mock")
+	cmd := NewCommand(NewMock(socketURL), "Some.method", nil)
+	if context.Background() != cmd.Context() {
+		t.Errorf("Expected background context, got %v", cmd.Context())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cmd.SetContext(ctx)
+	if ctx != cmd.Context() {
+		t.Errorf("Expected %v, got %v", ctx, cmd.Context())
+	}
+}
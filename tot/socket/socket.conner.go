@@ -2,6 +2,7 @@ package socket
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -13,8 +14,8 @@ Conn returns the current web socket pointer.
 Conn is a Conner implementation.
 */
 func (socket *Socket) Conn() WebSocketer {
-	socket.Connect()
-	return socket.conn
+	conn, _ := socket.connection()
+	return conn
 }
 
 /*
@@ -24,9 +25,8 @@ Connect is a Conner implementation.
 */
 func (socket *Socket) Connect() error {
 	socket.mux.Lock()
-	defer socket.mux.Unlock()
-
 	if socket.connected {
+		socket.mux.Unlock()
 		return nil
 	}
 
@@ -35,12 +35,16 @@ func (socket *Socket) Connect() error {
 	if nil != err {
 		log.Debugf("socket #%d - socket.Connect(): received error %s", socket.socketID, err.Error())
 		socket.connected = false
+		socket.mux.Unlock()
 		return errors.Wrap(err, "creating socket failed")
 	}
 	socket.conn = websocket
+	socket.connected = true
+	socket.dialed = true
+	socket.mux.Unlock()
 
 	log.Debugf("socket #%d - socket.Connect(): connection to %s established", socket.socketID, socket.url.String())
-	socket.connected = true
+	socket.emitConnectionState(ConnectionState.Connected, 0, nil)
 
 	return nil
 }
@@ -51,21 +55,56 @@ Connected returns whether a connection exists.
 Connected is a Conner implementation.
 */
 func (socket *Socket) Connected() bool {
+	socket.mux.Lock()
+	defer socket.mux.Unlock()
 	return socket.connected
 }
 
+/*
+connection returns the current websocket connection. The first call connects
+the socket, a lost connection is only re-established by Reconnect().
+*/
+func (socket *Socket) connection() (WebSocketer, error) {
+	socket.mux.Lock()
+	conn, dialed := socket.conn, socket.dialed
+	socket.mux.Unlock()
+	if nil != conn {
+		return conn, nil
+	}
+	if dialed {
+		return nil, fmt.Errorf("not connected")
+	}
+
+	if err := socket.Connect(); nil != err {
+		return nil, errors.Wrap(err, "socket connect failed")
+	}
+	socket.mux.Lock()
+	conn = socket.conn
+	socket.mux.Unlock()
+	if nil == conn {
+		return nil, fmt.Errorf("not connected")
+	}
+	return conn, nil
+}
+
 /*
 Disconnect closes a websocket connection.
 
 Disconnect is a Conner implementation.
 */
 func (socket *Socket) Disconnect() error {
+	socket.mux.Lock()
 	if !socket.connected {
+		socket.mux.Unlock()
 		return fmt.Errorf("not connected")
 	}
-	err := socket.conn.Close()
+	conn := socket.conn
 	socket.conn = nil
 	socket.connected = false
+	socket.mux.Unlock()
+
+	err := conn.Close()
+	socket.emitConnectionState(ConnectionState.Disconnected, 0, nil)
 	return errors.Wrap(err, "disconnect failed")
 }
 
@@ -75,11 +114,154 @@ ReadJSON reads data from a websocket connection.
 ReadJSON is a Conner implementation.
 */
 func (socket *Socket) ReadJSON(v interface{}) error {
-	err := socket.Connect()
+	conn, err := socket.connection()
 	if nil != err {
-		return errors.Wrap(err, "socket connect failed")
+		return err
+	}
+	return conn.ReadJSON(&v)
+}
+
+/*
+Reconnect re-establishes a lost websocket connection to the same URL according
+to the reconnect policy. Previously enabled domains are enabled again and
+pending commands are either replayed in command ID order or failed with a
+DisconnectedError. If every attempt fails the socket is stopped and any pending
+commands are failed.
+
+Reconnect is a Conner implementation.
+*/
+func (socket *Socket) Reconnect() error {
+	policy := socket.ReconnectPolicy()
+	if nil == policy {
+		policy = &ReconnectPolicy{MaxAttempts: 1}
+	}
+
+	socket.setReconnecting(policy.Replay)
+	defer socket.setReconnecting(false)
+
+	if socket.Connected() {
+		socket.Disconnect()
+	}
+	socket.closeSessions(errors.New("connection lost"))
+	if !policy.Replay {
+		socket.failPending(errors.New("connection lost"))
+	}
+
+	var err error
+	for attempt := 1; 0 == policy.MaxAttempts || attempt <= policy.MaxAttempts; attempt++ {
+		socket.emitConnectionState(ConnectionState.Reconnecting, attempt, err)
+		time.Sleep(policy.delay(attempt))
+		if socket.stopped() {
+			err = errors.New("socket stopped")
+			break
+		}
+
+		log.Infof("socket #%d - socket.Reconnect(): reconnect attempt #%d to %s", socket.socketID, attempt, socket.url.String())
+		if err = socket.Connect(); nil == err {
+			pending := socket.commands.List()
+			socket.restoreDomains()
+			socket.replayPending(pending)
+			return nil
+		}
+	}
+
+	log.Errorf("socket #%d - socket.Reconnect(): giving up on %s: %s", socket.socketID, socket.url.String(), err)
+	socket.setReconnecting(false)
+	socket.failPending(err)
+	socket.emitConnectionState(ConnectionState.Closed, 0, err)
+	return errors.Wrap(err, "reconnect failed")
+}
+
+/*
+replaying returns whether a reconnect is in progress that will replay pending
+commands.
+*/
+func (socket *Socket) replaying() bool {
+	socket.mux.Lock()
+	defer socket.mux.Unlock()
+	return socket.reconnecting
+}
+
+/*
+setReconnecting sets whether commands written while disconnected are left
+pending for Reconnect() to replay.
+*/
+func (socket *Socket) setReconnecting(reconnecting bool) {
+	socket.mux.Lock()
+	defer socket.mux.Unlock()
+	socket.reconnecting = reconnecting
+}
+
+/*
+failPending fails all pending commands with a DisconnectedError in command ID
+order.
+*/
+func (socket *Socket) failPending(err error) {
+	socket.failCommands(socket.commands, err)
+}
+
+/*
+failCommands fails all commands in the specified stack with a DisconnectedError
+in command ID order.
+*/
+func (socket *Socket) failCommands(commands CommandMapper, err error) {
+	for _, command := range commands.List() {
+		socket.abandonCommand(commands, command, &DisconnectedError{
+			Err:    err,
+			ID:     command.ID(),
+			Method: command.Method(),
+		})
+	}
+}
+
+/*
+replayPending re-sends the pending commands in command ID order.
+*/
+func (socket *Socket) replayPending(pending []Commander) {
+	for _, command := range pending {
+		log.Debugf("socket #%d - socket.replayPending(): replaying command #%d (%s)", socket.socketID, command.ID(), command.Method())
+		if err := socket.WriteJSON(&Payload{
+			ID:     command.ID(),
+			Method: command.Method(),
+			Params: command.Params(),
+		}); nil != err {
+			socket.abandonCommand(socket.commands, command, &DisconnectedError{
+				Err:    err,
+				ID:     command.ID(),
+				Method: command.Method(),
+			})
+		}
+	}
+}
+
+/*
+restoreDomains re-sends the enable command for every domain that was enabled
+before the connection was lost, in the order they were originally enabled.
+*/
+func (socket *Socket) restoreDomains() {
+	socket.mux.Lock()
+	enabled := make([]*Payload, len(socket.enabled))
+	copy(enabled, socket.enabled)
+	socket.mux.Unlock()
+
+	for _, payload := range enabled {
+		command := NewCommand(socket, payload.Method, payload.Params)
+		socket.commands.Set(command)
+		if err := socket.WriteJSON(&Payload{
+			ID:     command.ID(),
+			Method: command.Method(),
+			Params: command.Params(),
+		}); nil != err {
+			socket.commands.Delete(command.ID())
+			log.Warnf("socket #%d - socket.restoreDomains(): %s failed: %s", socket.socketID, command.Method(), err.Error())
+			continue
+		}
+		go func() {
+			if err := (<-command.Response()).Err(); nil != err {
+				log.Warnf("socket #%d - socket.restoreDomains(): %s failed: %s", socket.socketID, command.Method(), err.Error())
+			}
+		}()
 	}
-	return socket.conn.ReadJSON(&v)
 }
 
 /*
@@ -88,9 +270,9 @@ WriteJSON writes data to a websocket connection.
 WriteJSON is a Conner implementation.
 */
 func (socket *Socket) WriteJSON(v interface{}) error {
-	err := socket.Connect()
+	conn, err := socket.connection()
 	if nil != err {
-		return errors.Wrap(err, "socket connect failed")
+		return err
 	}
-	return socket.conn.WriteJSON(v)
+	return conn.WriteJSON(v)
 }
@@ -3,6 +3,9 @@ package socket
 import (
 	"net/url"
 	"testing"
+	"time"
+
+	"github.com/pkg/errors"
 )
 
 func TestConner(t *testing.T) {
@@ -24,3 +27,155 @@ func TestConner(t *testing.T) {
 		t.Errorf("Expected nil, got error: '%s'", err.Error())
 	}
 }
+
+func TestReconnectReplay(t *testing.T) {
+	socketURL, _ := url.Parse("http://www.example.com/")
+	socket := NewMock(socketURL)
+	socket.SetReconnectPolicy(&ReconnectPolicy{
+		Backoff: time.Millisecond,
+		Replay:  true,
+	})
+	socket.Connect()
+
+	socket.trackDomain(NewCommand(socket, "Page.enable", nil), &Response{})
+	socket.trackDomain(NewCommand(socket, "Network.enable", nil), &Response{})
+	socket.trackDomain(NewCommand(socket, "Network.disable", nil), &Response{})
+	pending1 := NewCommand(socket, "Some.method", nil)
+	pending2 := NewCommand(socket, "Other.method", nil)
+	socket.commands.Set(pending2)
+	socket.commands.Set(pending1)
+
+	if err := socket.Reconnect(); nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	if !socket.Connected() {
+		t.Errorf("Expected true, got false")
+	}
+
+	expected := []string{"Page.enable", "Some.method", "Other.method"}
+	written := socket.Conn().(*MockChromeWebSocket).written
+	if len(expected) != len(written) {
+		t.Fatalf("Expected %d payloads, got %d", len(expected), len(written))
+	}
+	for a, method := range expected {
+		if method != written[a].Method {
+			t.Errorf("Expected %s, got %s", method, written[a].Method)
+		}
+	}
+	if pending1.ID() != written[1].ID {
+		t.Errorf("Expected command #%d to be replayed, got #%d", pending1.ID(), written[1].ID)
+	}
+}
+
+func TestReconnectFailPending(t *testing.T) {
+	socketURL, _ := url.Parse("http://www.example.com/")
+	socket := NewMock(socketURL)
+	socket.SetReconnectPolicy(&ReconnectPolicy{
+		Backoff: time.Millisecond,
+	})
+	socket.Connect()
+
+	pending := NewCommand(socket, "Some.method", nil)
+	socket.commands.Set(pending)
+	resultChan := make(chan *Response)
+	go func() {
+		resultChan <- <-pending.Response()
+	}()
+
+	if err := socket.Reconnect(); nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	result := <-resultChan
+	if _, ok := result.Err().(*DisconnectedError); !ok {
+		t.Errorf("Expected *DisconnectedError, got %T: %v", result.Err(), result.Err())
+	}
+	if 0 != len(socket.Conn().(*MockChromeWebSocket).written) {
+		t.Errorf("Expected no replayed commands")
+	}
+}
+
+func TestReconnectGiveUp(t *testing.T) {
+	socketURL, _ := url.Parse("http://www.example.com/")
+	socket := NewMock(socketURL)
+	socket.SetReconnectPolicy(&ReconnectPolicy{
+		Backoff:     time.Millisecond,
+		MaxAttempts: 2,
+		Replay:      true,
+	})
+	socket.Connect()
+	socket.newSocket = func(socketURL *url.URL) (WebSocketer, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	states := make(chan ConnectionStateEnum, 10)
+	socket.OnConnectionStateChanged(func(event *ConnectionStateChangedEvent) {
+		states <- event.State
+	})
+
+	pending := NewCommand(socket, "Some.method", nil)
+	socket.commands.Set(pending)
+	resultChan := make(chan *Response)
+	go func() {
+		resultChan <- <-pending.Response()
+	}()
+
+	if err := socket.Reconnect(); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+	result := <-resultChan
+	if _, ok := result.Err().(*DisconnectedError); !ok {
+		t.Errorf("Expected *DisconnectedError, got %T: %v", result.Err(), result.Err())
+	}
+
+	closed := false
+	for !closed {
+		select {
+		case state := <-states:
+			closed = ConnectionState.Closed == state
+		case <-time.After(time.Second):
+			t.Fatalf("Expected a closed connection state event")
+		}
+	}
+}
+
+func TestConnectInlineStateHandler(t *testing.T) {
+	socketURL, _ := url.Parse("http://www.example.com/")
+	socket := NewMock(socketURL)
+
+	connected := make(chan bool, 1)
+	handler := NewEventHandler("Socket.connectionStateChanged", func(response *Response) {
+		connected <- socket.Connected()
+	})
+	handler.setInline()
+	socket.AddEventHandler(handler)
+
+	done := make(chan error)
+	go func() {
+		done <- socket.Connect()
+	}()
+	select {
+	case err := <-done:
+		if nil != err {
+			t.Fatalf("Expected nil, got error: '%s'", err.Error())
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Connect() deadlocked calling an inline state handler")
+	}
+	if !<-connected {
+		t.Errorf("Expected true, got false")
+	}
+}
+
+func TestWriteJSONAfterDisconnect(t *testing.T) {
+	socketURL, _ := url.Parse("http://www.example.com/")
+	socket := NewMock(socketURL)
+	socket.Connect()
+	socket.Disconnect()
+
+	if err := socket.WriteJSON(&Payload{ID: 1, Method: "Page.enable"}); nil == err {
+		t.Errorf("Expected error, got nil")
+	}
+	if socket.Connected() {
+		t.Errorf("Expected WriteJSON not to reconnect a lost connection")
+	}
+}
@@ -0,0 +1,62 @@
+package socket
+
+import (
+	"context"
+)
+
+/*
+WithContext returns a Socketer that binds every command sent through it to the
+specified context. Commands that have not received a response when the context
+is done are removed from the command stack and receive a CanceledError. The
+context replaces any context the socket was previously bound to.
+*/
+func WithContext(ctx context.Context, socket Socketer) Socketer {
+	return &ContextSocket{
+		Socketer: withoutContext(socket),
+		ctx:      ctx,
+	}
+}
+
+/*
+withoutContext removes the ContextSocket wrappers from a chain of socket
+wrappers so an inner context can't override an outer one.
+*/
+func withoutContext(socket Socketer) Socketer {
+	switch wrapper := socket.(type) {
+	case *ContextSocket:
+		return withoutContext(wrapper.Socketer)
+	case *TimeoutSocket:
+		return &TimeoutSocket{
+			Socketer: withoutContext(wrapper.Socketer),
+			timeout:  wrapper.timeout,
+		}
+	}
+	return socket
+}
+
+/*
+ContextSocket provides a Socketer interface that binds commands to a context
+before delivering them to the underlying socket.
+*/
+type ContextSocket struct {
+	Socketer
+	ctx context.Context
+}
+
+/*
+Context returns the context commands are bound to.
+*/
+func (socket *ContextSocket) Context() context.Context {
+	return socket.ctx
+}
+
+/*
+SendCommand binds the command to the socket context and delivers it to the
+underlying socket.
+
+SendCommand is a Socketer implementation.
+*/
+func (socket *ContextSocket) SendCommand(command Commander) chan *Response {
+	command.SetContext(socket.ctx)
+	return socket.Socketer.SendCommand(command)
+}
@@ -0,0 +1,101 @@
+package socket
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/page"
+)
+
+func TestWithContextCancel(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/context")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	resultChan := mockSocket.Page().WithContext(ctx).Navigate(&page.NavigateParams{
+		URL: "https://www.example.com/",
+	})
+	cancel()
+
+	result := <-resultChan
+	err, ok := result.Err.(*CanceledError)
+	if !ok {
+		t.Fatalf("Expected *CanceledError, got %T: %v", result.Err, result.Err)
+	}
+	if context.Canceled != err.Err {
+		t.Errorf("Expected %v, got %v", context.Canceled, err.Err)
+	}
+	if "Page.navigate" != err.Method {
+		t.Errorf("Expected Page.navigate, got %s", err.Method)
+	}
+	if _, e := mockSocket.commands.Get(err.ID); nil == e {
+		t.Errorf("Expected command #%d to be removed from the command stack", err.ID)
+	}
+}
+
+func TestWithContextDeadline(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/context")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	command := NewCommand(mockSocket, "Some.method", nil)
+	result := <-WithContext(ctx, mockSocket).SendCommand(command)
+	err, ok := result.Err().(*CanceledError)
+	if !ok {
+		t.Fatalf("Expected *CanceledError, got %T: %v", result.Err(), result.Err())
+	}
+	if context.DeadlineExceeded != err.Err {
+		t.Errorf("Expected %v, got %v", context.DeadlineExceeded, err.Err)
+	}
+	if command.ID() != err.ID {
+		t.Errorf("Expected %d, got %d", command.ID(), err.ID)
+	}
+}
+
+func TestWithContextResponse(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/context")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resultChan := mockSocket.Page().WithContext(ctx).BringToFront()
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: []byte(`{}`),
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+}
+
+func TestWithContextNested(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/context")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	socket := WithContext(
+		context.Background(),
+		WithTimeout(50*time.Millisecond, WithContext(canceled, mockSocket)),
+	)
+	result := <-socket.SendCommand(NewCommand(mockSocket, "Some.method", nil))
+	if _, ok := result.Err().(*TimeoutError); !ok {
+		t.Errorf("Expected *TimeoutError, got %T: %v", result.Err(), result.Err())
+	}
+	if _, ok := socket.(*ContextSocket).Socketer.(*TimeoutSocket).Socketer.(*ContextSocket); ok {
+		t.Errorf("Expected the inner context socket to be removed")
+	}
+}
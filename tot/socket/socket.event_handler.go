@@ -1,5 +1,9 @@
 package socket
 
+import (
+	"sync/atomic"
+)
+
 /*
 NewEventHandler returns a pointer to an event handler.
 */
@@ -18,6 +22,7 @@ Handler provides an EventHandler interface for managing an event handler.
 */
 type Handler struct {
 	callback func(response *Response)
+	inline   int32
 	name     string
 }
 
@@ -40,3 +45,18 @@ Name is an EventHandler implementation.
 func (handler *Handler) Name() string {
 	return handler.name
 }
+
+/*
+isInline returns whether the handler is executed in the socket read loop.
+*/
+func (handler *Handler) isInline() bool {
+	return 1 == atomic.LoadInt32(&handler.inline)
+}
+
+/*
+setInline marks the handler to be executed in the socket read loop instead of a
+new goroutine, so it receives events in order. The callback must never block.
+*/
+func (handler *Handler) setInline() {
+	atomic.StoreInt32(&handler.inline, 1)
+}
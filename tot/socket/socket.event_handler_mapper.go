@@ -100,7 +100,12 @@ func (stack *EventHandlerMap) Remove(
 
 	for k, hndl := range stack.stack[handler.Name()] {
 		if hndl == handler {
-			stack.stack[handler.Name()] = append(stack.stack[handler.Name()][:k], stack.stack[handler.Name()][k+1:]...)
+			// Copy rather than splice in place, the previous stack may still
+			// be in use by an event dispatcher.
+			handlers := make([]EventHandler, 0, len(stack.stack[handler.Name()])-1)
+			handlers = append(handlers, stack.stack[handler.Name()][:k]...)
+			handlers = append(handlers, stack.stack[handler.Name()][k+1:]...)
+			stack.stack[handler.Name()] = handlers
 			return nil
 		}
 	}
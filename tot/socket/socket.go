@@ -10,8 +10,31 @@ package socket
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
+/*
+DisconnectedError is delivered on a command result's Err field when the socket
+connection is lost before the socket responds and the command is not replayed.
+*/
+type DisconnectedError struct {
+	// Err is the error that caused the connection to be lost, if any.
+	Err error
+
+	// ID is the ID of the failed command.
+	ID int
+
+	// Method is the Chrome DevTools Protocol method of the failed command.
+	Method string
+}
+
+/*
+Error implements the error interface for DisconnectedError structs.
+*/
+func (err *DisconnectedError) Error() string {
+	return fmt.Sprintf("command #%d (%s) failed: connection lost: %v", err.ID, err.Method, err.Err)
+}
+
 /*
 Error represents a socket response error.
 */
@@ -28,15 +51,79 @@ func (err Error) Error() string {
 	return fmt.Sprintf("code=%d, data=%s, msg=%s", err.Code, err.Data, err.Message)
 }
 
+/*
+CanceledError is delivered on a command result's Err field when the context
+associated with the command is done before the socket responds.
+*/
+type CanceledError struct {
+	// Err is the error returned by the context, either context.Canceled or
+	// context.DeadlineExceeded.
+	Err error
+
+	// ID is the ID of the abandoned command.
+	ID int
+
+	// Method is the Chrome DevTools Protocol method of the abandoned command.
+	Method string
+}
+
+/*
+Error implements the error interface for CanceledError structs.
+*/
+func (err *CanceledError) Error() string {
+	return fmt.Sprintf("command #%d (%s) canceled: %s", err.ID, err.Method, err.Err)
+}
+
+/*
+TimeoutError is delivered on a command result's Err field when the socket does
+not respond to the command within the command or socket timeout.
+*/
+type TimeoutError struct {
+	// ID is the ID of the abandoned command.
+	ID int
+
+	// Method is the Chrome DevTools Protocol method of the abandoned command.
+	Method string
+
+	// Timeout is the timeout that expired.
+	Timeout time.Duration
+}
+
+/*
+Error implements the error interface for TimeoutError structs.
+*/
+func (err *TimeoutError) Error() string {
+	return fmt.Sprintf("command #%d (%s) timed out after %s", err.ID, err.Method, err.Timeout)
+}
+
 /*
 Response represents a socket message.
 */
 type Response struct {
-	Error  *Error          `json:"error"`
-	ID     int             `json:"id"`
-	Method string          `json:"method"`
-	Params json.RawMessage `json:"params"`
-	Result json.RawMessage `json:"result"`
+	Error     *Error          `json:"error"`
+	ID        int             `json:"id"`
+	Method    string          `json:"method"`
+	Params    json.RawMessage `json:"params"`
+	Result    json.RawMessage `json:"result"`
+	SessionID string          `json:"sessionId,omitempty"`
+
+	// err holds errors generated locally rather than by the socket, such as a
+	// cancelled command context.
+	err error
+}
+
+/*
+Err returns the error associated with the response, if any. Locally generated
+errors take precedence over errors returned by the socket.
+*/
+func (response *Response) Err() error {
+	if nil != response.err {
+		return response.err
+	}
+	if nil != response.Error && 0 != response.Error.Code {
+		return response.Error
+	}
+	return nil
 }
 
 /*
@@ -44,7 +131,8 @@ Payload represents a WebSocket JSON payload for a sending a command to the
 websocket.
 */
 type Payload struct {
-	ID     int         `json:"id"`
-	Method string      `json:"method"`
-	Params interface{} `json:"params"`
+	ID        int         `json:"id"`
+	Method    string      `json:"method"`
+	Params    interface{} `json:"params"`
+	SessionID string      `json:"sessionId,omitempty"`
 }
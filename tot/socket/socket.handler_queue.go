@@ -0,0 +1,148 @@
+package socket
+
+import (
+	"sync"
+)
+
+/*
+handlerQueue delivers events to a single handler sequentially, in the order
+they were received from the websocket.
+*/
+type handlerQueue struct {
+	handler EventHandler
+	mux     *sync.Mutex
+	pending []*Response
+	running bool
+}
+
+/*
+newHandlerQueue returns an empty queue for the specified handler.
+*/
+func newHandlerQueue(handler EventHandler) *handlerQueue {
+	return &handlerQueue{
+		handler: handler,
+		mux:     &sync.Mutex{},
+		pending: make([]*Response, 0),
+	}
+}
+
+/*
+push adds an event to the queue and starts draining the queue if it isn't
+already being drained. push never blocks on the handler.
+*/
+func (queue *handlerQueue) push(response *Response) {
+	queue.mux.Lock()
+	queue.pending = append(queue.pending, response)
+	if queue.running {
+		queue.mux.Unlock()
+		return
+	}
+	queue.running = true
+	queue.mux.Unlock()
+
+	go queue.drain()
+}
+
+/*
+drain executes the handler for each queued event in order and returns once the
+queue is empty.
+*/
+func (queue *handlerQueue) drain() {
+	for {
+		queue.mux.Lock()
+		if 0 == len(queue.pending) {
+			queue.running = false
+			queue.mux.Unlock()
+			return
+		}
+		response := queue.pending[0]
+		queue.pending[0] = nil
+		queue.pending = queue.pending[1:]
+		queue.mux.Unlock()
+
+		queue.handler.Handle(response)
+	}
+}
+
+/*
+SetOrderedDispatch enables or disables in-order event dispatch. By default each
+handler is executed in a new goroutine for every event, so a handler may observe
+events out of order. With ordered dispatch enabled each handler receives events
+sequentially, in the order they were read from the websocket. Different handlers
+still run concurrently.
+*/
+func (socket *Socket) SetOrderedDispatch(ordered bool) {
+	socket.queueMux.Lock()
+	socket.ordered = ordered
+	socket.queueMux.Unlock()
+}
+
+/*
+OrderedDispatch returns whether in-order event dispatch is enabled.
+*/
+func (socket *Socket) OrderedDispatch() bool {
+	socket.queueMux.Lock()
+	defer socket.queueMux.Unlock()
+	return socket.ordered
+}
+
+/*
+dispatch delivers an event to a handler from the specified stack according to
+the dispatch mode. Handlers that never block, such as subscription handlers,
+are executed immediately.
+*/
+func (socket *Socket) dispatch(handlers EventHandlerMapper, handler EventHandler, response *Response) {
+	if inline, ok := handler.(*Handler); ok && inline.isInline() {
+		handler.Handle(response)
+		return
+	}
+
+	socket.queueMux.Lock()
+	if !socket.ordered {
+		socket.queueMux.Unlock()
+		go handler.Handle(response)
+		return
+	}
+	queue, ok := socket.queues[handler]
+	if !ok {
+		// The handler may have been removed since the event's handlers were
+		// retrieved, its queue would never be removed.
+		if !isRegistered(handlers, handler) {
+			socket.queueMux.Unlock()
+			return
+		}
+		queue = newHandlerQueue(handler)
+		socket.queues[handler] = queue
+	}
+	socket.queueMux.Unlock()
+
+	queue.push(response)
+}
+
+/*
+removeQueue discards the dispatch queue for a handler. Events that have already
+been queued are still delivered.
+*/
+func (socket *Socket) removeQueue(handler EventHandler) {
+	socket.queueMux.Lock()
+	delete(socket.queues, handler)
+	socket.queueMux.Unlock()
+}
+
+/*
+isRegistered returns whether a handler is in the specified stack.
+*/
+func isRegistered(handlers EventHandlerMapper, handler EventHandler) bool {
+	handlers.Lock()
+	defer handlers.Unlock()
+	registered, err := handlers.Get(handler.Name())
+	if nil != err {
+		return false
+	}
+	for _, hndlr := range registered {
+		if hndlr == handler {
+			return true
+		}
+	}
+	return false
+}
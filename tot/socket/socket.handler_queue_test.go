@@ -0,0 +1,85 @@
+package socket
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestOrderedDispatch(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/ordered")
+	mockSocket := NewMock(socketURL)
+	mockSocket.SetOrderedDispatch(true)
+	if !mockSocket.OrderedDispatch() {
+		t.Errorf("Expected true, got false")
+	}
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	count := 20
+	results := make(chan int, count)
+	mockSocket.AddEventHandler(NewEventHandler(
+		"Some.event",
+		func(response *Response) {
+			value, _ := strconv.Atoi(string(response.Result))
+			// Earlier events take longer to handle.
+			time.Sleep(time.Duration(count-value) * time.Millisecond)
+			results <- value
+		},
+	))
+	for a := 0; a < count; a++ {
+		mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+			ID:     0,
+			Error:  &Error{},
+			Method: "Some.event",
+			Result: []byte(fmt.Sprintf("%d", a)),
+		})
+	}
+
+	for a := 0; a < count; a++ {
+		select {
+		case value := <-results:
+			if a != value {
+				t.Errorf("Expected event %d, got %d", a, value)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for event %d", a)
+		}
+	}
+}
+
+func TestOrderedDispatchRemoveHandler(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/ordered")
+	mockSocket := NewMock(socketURL)
+	mockSocket.SetOrderedDispatch(true)
+
+	handler := NewEventHandler("Some.event", func(response *Response) {})
+	mockSocket.AddEventHandler(handler)
+	mockSocket.handleEvent(&Response{Method: "Some.event"})
+	if _, ok := mockSocket.queues[handler]; !ok {
+		t.Errorf("Expected a dispatch queue for the handler")
+	}
+
+	mockSocket.RemoveEventHandler(handler)
+	if _, ok := mockSocket.queues[handler]; ok {
+		t.Errorf("Expected the dispatch queue to be removed")
+	}
+}
+
+func TestOrderedDispatchRemovedHandler(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/ordered")
+	mockSocket := NewMock(socketURL)
+	mockSocket.SetOrderedDispatch(true)
+
+	handler := NewEventHandler("Some.event", func(response *Response) {})
+	mockSocket.AddEventHandler(handler)
+	mockSocket.RemoveEventHandler(handler)
+
+	// An event dispatched from a snapshot taken before the handler was removed.
+	mockSocket.dispatch(mockSocket.handlers, handler, &Response{Method: "Some.event"})
+	if _, ok := mockSocket.queues[handler]; ok {
+		t.Errorf("Expected no dispatch queue for a removed handler")
+	}
+}
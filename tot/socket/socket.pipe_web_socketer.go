@@ -0,0 +1,126 @@
+package socket
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/url"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+/*
+NewPipe returns a pointer to a Socket that communicates with a Chromium process
+started with the --remote-debugging-pipe flag. reader receives the messages
+Chromium writes to file descriptor 4 and writer delivers messages to file
+descriptor 3.
+
+Pipe connections can't be re-established so reconnection always fails.
+*/
+func NewPipe(reader io.ReadCloser, writer io.WriteCloser) *Socket {
+	pipe := NewPipeWebsocket(reader, writer)
+	return newWithTransport(
+		&url.URL{Scheme: "pipe", Path: "/devtools/browser"},
+		func(socketURL *url.URL) (WebSocketer, error) {
+			if pipe.Closed() {
+				return nil, errors.New("pipe connection closed")
+			}
+			log.Infof("Pipe connection to %s established", socketURL.String())
+			return pipe, nil
+		},
+	)
+}
+
+/*
+NewPipeWebsocket returns a WebSocketer that exchanges NUL-delimited JSON
+messages over a pair of pipes.
+*/
+func NewPipeWebsocket(reader io.ReadCloser, writer io.WriteCloser) *PipeWebSocket {
+	return &PipeWebSocket{
+		buffer:   bufio.NewReader(reader),
+		mux:      &sync.Mutex{},
+		reader:   reader,
+		writeMux: &sync.Mutex{},
+		writer:   writer,
+	}
+}
+
+/*
+PipeWebSocket provides a WebSocketer interface for the Chromium remote
+debugging pipe transport, where each message is a JSON document terminated by a
+NUL byte.
+*/
+type PipeWebSocket struct {
+	buffer   *bufio.Reader
+	closed   bool
+	mux      *sync.Mutex
+	reader   io.ReadCloser
+	writeMux *sync.Mutex
+	writer   io.WriteCloser
+}
+
+/*
+Close closes both pipes.
+
+Close is a WebSocketer implementation.
+*/
+func (pipe *PipeWebSocket) Close() error {
+	pipe.mux.Lock()
+	defer pipe.mux.Unlock()
+	if pipe.closed {
+		return nil
+	}
+	pipe.closed = true
+
+	writeErr := pipe.writer.Close()
+	readErr := pipe.reader.Close()
+	if nil != writeErr {
+		return writeErr
+	}
+	return readErr
+}
+
+/*
+Closed returns whether the pipes have been closed.
+*/
+func (pipe *PipeWebSocket) Closed() bool {
+	pipe.mux.Lock()
+	defer pipe.mux.Unlock()
+	return pipe.closed
+}
+
+/*
+ReadJSON reads the next NUL-terminated message and unmarshalls it into the
+provided variable.
+
+ReadJSON is a WebSocketer implementation.
+*/
+func (pipe *PipeWebSocket) ReadJSON(v interface{}) error {
+	message, err := pipe.buffer.ReadBytes(0)
+	if nil != err {
+		return errors.Wrap(err, "pipe read failed")
+	}
+	return json.Unmarshal(message[:len(message)-1], v)
+}
+
+/*
+WriteJSON marshalls the provided data as JSON and writes it to the pipe
+followed by a NUL byte.
+
+WriteJSON is a WebSocketer implementation.
+*/
+func (pipe *PipeWebSocket) WriteJSON(v interface{}) error {
+	message, err := json.Marshal(v)
+	if nil != err {
+		return errors.Wrap(err, "message encoding failed")
+	}
+
+	pipe.writeMux.Lock()
+	defer pipe.writeMux.Unlock()
+	if _, err := pipe.writer.Write(append(message, 0)); nil != err {
+		return errors.Wrap(err, "pipe write failed")
+	}
+	return nil
+}
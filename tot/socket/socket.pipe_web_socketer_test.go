@@ -0,0 +1,99 @@
+package socket
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+)
+
+/*
+servePipe emulates the browser end of a remote debugging pipe, replying to each
+command with the result returned by the reply function.
+*/
+func servePipe(commands io.Reader, responses io.Writer, reply func(payload *Payload) string) {
+	buffer := bufio.NewReader(commands)
+	for {
+		message, err := buffer.ReadBytes(0)
+		if nil != err {
+			return
+		}
+		payload := &Payload{}
+		json.Unmarshal(message[:len(message)-1], payload)
+		response, _ := json.Marshal(map[string]interface{}{
+			"id":     payload.ID,
+			"result": json.RawMessage(reply(payload)),
+		})
+		responses.Write(append(response, 0))
+	}
+}
+
+func TestPipeWebSocket(t *testing.T) {
+	reader, remoteWriter := io.Pipe()
+	remoteReader, writer := io.Pipe()
+	pipe := NewPipeWebsocket(reader, writer)
+
+	go func() {
+		remoteWriter.Write([]byte(`{"id": 1, "result": {}}` + "\x00" + `{"method": "Page.loadEventFired", "params": {"timestamp": 1}}` + "\x00"))
+	}()
+	response := &Response{}
+	if err := pipe.ReadJSON(response); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if 1 != response.ID {
+		t.Errorf("Expected 1, received %d", response.ID)
+	}
+	response = &Response{}
+	if err := pipe.ReadJSON(response); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if "Page.loadEventFired" != response.Method {
+		t.Errorf("Expected 'Page.loadEventFired', received '%s'", response.Method)
+	}
+
+	go pipe.WriteJSON(&Payload{ID: 2, Method: "Page.enable"})
+	message, err := bufio.NewReader(remoteReader).ReadBytes(0)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if `{"id":2,"method":"Page.enable","params":null}` != string(message[:len(message)-1]) {
+		t.Errorf("Unexpected message '%s'", string(message))
+	}
+
+	if err := pipe.Close(); nil != err {
+		t.Errorf("Expected nil, received error: '%s'", err.Error())
+	}
+	if !pipe.Closed() {
+		t.Errorf("Expected the pipe to be closed")
+	}
+	if err := pipe.ReadJSON(&Response{}); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
+
+func TestNewPipe(t *testing.T) {
+	reader, remoteWriter := io.Pipe()
+	remoteReader, writer := io.Pipe()
+	go servePipe(remoteReader, remoteWriter, func(payload *Payload) string {
+		return `{"product": "HeadlessChrome/66.0.3359.117"}`
+	})
+
+	socket := NewPipe(reader, writer)
+	defer socket.Stop()
+	if "pipe:///devtools/browser" != socket.URL().String() {
+		t.Errorf("Expected 'pipe:///devtools/browser', received '%s'", socket.URL().String())
+	}
+
+	select {
+	case result := <-socket.Browser().GetVersion():
+		if nil != result.Err {
+			t.Fatalf("Expected nil, received error: '%s'", result.Err.Error())
+		}
+		if "HeadlessChrome/66.0.3359.117" != result.Product {
+			t.Errorf("Expected 'HeadlessChrome/66.0.3359.117', received '%s'", result.Product)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for a response")
+	}
+}
@@ -0,0 +1,176 @@
+package socket
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+/*
+ReconnectPolicy defines how a socket re-establishes a lost websocket
+connection. Reconnection is disabled unless a policy is set with
+Socket.SetReconnectPolicy().
+*/
+type ReconnectPolicy struct {
+	// Optional. Backoff is the delay before the first reconnect attempt. The
+	// delay doubles after each failed attempt. Defaults to 100ms.
+	Backoff time.Duration
+
+	// Optional. MaxBackoff caps the delay between reconnect attempts. A zero
+	// value leaves the delay uncapped.
+	MaxBackoff time.Duration
+
+	// Optional. MaxAttempts is the number of reconnect attempts made before
+	// the socket gives up and closes. A zero value retries indefinitely.
+	MaxAttempts int
+
+	// Optional. Replay re-sends pending commands in command ID order once the
+	// connection is re-established. Otherwise pending commands fail with a
+	// DisconnectedError as soon as the connection is lost.
+	Replay bool
+}
+
+/*
+delay returns the backoff delay before the specified reconnect attempt.
+*/
+func (policy *ReconnectPolicy) delay(attempt int) time.Duration {
+	delay := policy.Backoff
+	if delay <= 0 {
+		delay = 100 * time.Millisecond
+	}
+	for a := 1; a < attempt; a++ {
+		delay *= 2
+		if policy.MaxBackoff > 0 && delay >= policy.MaxBackoff {
+			return policy.MaxBackoff
+		}
+	}
+	if policy.MaxBackoff > 0 && delay > policy.MaxBackoff {
+		return policy.MaxBackoff
+	}
+	return delay
+}
+
+/*
+ConnectionStateChangedEvent represents Socket.connectionStateChanged event data.
+The event is generated by the socket itself rather than by Chrome.
+*/
+type ConnectionStateChangedEvent struct {
+	// Attempt is the reconnect attempt number, if the socket is reconnecting.
+	Attempt int `json:"attempt,omitempty"`
+
+	// Reason describes the error that caused the state change, if any.
+	Reason string `json:"reason,omitempty"`
+
+	// State is the new connection state.
+	State ConnectionStateEnum `json:"state"`
+
+	// Error information related to this event
+	Err error `json:"-"`
+}
+
+/*
+OnConnectionStateChanged adds a handler to the Socket.connectionStateChanged
+event. Socket.connectionStateChanged fires when the websocket connection is
+established, lost, re-established or closed.
+*/
+func (socket *Socket) OnConnectionStateChanged(
+	callback func(event *ConnectionStateChangedEvent),
+) {
+	handler := NewEventHandler(
+		"Socket.connectionStateChanged",
+		func(response *Response) {
+			event := &ConnectionStateChangedEvent{}
+			json.Unmarshal([]byte(response.Result), event)
+			if nil != response.Error && 0 != response.Error.Code {
+				event.Err = response.Error
+			}
+			callback(event)
+		},
+	)
+	socket.AddEventHandler(handler)
+}
+
+/*
+SetReconnectPolicy sets the policy used to re-establish a lost websocket
+connection. A nil policy disables reconnection.
+*/
+func (socket *Socket) SetReconnectPolicy(policy *ReconnectPolicy) {
+	socket.mux.Lock()
+	socket.reconnectPolicy = policy
+	socket.mux.Unlock()
+}
+
+/*
+ReconnectPolicy returns the policy used to re-establish a lost websocket
+connection, if any.
+*/
+func (socket *Socket) ReconnectPolicy() *ReconnectPolicy {
+	socket.mux.Lock()
+	defer socket.mux.Unlock()
+	return socket.reconnectPolicy
+}
+
+/*
+emitConnectionState delivers a Socket.connectionStateChanged event to any
+registered handlers.
+*/
+func (socket *Socket) emitConnectionState(
+	state ConnectionStateEnum,
+	attempt int,
+	err error,
+) {
+	event := &ConnectionStateChangedEvent{
+		Attempt: attempt,
+		State:   state,
+	}
+	if nil != err {
+		event.Reason = err.Error()
+	}
+	result, _ := json.Marshal(event)
+	socket.handleEvent(&Response{
+		Method: "Socket.connectionStateChanged",
+		Result: result,
+	})
+}
+
+/*
+trackDomain records successful domain enable and disable commands so the
+enabled domains can be restored after a reconnect. Domains are kept in the
+order they were first enabled, enabling a domain again only updates its
+parameters.
+*/
+func (socket *Socket) trackDomain(command Commander, response *Response) {
+	if nil != response.Err() {
+		return
+	}
+
+	method := command.Method()
+	var domain string
+	switch {
+	case strings.HasSuffix(method, ".enable"):
+		domain = strings.TrimSuffix(method, ".enable")
+	case strings.HasSuffix(method, ".disable"):
+		domain = strings.TrimSuffix(method, ".disable")
+	default:
+		return
+	}
+
+	socket.mux.Lock()
+	defer socket.mux.Unlock()
+
+	enable := strings.HasSuffix(method, ".enable")
+	enabled := make([]*Payload, 0, len(socket.enabled)+1)
+	found := false
+	for _, payload := range socket.enabled {
+		if payload.Method != domain+".enable" {
+			enabled = append(enabled, payload)
+		} else if enable {
+			enabled = append(enabled, &Payload{Method: method, Params: command.Params()})
+			found = true
+		}
+	}
+	if enable && !found {
+		enabled = append(enabled, &Payload{Method: method, Params: command.Params()})
+	}
+	socket.enabled = enabled
+}
@@ -0,0 +1,54 @@
+package socket
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReconnectPolicyDelay(t *testing.T) {
+	policy := &ReconnectPolicy{}
+	if 100*time.Millisecond != policy.delay(1) {
+		t.Errorf("Expected 100ms, got %s", policy.delay(1))
+	}
+
+	policy = &ReconnectPolicy{
+		Backoff:    time.Second,
+		MaxBackoff: 5 * time.Second,
+	}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for a, delay := range expected {
+		if delay != policy.delay(a+1) {
+			t.Errorf("Expected %s for attempt %d, got %s", delay, a+1, policy.delay(a+1))
+		}
+	}
+}
+
+func TestTrackDomain(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/domains")
+	socket := NewMock(socketURL)
+
+	for _, command := range []Commander{
+		NewCommand(socket, "Page.enable", nil),
+		NewCommand(socket, "Network.enable", nil),
+		NewCommand(socket, "Runtime.enable", nil),
+		NewCommand(socket, "Page.enable", map[string]int{"bufferSize": 1}),
+		NewCommand(socket, "Network.disable", nil),
+		NewCommand(socket, "Page.navigate", nil),
+	} {
+		socket.trackDomain(command, &Response{})
+	}
+	socket.trackDomain(NewCommand(socket, "DOM.enable", nil), &Response{Error: &Error{Code: 1}})
+
+	methods := []string{}
+	for _, payload := range socket.enabled {
+		methods = append(methods, payload.Method)
+	}
+	if "Page.enable Runtime.enable" != strings.Join(methods, " ") {
+		t.Errorf("Expected 'Page.enable Runtime.enable', got '%s'", strings.Join(methods, " "))
+	}
+	if nil == socket.enabled[0].Params {
+		t.Errorf("Expected the Page.enable parameters to be updated")
+	}
+}
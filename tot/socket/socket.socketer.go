@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -14,12 +15,27 @@ New returns a pointer to a websocket struct that implements Socketer interface
 listening to the specified URL.
 */
 func New(url *url.URL) *Socket {
+	return newWithTransport(url, NewWebsocket)
+}
+
+/*
+newWithTransport returns a pointer to a listening Socket that uses the
+specified function to establish the underlying WebSocketer connection.
+*/
+func newWithTransport(
+	url *url.URL,
+	transport func(socketURL *url.URL) (WebSocketer, error),
+) *Socket {
 	socket := &Socket{
 		commands:     NewCommandMap(),
 		commandIDMux: &sync.Mutex{},
 		handlers:     NewEventHandlerMap(),
 		mux:          &sync.Mutex{},
-		newSocket:    NewWebsocket,
+		newSocket:    transport,
+		queueMux:     &sync.Mutex{},
+		queues:       make(map[EventHandler]*handlerQueue),
+		sessionMux:   &sync.Mutex{},
+		sessions:     make(map[string]*Session),
 		socketID:     NextSocketID(),
 		url:          url,
 	}
@@ -89,17 +105,27 @@ func NextSocketID() int {
 Socket is a Socketer implementation.
 */
 type Socket struct {
-	commands      CommandMapper
-	commandID     int
-	commandIDMux  *sync.Mutex
-	conn          WebSocketer
-	connected     bool
-	handlers      EventHandlerMapper
-	newSocket     func(socketURL *url.URL) (WebSocketer, error)
-	url           *url.URL
-	socketID      int
-	stopListening bool
-	mux           *sync.Mutex
+	commands        CommandMapper
+	commandID       int
+	commandIDMux    *sync.Mutex
+	conn            WebSocketer
+	connected       bool
+	dialed          bool
+	enabled         []*Payload
+	handlers        EventHandlerMapper
+	newSocket       func(socketURL *url.URL) (WebSocketer, error)
+	ordered         bool
+	queueMux        *sync.Mutex
+	queues          map[EventHandler]*handlerQueue
+	url             *url.URL
+	reconnecting    bool
+	reconnectPolicy *ReconnectPolicy
+	sessionMux      *sync.Mutex
+	sessions        map[string]*Session
+	socketID        int
+	stopListening   bool
+	mux             *sync.Mutex
+	timeout         time.Duration
 
 	// Protocol interfaces for the API.
 	accessibility        *AccessibilityProtocol
@@ -193,6 +219,7 @@ func (socket *Socket) handleResponse(response *Response) {
 		)
 		command.Respond(response)
 		socket.commands.Delete(command.ID())
+		socket.trackDomain(command, response)
 		log.Debugf(
 			"socket #%d - Command #%d complete: %s{%s}",
 			socket.socketID,
@@ -220,14 +247,20 @@ func (socket *Socket) handleEvent(
 	if response.Method == "Inspector.targetCrashed" {
 		log.Errorf("socket #%d - Chrome has crashed!", socket.socketID)
 	}
+	if response.Method == "Target.detachedFromTarget" {
+		socket.handleDetached(response)
+	}
 
-	if handlers, err := socket.handlers.Get(response.Method); nil != err {
+	socket.handlers.Lock()
+	handlers, err := socket.handlers.Get(response.Method)
+	socket.handlers.Unlock()
+	if nil != err {
 		log.Debugf("socket #%d - %s", socket.socketID, err.Error())
 
 	} else {
 		for a, event := range handlers {
 			log.Infof("socket #%d - Executing handler #%d for event %s", socket.socketID, a, response.Method)
-			go event.Handle(response)
+			socket.dispatch(socket.handlers, event, response)
 		}
 	}
 }
@@ -291,16 +324,37 @@ func (socket *Socket) Listen() error {
 	}
 	defer socket.Disconnect()
 
+	socket.mux.Lock()
 	socket.stopListening = false
+	socket.mux.Unlock()
 	for {
 		response := &Response{}
 		err = socket.ReadJSON(&response)
 		if nil != err {
 			log.Errorf("socket #%d - %s", socket.socketID, err.Error())
+			if !socket.stopped() && nil != socket.ReconnectPolicy() {
+				if err = socket.Reconnect(); nil == err {
+					continue
+				}
+			}
 			socket.Stop() // This will end the loop after handling the current response (if any)
 		}
 
-		if response.ID > 0 {
+		// Event handlers decode event data from the Result field but Chrome
+		// delivers it in the Params field.
+		if "" != response.Method && (0 == len(response.Result) || "null" == string(response.Result)) {
+			response.Result = response.Params
+		}
+
+		if session := socket.session(response.SessionID); nil != session {
+			log.Debugf(
+				"socket #%d - socket.Listen(): Session %s, sending to session handler",
+				socket.socketID,
+				response.SessionID,
+			)
+			session.handleMessage(response)
+
+		} else if response.ID > 0 {
 			log.Debugf(
 				"socket #%d - socket.Listen(): Response ID #%d, sending to command handler",
 				socket.socketID,
@@ -331,7 +385,7 @@ func (socket *Socket) Listen() error {
 			socket.handleUnknown(response)
 		}
 
-		if socket.stopListening {
+		if socket.stopped() {
 			log.Infof("socket #%d - %s: Socket shutting down", socket.socketID, socket.URL().String())
 			break
 		}
@@ -366,23 +420,32 @@ func (socket *Socket) RemoveEventHandler(
 	handler EventHandler,
 ) error {
 	socket.handlers.Lock()
-	defer socket.handlers.Unlock()
-
 	handlers, err := socket.handlers.Get(handler.Name())
 	if nil != err {
+		socket.handlers.Unlock()
 		log.Warnf("socket #%d - RemoveEventHandler(): Could not remove handler: %s", socket.socketID, err.Error())
 		return err
 	}
 
 	for i, hndlr := range handlers {
 		if hndlr == handler {
-			handlers = append(handlers[:i], handlers[i+1:]...)
-			socket.handlers.Set(handler.Name(), handlers)
+			// Copy rather than splice in place, the previous stack may still
+			// be in use by handleEvent().
+			remaining := make([]EventHandler, 0, len(handlers)-1)
+			remaining = append(remaining, handlers[:i]...)
+			remaining = append(remaining, handlers[i+1:]...)
+			socket.handlers.Set(handler.Name(), remaining)
+			socket.handlers.Unlock()
+
+			// The handler lock is released first, dispatch() holds the queue
+			// lock while it checks the handler is still registered.
+			socket.removeQueue(handler)
 			return nil
 		}
 	}
+	socket.handlers.Unlock()
 
-	log.Warnf("socket #%d - RemoveEventHandler(): handler not found")
+	log.Warnf("socket #%d - RemoveEventHandler(): handler not found", socket.socketID)
 	return nil
 }
 
@@ -392,13 +455,16 @@ SendCommand delivers a command payload to the websocket connection.
 SendCommand is a Socketer implementation.
 
 Workflow:
-	1. The socket's command mutex is locked.
-	2. The command counter is incremented.
-	3. The payload is sent to the socket connection and the mutex is unlocked.
-	4. The command is stored using the generated ID.
-	5. When the command has been executed and the socket responds,
-	socket.HandleCmd() is triggered from the command instance to generate the
-	response and the command unlocks itself.
+ 1. The command is stored using its ID.
+ 2. The payload is sent to the socket connection. If the write fails the
+    command is removed from the stack and an error response is delivered.
+ 3. When the command has been executed and the socket responds,
+    socket.handleResponse() delivers the response to the command.
+ 4. If the command context is done before the socket responds, the command
+    is removed from the stack and a CanceledError response is delivered.
+ 5. If the command or socket timeout expires before the socket responds,
+    the command is removed from the stack and a TimeoutError response is
+    delivered.
 */
 func (socket *Socket) SendCommand(command Commander) chan *Response {
 	log.Debugf(
@@ -407,15 +473,35 @@ func (socket *Socket) SendCommand(command Commander) chan *Response {
 		command.ID(),
 		command.Method(),
 	)
+	return socket.sendCommand(socket.commands, command, "")
+}
 
+/*
+sendCommand stores a command in the specified stack and delivers its payload to
+the websocket connection, addressed to the specified target session if any.
+*/
+func (socket *Socket) sendCommand(
+	commands CommandMapper,
+	command Commander,
+	sessionID string,
+) chan *Response {
 	go func() {
 		payload := &Payload{
-			ID:     command.ID(),
-			Method: command.Method(),
-			Params: command.Params(),
+			ID:        command.ID(),
+			Method:    command.Method(),
+			Params:    command.Params(),
+			SessionID: sessionID,
 		}
 
+		commands.Set(command)
 		if err := socket.WriteJSON(payload); err != nil {
+			if "" == sessionID && socket.replaying() {
+				// Reconnect() replays the command once the connection is
+				// re-established or fails it if every attempt fails.
+				socket.watchCommand(commands, command)
+				return
+			}
+			commands.Delete(command.ID())
 			command.Respond(&Response{Error: &Error{
 				Code:    1,
 				Data:    []byte(fmt.Sprintf(`"%s"`, err.Error())),
@@ -424,12 +510,82 @@ func (socket *Socket) SendCommand(command Commander) chan *Response {
 			return
 		}
 
-		socket.commands.Set(command)
+		socket.watchCommand(commands, command)
 	}()
 
 	return command.Response()
 }
 
+/*
+watchCommand abandons a pending command if its context is done or its timeout
+expires before the socket responds.
+*/
+func (socket *Socket) watchCommand(commands CommandMapper, command Commander) {
+	ctx := command.Context()
+
+	timeout := command.Timeout()
+	if 0 == timeout {
+		timeout = socket.Timeout()
+	}
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	if nil == ctx.Done() && nil == expired {
+		return
+	}
+
+	select {
+	case <-command.Done():
+	case <-ctx.Done():
+		socket.abandonCommand(commands, command, &CanceledError{
+			Err:    ctx.Err(),
+			ID:     command.ID(),
+			Method: command.Method(),
+		})
+	case <-expired:
+		socket.abandonCommand(commands, command, &TimeoutError{
+			ID:      command.ID(),
+			Method:  command.Method(),
+			Timeout: timeout,
+		})
+	}
+}
+
+/*
+abandonCommand removes a pending command from the specified stack and delivers
+the specified error as its response.
+*/
+func (socket *Socket) abandonCommand(commands CommandMapper, command Commander, err error) {
+	commands.Delete(command.ID())
+	log.Debugf(
+		"socket #%d - socket.abandonCommand(): command #%d (%s) abandoned: %s",
+		socket.socketID,
+		command.ID(),
+		command.Method(),
+		err.Error(),
+	)
+	command.Respond(&Response{
+		ID:     command.ID(),
+		Method: command.Method(),
+		err:    err,
+	})
+}
+
+/*
+SetTimeout sets the default amount of time to wait for a response to a command
+before abandoning it with a TimeoutError. A zero value disables the default
+timeout. Commands may override the default with Commander.SetTimeout().
+*/
+func (socket *Socket) SetTimeout(timeout time.Duration) {
+	socket.mux.Lock()
+	socket.timeout = timeout
+	socket.mux.Unlock()
+}
+
 /*
 Stop signals the socket read loop to stop listening for data and close the
 websocket connection.
@@ -437,7 +593,27 @@ websocket connection.
 Stop is a Socketer implementation.
 */
 func (socket *Socket) Stop() {
+	socket.mux.Lock()
 	socket.stopListening = true
+	socket.mux.Unlock()
+}
+
+/*
+stopped returns whether the socket has been told to stop listening.
+*/
+func (socket *Socket) stopped() bool {
+	socket.mux.Lock()
+	defer socket.mux.Unlock()
+	return socket.stopListening
+}
+
+/*
+Timeout returns the default amount of time to wait for a response to a command.
+*/
+func (socket *Socket) Timeout() time.Duration {
+	socket.mux.Lock()
+	defer socket.mux.Unlock()
+	return socket.timeout
 }
 
 /*
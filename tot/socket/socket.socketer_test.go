@@ -4,6 +4,7 @@ import (
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestNewSocket(t *testing.T) {
@@ -126,6 +127,38 @@ func TestRemoveEventHandler(t *testing.T) {
 	}
 }
 
+func TestListenEventParams(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/event")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	events := make(chan *Response, 2)
+	mockSocket.AddEventHandler(NewEventHandler(
+		"Test.event",
+		func(response *Response) { events <- response },
+	))
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		Method: "Test.event",
+		Params: []byte(`{"value":"params"}`),
+	})
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		Method: "Test.event",
+		Result: []byte(`{"value":"result"}`),
+	})
+
+	for _, expected := range []string{`{"value":"params"}`, `{"value":"result"}`} {
+		select {
+		case event := <-events:
+			if expected != string(event.Result) {
+				t.Errorf("Expected '%s', received '%s'", expected, event.Result)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected the event handler to be called")
+		}
+	}
+}
+
 //func TestReadJSONError(t *testing.T) {
 //	socketURL, _ := url.Parse("https://www.example.com/error")
 //	mockSocket := NewMock(socketURL)
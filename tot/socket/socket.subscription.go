@@ -0,0 +1,217 @@
+package socket
+
+import (
+	"sync"
+)
+
+/*
+OverflowPolicy defines how a subscription handles events that arrive while its
+buffer is full.
+*/
+type OverflowPolicy int
+
+const (
+	// OverflowQueue queues events that don't fit in the buffer until the
+	// consumer reads them, so no event is lost. The queue is unbounded and
+	// grows for as long as the consumer falls behind; the socket read loop is
+	// never blocked. A single goroutine per subscription delivers the queued
+	// events in order.
+	OverflowQueue OverflowPolicy = iota
+
+	// OverflowDropNewest discards the incoming event.
+	OverflowDropNewest
+
+	// OverflowDropOldest discards the oldest buffered event to make room for
+	// the incoming event.
+	OverflowDropOldest
+)
+
+/*
+SubscriptionOptions configures the buffering of a subscription.
+*/
+type SubscriptionOptions struct {
+	// Optional. Buffer is the capacity of the subscription channel. Defaults
+	// to 16.
+	Buffer int
+
+	// Optional. Overflow defines how events are handled when the buffer is
+	// full. Defaults to OverflowQueue.
+	Overflow OverflowPolicy
+}
+
+/*
+NewSubscription returns a subscription that is ready to receive events. The
+caller is responsible for registering an event handler that delivers events to
+the subscription with Deliver() and assigning it with SetHandler().
+*/
+func NewSubscription[T any](socket Socketer, options *SubscriptionOptions) *Subscription[T] {
+	if nil == options {
+		options = &SubscriptionOptions{}
+	}
+	buffer := options.Buffer
+	if buffer <= 0 {
+		buffer = 16
+	}
+	return &Subscription[T]{
+		done:     make(chan struct{}),
+		events:   make(chan T, buffer),
+		mux:      &sync.RWMutex{},
+		overflow: options.Overflow,
+		queueMux: &sync.Mutex{},
+		socket:   socket,
+	}
+}
+
+/*
+Subscription delivers decoded events on a typed channel until it is
+unsubscribed.
+*/
+type Subscription[T any] struct {
+	done     chan struct{}
+	dropped  int
+	events   chan T
+	handler  EventHandler
+	mux      *sync.RWMutex
+	once     sync.Once
+	overflow OverflowPolicy
+	pending  []T
+	pumping  bool
+	queueMux *sync.Mutex
+	socket   Socketer
+	stopped  bool
+}
+
+/*
+Deliver sends an event to the subscription channel according to the overflow
+policy. Deliver never blocks. Events delivered after the subscription has been
+unsubscribed are discarded.
+*/
+func (sub *Subscription[T]) Deliver(event T) {
+	if OverflowQueue == sub.overflow {
+		sub.mux.RLock()
+		stopped := sub.stopped
+		sub.mux.RUnlock()
+		if stopped {
+			return
+		}
+
+		sub.queueMux.Lock()
+		sub.pending = append(sub.pending, event)
+		if sub.pumping {
+			sub.queueMux.Unlock()
+			return
+		}
+		sub.pumping = true
+		sub.queueMux.Unlock()
+		go sub.pump()
+		return
+	}
+
+	sub.mux.Lock()
+	defer sub.mux.Unlock()
+	if sub.stopped {
+		return
+	}
+	for {
+		select {
+		case sub.events <- event:
+			return
+		default:
+		}
+		sub.dropped++
+		if OverflowDropNewest == sub.overflow {
+			return
+		}
+		select {
+		case <-sub.events:
+		default:
+		}
+	}
+}
+
+/*
+pump sends the queued events to the subscription channel in order and returns
+once the queue is empty or the subscription is unsubscribed.
+*/
+func (sub *Subscription[T]) pump() {
+	for {
+		sub.queueMux.Lock()
+		if 0 == len(sub.pending) {
+			sub.pumping = false
+			sub.queueMux.Unlock()
+			return
+		}
+		event := sub.pending[0]
+		var zero T
+		sub.pending[0] = zero
+		sub.pending = sub.pending[1:]
+		sub.queueMux.Unlock()
+
+		sub.mux.RLock()
+		if sub.stopped {
+			sub.mux.RUnlock()
+			return
+		}
+		select {
+		case sub.events <- event:
+		case <-sub.done:
+		}
+		sub.mux.RUnlock()
+	}
+}
+
+/*
+Dropped returns the number of events discarded because the buffer was full.
+*/
+func (sub *Subscription[T]) Dropped() int {
+	sub.mux.RLock()
+	defer sub.mux.RUnlock()
+	return sub.dropped
+}
+
+/*
+Events returns the channel events are delivered on. The channel is closed when
+the subscription is unsubscribed.
+*/
+func (sub *Subscription[T]) Events() <-chan T {
+	return sub.events
+}
+
+/*
+Handler returns the event handler registered for the subscription.
+*/
+func (sub *Subscription[T]) Handler() EventHandler {
+	return sub.handler
+}
+
+/*
+SetHandler sets the event handler registered for the subscription. Deliver
+never blocks, so the handler is executed in the socket read loop and the
+subscription receives events in the order they were read.
+*/
+func (sub *Subscription[T]) SetHandler(handler EventHandler) {
+	if inline, ok := handler.(*Handler); ok {
+		inline.setInline()
+	}
+	sub.handler = handler
+}
+
+/*
+Unsubscribe removes the subscription's event handler from the socket and closes
+the events channel. Calling Unsubscribe more than once has no effect.
+*/
+func (sub *Subscription[T]) Unsubscribe() error {
+	var err error
+	sub.once.Do(func() {
+		close(sub.done)
+		sub.mux.Lock()
+		sub.stopped = true
+		close(sub.events)
+		sub.mux.Unlock()
+
+		if nil != sub.handler {
+			err = sub.socket.RemoveEventHandler(sub.handler)
+		}
+	})
+	return err
+}
@@ -0,0 +1,205 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/url"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/page"
+)
+
+func TestSubscription(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/subscription")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	sub := mockSocket.Page().SubscribeLoadEventFired(nil)
+	mockResult := &page.LoadEventFiredEvent{
+		Timestamp: page.MonotonicTime(time.Now().Unix()),
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Page.loadEventFired",
+		Result: mockResultBytes,
+	})
+	result := <-sub.Events()
+	if mockResult.Timestamp != result.Timestamp {
+		t.Errorf("Expected %d, got %d", mockResult.Timestamp, result.Timestamp)
+	}
+
+	if err := sub.Unsubscribe(); nil != err {
+		t.Errorf("Expected nil, got error: '%s'", err.Error())
+	}
+	if _, ok := <-sub.Events(); ok {
+		t.Errorf("Expected the events channel to be closed")
+	}
+	handlers, _ := mockSocket.handlers.Get("Page.loadEventFired")
+	if 0 != len(handlers) {
+		t.Errorf("Expected no handlers, found %d", len(handlers))
+	}
+	if err := sub.Unsubscribe(); nil != err {
+		t.Errorf("Expected nil, got error: '%s'", err.Error())
+	}
+	sub.Deliver(mockResult)
+}
+
+func TestSubscriptionDropNewest(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/subscription")
+	sub := NewSubscription[int](NewMock(socketURL), &SubscriptionOptions{
+		Buffer:   2,
+		Overflow: OverflowDropNewest,
+	})
+	for a := 1; a <= 4; a++ {
+		sub.Deliver(a)
+	}
+	if 2 != sub.Dropped() {
+		t.Errorf("Expected 2 dropped events, got %d", sub.Dropped())
+	}
+	sub.Unsubscribe()
+
+	expected := []int{1, 2}
+	a := 0
+	for event := range sub.Events() {
+		if expected[a] != event {
+			t.Errorf("Expected %d, got %d", expected[a], event)
+		}
+		a++
+	}
+}
+
+func TestSubscriptionDropOldest(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/subscription")
+	sub := NewSubscription[int](NewMock(socketURL), &SubscriptionOptions{
+		Buffer:   2,
+		Overflow: OverflowDropOldest,
+	})
+	for a := 1; a <= 4; a++ {
+		sub.Deliver(a)
+	}
+	if 2 != sub.Dropped() {
+		t.Errorf("Expected 2 dropped events, got %d", sub.Dropped())
+	}
+	sub.Unsubscribe()
+
+	expected := []int{3, 4}
+	a := 0
+	for event := range sub.Events() {
+		if expected[a] != event {
+			t.Errorf("Expected %d, got %d", expected[a], event)
+		}
+		a++
+	}
+}
+
+func TestSubscriptionBlockUnsubscribe(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/subscription")
+	sub := NewSubscription[int](NewMock(socketURL), &SubscriptionOptions{
+		Buffer: 1,
+	})
+	sub.Deliver(1)
+
+	delivered := make(chan bool)
+	go func() {
+		sub.Deliver(2)
+		delivered <- true
+	}()
+	time.Sleep(10 * time.Millisecond)
+	sub.Unsubscribe()
+
+	select {
+	case <-delivered:
+	case <-time.After(time.Second):
+		t.Errorf("Expected Unsubscribe() to release blocked deliveries")
+	}
+}
+
+func TestSubscriptionBlockOrder(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/subscription")
+	sub := NewSubscription[int](NewMock(socketURL), &SubscriptionOptions{
+		Buffer: 1,
+	})
+	defer sub.Unsubscribe()
+
+	goroutines := runtime.NumGoroutine()
+	for a := 1; a <= 100; a++ {
+		sub.Deliver(a)
+	}
+	if runtime.NumGoroutine() > goroutines+1 {
+		t.Errorf("Expected a single delivery goroutine, found %d new goroutines", runtime.NumGoroutine()-goroutines)
+	}
+	for a := 1; a <= 100; a++ {
+		select {
+		case event := <-sub.Events():
+			if a != event {
+				t.Fatalf("Expected %d, got %d", a, event)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected event %d", a)
+		}
+	}
+	if 0 != sub.Dropped() {
+		t.Errorf("Expected no dropped events, got %d", sub.Dropped())
+	}
+}
+
+func TestSubscriptionSocketOrder(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/subscription")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	sub := mockSocket.Page().SubscribeLoadEventFired(&SubscriptionOptions{Buffer: 1})
+	defer sub.Unsubscribe()
+	for a := 1; a <= 20; a++ {
+		mockResultBytes, _ := json.Marshal(&page.LoadEventFiredEvent{
+			Timestamp: page.MonotonicTime(a),
+		})
+		mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+			Error:  &Error{},
+			Method: "Page.loadEventFired",
+			Result: mockResultBytes,
+		})
+	}
+	time.Sleep(300 * time.Millisecond)
+	for a := 1; a <= 20; a++ {
+		select {
+		case event := <-sub.Events():
+			if page.MonotonicTime(a) != event.Timestamp {
+				t.Fatalf("Expected %d, got %d", a, event.Timestamp)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected event %d", a)
+		}
+	}
+}
+
+type inlineRecorder struct {
+	*EventHandlerMap
+	inline chan bool
+}
+
+func (recorder *inlineRecorder) Add(handler EventHandler) error {
+	recorder.inline <- handler.(*Handler).isInline()
+	return recorder.EventHandlerMap.Add(handler)
+}
+
+func TestSubscriptionInlineBeforeAdd(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/subscription")
+	mockSocket := NewMock(socketURL)
+	recorder := &inlineRecorder{
+		EventHandlerMap: NewEventHandlerMap(),
+		inline:          make(chan bool, 1),
+	}
+	mockSocket.handlers = recorder
+
+	sub := mockSocket.Page().SubscribeLoadEventFired(nil)
+	defer sub.Unsubscribe()
+	if !<-recorder.inline {
+		t.Errorf("Expected the handler to be inline when it is registered")
+	}
+}
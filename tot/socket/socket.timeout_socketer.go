@@ -0,0 +1,62 @@
+package socket
+
+import (
+	"time"
+)
+
+/*
+WithTimeout returns a Socketer that overrides the socket default timeout for
+every command sent through it. Commands that have not received a response when
+the timeout expires are removed from the command stack and receive a
+TimeoutError. A negative timeout disables the timeout. The timeout replaces any
+timeout the socket was previously bound to.
+*/
+func WithTimeout(timeout time.Duration, socket Socketer) Socketer {
+	return &TimeoutSocket{
+		Socketer: withoutTimeout(socket),
+		timeout:  timeout,
+	}
+}
+
+/*
+withoutTimeout removes the TimeoutSocket wrappers from a chain of socket
+wrappers so an inner timeout can't override an outer one.
+*/
+func withoutTimeout(socket Socketer) Socketer {
+	switch wrapper := socket.(type) {
+	case *TimeoutSocket:
+		return withoutTimeout(wrapper.Socketer)
+	case *ContextSocket:
+		return &ContextSocket{
+			Socketer: withoutTimeout(wrapper.Socketer),
+			ctx:      wrapper.ctx,
+		}
+	}
+	return socket
+}
+
+/*
+TimeoutSocket provides a Socketer interface that sets a timeout on commands
+before delivering them to the underlying socket.
+*/
+type TimeoutSocket struct {
+	Socketer
+	timeout time.Duration
+}
+
+/*
+SendCommand sets the command timeout and delivers it to the underlying socket.
+
+SendCommand is a Socketer implementation.
+*/
+func (socket *TimeoutSocket) SendCommand(command Commander) chan *Response {
+	command.SetTimeout(socket.timeout)
+	return socket.Socketer.SendCommand(command)
+}
+
+/*
+Timeout returns the timeout commands are bound to.
+*/
+func (socket *TimeoutSocket) Timeout() time.Duration {
+	return socket.timeout
+}
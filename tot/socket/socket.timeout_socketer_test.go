@@ -0,0 +1,103 @@
+package socket
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/page"
+)
+
+func TestSocketTimeout(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/timeout")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	mockSocket.SetTimeout(50 * time.Millisecond)
+	if 50*time.Millisecond != mockSocket.Timeout() {
+		t.Errorf("Expected 50ms, got %s", mockSocket.Timeout())
+	}
+
+	command := NewCommand(mockSocket, "Some.method", nil)
+	result := <-mockSocket.SendCommand(command)
+	err, ok := result.Err().(*TimeoutError)
+	if !ok {
+		t.Fatalf("Expected *TimeoutError, got %T: %v", result.Err(), result.Err())
+	}
+	if command.ID() != err.ID {
+		t.Errorf("Expected %d, got %d", command.ID(), err.ID)
+	}
+	if "Some.method" != err.Method {
+		t.Errorf("Expected Some.method, got %s", err.Method)
+	}
+	if _, e := mockSocket.commands.Get(err.ID); nil == e {
+		t.Errorf("Expected command #%d to be removed from the command stack", err.ID)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/timeout")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	mockSocket.SetTimeout(time.Hour)
+	resultChan := mockSocket.Page().WithTimeout(50 * time.Millisecond).Navigate(&page.NavigateParams{
+		URL: "https://www.example.com/",
+	})
+	result := <-resultChan
+	err, ok := result.Err.(*TimeoutError)
+	if !ok {
+		t.Fatalf("Expected *TimeoutError, got %T: %v", result.Err, result.Err)
+	}
+	if 50*time.Millisecond != err.Timeout {
+		t.Errorf("Expected 50ms, got %s", err.Timeout)
+	}
+	if "Page.navigate" != err.Method {
+		t.Errorf("Expected Page.navigate, got %s", err.Method)
+	}
+}
+
+func TestWithTimeoutDisabled(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/timeout")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	mockSocket.SetTimeout(10 * time.Millisecond)
+	resultChan := mockSocket.Page().WithTimeout(-1).BringToFront()
+	time.Sleep(50 * time.Millisecond)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     mockSocket.CurCommandID(),
+		Error:  &Error{},
+		Result: []byte(`{}`),
+	})
+	result := <-resultChan
+	if nil != result.Err {
+		t.Errorf("Expected nil, got error: '%s'", result.Err.Error())
+	}
+}
+
+func TestWithTimeoutNested(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/timeout")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	socket := WithTimeout(
+		50*time.Millisecond,
+		WithContext(ctx, WithTimeout(time.Hour, mockSocket)),
+	)
+	result := <-socket.SendCommand(NewCommand(mockSocket, "Some.method", nil))
+	err, ok := result.Err().(*TimeoutError)
+	if !ok {
+		t.Fatalf("Expected *TimeoutError, got %T: %v", result.Err(), result.Err())
+	}
+	if 50*time.Millisecond != err.Timeout {
+		t.Errorf("Expected 50ms, got %s", err.Timeout)
+	}
+}
@@ -0,0 +1,54 @@
+package socket
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+/*
+WaitFor blocks until the subscription delivers an event that satisfies the
+predicate and returns that event. A nil predicate matches the first event. The
+subscription is unsubscribed before WaitFor returns. If the context is done
+first the context error is returned.
+*/
+func WaitFor[T any](
+	ctx context.Context,
+	sub *Subscription[T],
+	predicate func(event T) bool,
+) (T, error) {
+	defer sub.Unsubscribe()
+
+	var empty T
+	for {
+		select {
+		case <-ctx.Done():
+			return empty, ctx.Err()
+		case event, ok := <-sub.Events():
+			if !ok {
+				return empty, errors.New("subscription closed before a matching event was received")
+			}
+			if nil == predicate || predicate(event) {
+				return event, nil
+			}
+		}
+	}
+}
+
+/*
+WaitForEvent blocks until the socket receives an event with the specified
+method name that satisfies the predicate and returns the raw event response. A
+nil predicate matches the first event. The event handler is removed before
+WaitForEvent returns.
+*/
+func (socket *Socket) WaitForEvent(
+	ctx context.Context,
+	method string,
+	predicate func(response *Response) bool,
+) (*Response, error) {
+	sub := NewSubscription[*Response](socket, nil)
+	handler := NewEventHandler(method, sub.Deliver)
+	sub.SetHandler(handler)
+	socket.AddEventHandler(handler)
+	return WaitFor(ctx, sub, predicate)
+}
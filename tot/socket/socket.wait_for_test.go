@@ -0,0 +1,89 @@
+package socket
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/page"
+)
+
+func TestWaitForEvent(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/wait")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	for _, result := range []string{`"first"`, `"second"`} {
+		mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+			ID:     0,
+			Error:  &Error{},
+			Method: "Some.event",
+			Result: []byte(result),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	response, err := mockSocket.WaitForEvent(ctx, "Some.event", func(response *Response) bool {
+		return `"second"` == string(response.Result)
+	})
+	if nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	if `"second"` != string(response.Result) {
+		t.Errorf("Expected '\"second\"', got '%s'", response.Result)
+	}
+	handlers, _ := mockSocket.handlers.Get("Some.event")
+	if 0 != len(handlers) {
+		t.Errorf("Expected no handlers, found %d", len(handlers))
+	}
+}
+
+func TestWaitForEventTimeout(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/wait")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := mockSocket.WaitForEvent(ctx, "Some.event", nil)
+	if context.DeadlineExceeded != err {
+		t.Errorf("Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	handlers, _ := mockSocket.handlers.Get("Some.event")
+	if 0 != len(handlers) {
+		t.Errorf("Expected no handlers, found %d", len(handlers))
+	}
+}
+
+func TestWaitForTyped(t *testing.T) {
+	socketURL, _ := url.Parse("https://www.example.com/wait")
+	mockSocket := NewMock(socketURL)
+	go mockSocket.Listen()
+	defer mockSocket.Stop()
+
+	mockResult := &page.LoadEventFiredEvent{
+		Timestamp: page.MonotonicTime(time.Now().Unix()),
+	}
+	mockResultBytes, _ := json.Marshal(mockResult)
+	mockSocket.Conn().(*MockChromeWebSocket).AddMockData(&Response{
+		ID:     0,
+		Error:  &Error{},
+		Method: "Page.loadEventFired",
+		Result: mockResultBytes,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	result, err := mockSocket.Page().WaitForLoadEventFired(ctx, nil)
+	if nil != err {
+		t.Fatalf("Expected nil, got error: '%s'", err.Error())
+	}
+	if mockResult.Timestamp != result.Timestamp {
+		t.Errorf("Expected %d, got %d", mockResult.Timestamp, result.Timestamp)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
 
 	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
@@ -27,7 +28,10 @@ func NewWebsocket(socketURL *url.URL) (WebSocketer, error) {
 	}
 	log.Infof("Websocket connection to %s established: %s", socketURL.String(), response.Status)
 
-	return &ChromeWebSocket{conn: websocket}, nil
+	return &ChromeWebSocket{
+		conn:     websocket,
+		writeMux: &sync.Mutex{},
+	}, nil
 }
 
 /*
@@ -39,6 +43,7 @@ ChromeWebSocket represents a WebSocketer interface
 type ChromeWebSocket struct {
 	conn          *websocket.Conn
 	mockResponses []*Response
+	writeMux      *sync.Mutex
 }
 
 /*
@@ -73,6 +78,7 @@ func (socket *ChromeWebSocket) ReadJSON(v interface{}) error {
 
 /*
 WriteJSON marshalls the provided data as JSON and writes it to the websocket.
+Writes are serialized, the connection supports a single concurrent writer.
 
 WriteJSON is a WebSocketer implementation.
 */
@@ -80,5 +86,7 @@ func (socket *ChromeWebSocket) WriteJSON(v interface{}) error {
 	if nil == socket.conn {
 		return errors.New("not connected")
 	}
+	socket.writeMux.Lock()
+	defer socket.writeMux.Unlock()
 	return socket.conn.WriteJSON(v)
 }
@@ -0,0 +1,64 @@
+package socket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestChromeWebSocketConcurrentWrites(t *testing.T) {
+	count := 50
+	received := make(chan *Payload, count)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if nil != err {
+			return
+		}
+		defer conn.Close()
+		for {
+			payload := &Payload{}
+			if err := conn.ReadJSON(payload); nil != err {
+				return
+			}
+			received <- payload
+		}
+	}))
+	defer server.Close()
+
+	socketURL, _ := url.Parse("ws://" + strings.TrimPrefix(server.URL, "http://"))
+	conn, err := NewWebsocket(socketURL)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	defer conn.Close()
+
+	wg := &sync.WaitGroup{}
+	for a := 0; a < count; a++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			if err := conn.WriteJSON(&Payload{ID: id, Method: "Page.enable"}); nil != err {
+				t.Errorf("Expected nil, received error: '%s'", err.Error())
+			}
+		}(a + 1)
+	}
+	wg.Wait()
+
+	for a := 0; a < count; a++ {
+		select {
+		case payload := <-received:
+			if "Page.enable" != payload.Method {
+				t.Errorf("Expected Page.enable, received '%s'", payload.Method)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for message %d", a)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/dom"
+	"github.com/mkenney/go-chrome/tot/cdtp/runtime"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+/*
+objectGroupCount numbers the object groups of element handles.
+*/
+var objectGroupCount uint64
+
+/*
+Document returns a handle for the document of the tab.
+*/
+func (tab *Tab) Document(ctx context.Context) (*ElementHandle, error) {
+	return tab.document(ctx, "")
+}
+
+/*
+document returns a handle for the document of the tab in an object group, or
+in the element group if group is empty.
+*/
+func (tab *Tab) document(ctx context.Context, group string) (*ElementHandle, error) {
+	objectGroup := group
+	if "" == objectGroup {
+		objectGroup = tab.elementGroup()
+	}
+	result := <-tab.Protocol().Runtime().WithContext(ctx).Evaluate(&runtime.EvaluateParams{
+		Expression:  "document",
+		ObjectGroup: objectGroup,
+	})
+	if nil != result.Err {
+		return nil, errors.Wrap(result.Err, "could not evaluate the document")
+	}
+	if nil != result.ExceptionDetails {
+		return nil, exceptionError(result.ExceptionDetails)
+	}
+	document := newElementHandle(tab, result.Result)
+	if nil == document {
+		return nil, errors.New("the tab has no document")
+	}
+	document.objectGroup = group
+	return document, nil
+}
+
+/*
+QuerySelector returns the first element in the tab matching a selector, or nil
+if there is none. Selectors are CSS selectors unless they are prefixed with a
+selector engine name, see SelectorEngine.
+*/
+func (tab *Tab) QuerySelector(ctx context.Context, selector string) (*ElementHandle, error) {
+	document, err := tab.Document(ctx)
+	if nil != err {
+		return nil, err
+	}
+	return document.QuerySelector(ctx, selector)
+}
+
+/*
+QuerySelectorAll returns all elements in the tab matching a selector.
+*/
+func (tab *Tab) QuerySelectorAll(ctx context.Context, selector string) ([]*ElementHandle, error) {
+	document, err := tab.Document(ctx)
+	if nil != err {
+		return nil, err
+	}
+	return document.QuerySelectorAll(ctx, selector)
+}
+
+/*
+ReleaseElements releases all element handles returned by queries in the tab.
+Handles are released automatically when the tab navigates to a new document.
+*/
+func (tab *Tab) ReleaseElements(ctx context.Context) error {
+	tab.mux.Lock()
+	group := tab.objectGroup
+	tab.objectGroup = ""
+	tab.mux.Unlock()
+	if "" == group {
+		return nil
+	}
+
+	result := <-tab.Protocol().Runtime().WithContext(ctx).ReleaseObjectGroup(&runtime.ReleaseObjectGroupParams{
+		ObjectGroup: group,
+	})
+	if nil != result.Err {
+		return errors.Wrap(result.Err, "could not release the elements")
+	}
+	return nil
+}
+
+/*
+elementGroup returns the object group element handles are added to.
+*/
+func (tab *Tab) elementGroup() string {
+	tab.mux.Lock()
+	defer tab.mux.Unlock()
+	if "" == tab.objectGroup {
+		tab.objectGroup = fmt.Sprintf("go-chrome-elements-%d", atomic.AddUint64(&objectGroupCount, 1))
+	}
+	return tab.objectGroup
+}
+
+/*
+keepObject copies a remote object into the element group and returns the copy,
+so that it survives the release of the temporary object group it was created
+in. Values that aren't remote objects are returned as is.
+*/
+func (tab *Tab) keepObject(ctx context.Context, object *runtime.RemoteObject) (*runtime.RemoteObject, error) {
+	if nil == object || "" == object.ObjectID {
+		return object, nil
+	}
+	result := <-tab.Protocol().Runtime().WithContext(ctx).CallFunctionOn(&runtime.CallFunctionOnParams{
+		FunctionDeclaration: keepObjectFunction,
+		ObjectGroup:         tab.elementGroup(),
+		ObjectID:            object.ObjectID,
+	})
+	if nil != result.Err {
+		return nil, errors.Wrap(result.Err, "could not keep the remote object")
+	}
+	if nil != result.ExceptionDetails {
+		return nil, exceptionError(result.ExceptionDetails)
+	}
+	return result.Result, nil
+}
+
+/*
+keepElement copies an element handle into the element group, see keepObject.
+*/
+func (tab *Tab) keepElement(ctx context.Context, element *ElementHandle) (*ElementHandle, error) {
+	object, err := tab.keepObject(ctx, &runtime.RemoteObject{
+		ObjectID: element.objectID,
+		Subtype:  runtime.ObjectSubtype.Node,
+		Type:     runtime.ObjectType.Object,
+	})
+	if nil != err {
+		return nil, err
+	}
+	return newElementHandle(tab, object), nil
+}
+
+/*
+keepObjectFunction returns its this value. Strict mode keeps primitive values
+from being boxed.
+*/
+const keepObjectFunction = `function() { "use strict"; return this }`
+
+/*
+releaseGroup releases a temporary object group. The release is not bound to a
+context, the group is released after the context of a wait is done.
+*/
+func (tab *Tab) releaseGroup(group string) {
+	result := <-tab.Protocol().Runtime().ReleaseObjectGroup(&runtime.ReleaseObjectGroupParams{
+		ObjectGroup: group,
+	})
+	if nil != result.Err {
+		log.Debugf("could not release object group %s: %s", group, result.Err)
+	}
+}
+
+/*
+resetElements starts a new object group after the document the current group's
+objects belong to was replaced.
+*/
+func (tab *Tab) resetElements() {
+	tab.mux.Lock()
+	tab.objectGroup = ""
+	tab.mux.Unlock()
+
+	tab.documentMux.Lock()
+	tab.documentRequested = false
+	tab.documentMux.Unlock()
+}
+
+/*
+nodeID returns the DOM node ID of a remote object.
+
+Requesting the document invalidates all node IDs the client has been given so
+it is only requested once per document. If the node can't be requested the
+document is requested again, it may have been replaced without the tab
+navigating.
+*/
+func (tab *Tab) nodeID(ctx context.Context, objectID runtime.RemoteObjectID) (dom.NodeID, error) {
+	tab.documentMux.Lock()
+	defer tab.documentMux.Unlock()
+
+	requested := tab.documentRequested
+	if !requested {
+		if err := tab.requestDocument(ctx); nil != err {
+			return 0, err
+		}
+	}
+	result := <-tab.Protocol().DOM().WithContext(ctx).RequestNode(&dom.RequestNodeParams{
+		ObjectID: objectID,
+	})
+	if nil != result.Err && requested {
+		if err := tab.requestDocument(ctx); nil != err {
+			return 0, err
+		}
+		result = <-tab.Protocol().DOM().WithContext(ctx).RequestNode(&dom.RequestNodeParams{
+			ObjectID: objectID,
+		})
+	}
+	if nil != result.Err {
+		return 0, errors.Wrap(result.Err, "could not request the element node")
+	}
+	return result.NodeID, nil
+}
+
+/*
+requestDocument requests the document so that nodes can be pushed to the
+client. The caller must hold the document lock.
+*/
+func (tab *Tab) requestDocument(ctx context.Context) error {
+	document := <-tab.Protocol().DOM().WithContext(ctx).GetDocument(&dom.GetDocumentParams{})
+	if nil != document.Err {
+		return errors.Wrap(document.Err, "could not get the document")
+	}
+	tab.documentRequested = true
+	return nil
+}
@@ -0,0 +1,243 @@
+package chrome
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/network"
+	"github.com/mkenney/go-chrome/tot/cdtp/page"
+	"github.com/pkg/errors"
+)
+
+/*
+NetworkIdleTime is the amount of time without network requests after which a
+page is considered network idle.
+*/
+var NetworkIdleTime = 500 * time.Millisecond
+
+/*
+WaitUntil selects the page lifecycle condition that completes a navigation.
+*/
+type WaitUntil int
+
+const (
+	// WaitLoad waits for the load event. It is the default.
+	WaitLoad WaitUntil = iota
+
+	// WaitDOMContentLoaded waits for the DOMContentLoaded event.
+	WaitDOMContentLoaded
+
+	// WaitNetworkIdle waits for the load event and then for the page to make
+	// no network requests for NetworkIdleTime.
+	WaitNetworkIdle
+)
+
+/*
+String implements Stringer.
+*/
+func (waitUntil WaitUntil) String() string {
+	switch waitUntil {
+	case WaitLoad:
+		return "load"
+	case WaitDOMContentLoaded:
+		return "DOMContentLoaded"
+	case WaitNetworkIdle:
+		return "networkIdle"
+	}
+	return fmt.Sprintf("WaitUntil(%d)", int(waitUntil))
+}
+
+/*
+NavigationResponse is the response to the main document request of a
+navigation.
+*/
+type NavigationResponse struct {
+	// FrameID is the ID of the navigated frame.
+	FrameID page.FrameID
+
+	// Headers contains the response headers.
+	Headers network.Headers
+
+	// LoaderID is the loader ID of the navigation.
+	LoaderID page.LoaderID
+
+	// Status is the HTTP status code.
+	Status int
+
+	// StatusText is the HTTP status text.
+	StatusText string
+
+	// URL is the URL of the response, after redirects.
+	URL string
+}
+
+/*
+navigation tracks the events of a navigation until its wait condition is met.
+*/
+type navigation struct {
+	domContentLoaded bool
+	frameID          page.FrameID
+	inflight         map[network.RequestID]bool
+	loaded           bool
+	loaderID         page.LoaderID
+	networkIdle      bool
+	response         *NavigationResponse
+	waitUntil        WaitUntil
+}
+
+/*
+done returns whether the wait condition is met.
+*/
+func (nav *navigation) done() bool {
+	switch nav.waitUntil {
+	case WaitDOMContentLoaded:
+		return nav.domContentLoaded
+	case WaitNetworkIdle:
+		return nav.loaded && nav.networkIdle
+	}
+	return nav.loaded
+}
+
+/*
+responseReceived records the response to the main document request. The main
+document request ID is the loader ID of the navigation.
+*/
+func (nav *navigation) responseReceived(event *network.ResponseReceivedEvent) {
+	if string(event.RequestID) != string(nav.loaderID) || nil == event.Response {
+		return
+	}
+	nav.response = &NavigationResponse{
+		FrameID:    nav.frameID,
+		Headers:    event.Response.Headers,
+		LoaderID:   nav.loaderID,
+		Status:     event.Response.Status,
+		StatusText: event.Response.StatusText,
+		URL:        event.Response.URL,
+	}
+}
+
+/*
+Goto navigates the tab to a URL and waits until the waitUntil condition is met
+or the context is done.
+
+The response to the main document request is returned. Navigations that fail,
+for example because the host can't be resolved, return an error with the
+errorText reported by Chromium. Same-document navigations, such as changing the
+URL fragment, complete immediately and return a nil response. Element handles
+of the previous document are invalid after a navigation.
+*/
+func (tab *Tab) Goto(ctx context.Context, url string, waitUntil WaitUntil) (*NavigationResponse, error) {
+	protocol := tab.Protocol()
+	if result := <-protocol.Page().WithContext(ctx).Enable(); nil != result.Err {
+		return nil, errors.Wrap(result.Err, "could not enable page events")
+	}
+	if result := <-protocol.Page().WithContext(ctx).SetLifecycleEventsEnabled(
+		&page.SetLifecycleEventsEnabledParams{Enabled: true},
+	); nil != result.Err {
+		return nil, errors.Wrap(result.Err, "could not enable lifecycle events")
+	}
+	if result := <-protocol.Network().WithContext(ctx).Enable(&network.EnableParams{}); nil != result.Err {
+		return nil, errors.Wrap(result.Err, "could not enable network events")
+	}
+
+	// Subscribe before navigating so no event is missed.
+	lifecycle := protocol.Page().SubscribeLifecycleEvent(nil)
+	defer lifecycle.Unsubscribe()
+	requests := protocol.Network().SubscribeRequestWillBeSent(nil)
+	defer requests.Unsubscribe()
+	responses := protocol.Network().SubscribeResponseReceived(nil)
+	defer responses.Unsubscribe()
+	finished := protocol.Network().SubscribeLoadingFinished(nil)
+	defer finished.Unsubscribe()
+	failed := protocol.Network().SubscribeLoadingFailed(nil)
+	defer failed.Unsubscribe()
+
+	result := <-protocol.Page().WithContext(ctx).Navigate(&page.NavigateParams{URL: url})
+	if nil != result.Err {
+		return nil, errors.Wrap(result.Err, fmt.Sprintf("navigation to '%s' failed", url))
+	}
+	if "" != result.ErrorText {
+		return nil, fmt.Errorf("navigation to '%s' failed: %s", url, result.ErrorText)
+	}
+	if "" == result.LoaderID {
+		return nil, nil
+	}
+	tab.resetElements()
+
+	nav := &navigation{
+		frameID:   result.FrameID,
+		inflight:  make(map[network.RequestID]bool),
+		loaderID:  result.LoaderID,
+		waitUntil: waitUntil,
+	}
+	idle := time.NewTimer(NetworkIdleTime)
+	defer idle.Stop()
+	resetIdle := func() {
+		if !idle.Stop() {
+			select {
+			case <-idle.C:
+			default:
+			}
+		}
+		if 0 == len(nav.inflight) {
+			idle.Reset(NetworkIdleTime)
+		}
+	}
+
+	for !nav.done() {
+		select {
+		case <-ctx.Done():
+			return nav.response, errors.Wrap(ctx.Err(), fmt.Sprintf("navigation to '%s' did not reach %s", url, waitUntil))
+
+		case event := <-lifecycle.Events():
+			if event.FrameID != nav.frameID || event.LoaderID != nav.loaderID {
+				continue
+			}
+			switch event.Name {
+			case "DOMContentLoaded":
+				nav.domContentLoaded = true
+			case "load":
+				nav.loaded = true
+			case "networkIdle":
+				nav.networkIdle = true
+			}
+
+		case event := <-requests.Events():
+			nav.inflight[event.RequestID] = true
+			nav.networkIdle = false
+			resetIdle()
+
+		case event := <-responses.Events():
+			nav.responseReceived(event)
+
+		case event := <-finished.Events():
+			delete(nav.inflight, event.RequestID)
+			resetIdle()
+
+		case event := <-failed.Events():
+			if string(event.RequestID) == string(nav.loaderID) {
+				return nil, fmt.Errorf("navigation to '%s' failed: %s", url, event.ErrorText)
+			}
+			delete(nav.inflight, event.RequestID)
+			resetIdle()
+
+		case <-idle.C:
+			if 0 == len(nav.inflight) {
+				nav.networkIdle = true
+			}
+		}
+	}
+
+	// The document response precedes the lifecycle events, but events of
+	// different types are delivered on separate channels.
+	if nil == nav.response {
+		select {
+		case event := <-responses.Events():
+			nav.responseReceived(event)
+		default:
+		}
+	}
+
+	return nav.response, nil
+}
@@ -0,0 +1,181 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+/*
+newPageTab returns a tab connected to a test server that emulates a page
+socket. The respond function returns the result of each command and the event
+messages that follow it, which are spaced out so they are handled in order. An
+empty result is sent as an empty object.
+*/
+func newPageTab(t *testing.T, respond func(method string, params json.RawMessage) (string, []string)) *Tab {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if nil != err {
+			return
+		}
+		defer conn.Close()
+		for {
+			command := struct {
+				ID     int             `json:"id"`
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			}{}
+			if err := conn.ReadJSON(&command); nil != err {
+				return
+			}
+			result, events := respond(command.Method, command.Params)
+			if "" == result {
+				result = "{}"
+			}
+			conn.WriteJSON(map[string]interface{}{"id": command.ID, "result": json.RawMessage(result)})
+			for _, event := range events {
+				time.Sleep(10 * time.Millisecond)
+				conn.WriteMessage(websocket.TextMessage, []byte(event))
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	tab := &Tab{
+		chrome: New(&Flags{}, "", "", "", ""),
+		data: &TabData{
+			ID:                   "tab-1",
+			WebSocketDebuggerURL: "ws://" + strings.TrimPrefix(server.URL, "http://") + "/devtools/page/tab-1",
+		},
+	}
+	if err := tab.connect(); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	t.Cleanup(func() { tab.Socket().Stop() })
+	return tab
+}
+
+/*
+navigateResponder responds to Page.navigate with the provided result and
+events.
+*/
+func navigateResponder(result string, events ...string) func(string, json.RawMessage) (string, []string) {
+	return func(method string, params json.RawMessage) (string, []string) {
+		if "Page.navigate" != method {
+			return "", nil
+		}
+		return result, events
+	}
+}
+
+func TestTabGoto(t *testing.T) {
+	tab := newPageTab(t, navigateResponder(
+		`{"frameId": "frame-1", "loaderId": "loader-1"}`,
+		`{"method": "Network.requestWillBeSent", "params": {"requestId": "loader-1", "loaderId": "loader-1", "frameId": "frame-1"}}`,
+		`{"method": "Network.responseReceived", "params": {"requestId": "loader-1", "loaderId": "loader-1", "frameId": "frame-1", "response": {"url": "https://example.com/", "status": 200, "statusText": "OK", "headers": {"Content-Type": "text/html"}}}}`,
+		`{"method": "Network.loadingFinished", "params": {"requestId": "loader-1"}}`,
+		`{"method": "Page.lifecycleEvent", "params": {"frameId": "frame-1", "loaderId": "loader-0", "name": "load"}}`,
+		`{"method": "Page.lifecycleEvent", "params": {"frameId": "frame-1", "loaderId": "loader-1", "name": "DOMContentLoaded"}}`,
+		`{"method": "Page.lifecycleEvent", "params": {"frameId": "frame-1", "loaderId": "loader-1", "name": "load"}}`,
+	))
+
+	response, err := tab.Goto(context.Background(), "https://example.com/", WaitLoad)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if nil == response {
+		t.Fatalf("Expected a response, received nil")
+	}
+	if 200 != response.Status || "OK" != response.StatusText {
+		t.Errorf("Expected 200 OK, received %d %s", response.Status, response.StatusText)
+	}
+	if "https://example.com/" != response.URL || "frame-1" != string(response.FrameID) || "loader-1" != string(response.LoaderID) {
+		t.Errorf("Unexpected response %+v", response)
+	}
+	if "text/html" != response.Headers["Content-Type"] {
+		t.Errorf("Expected 'text/html', received '%v'", response.Headers["Content-Type"])
+	}
+}
+
+func TestTabGotoError(t *testing.T) {
+	tab := newPageTab(t, navigateResponder(
+		`{"frameId": "frame-1", "loaderId": "loader-1", "errorText": "net::ERR_NAME_NOT_RESOLVED"}`,
+	))
+	_, err := tab.Goto(context.Background(), "https://invalid.invalid/", WaitLoad)
+	if nil == err {
+		t.Fatalf("Expected error, received nil")
+	}
+	if !strings.Contains(err.Error(), "net::ERR_NAME_NOT_RESOLVED") {
+		t.Errorf("Expected the error text, received '%s'", err.Error())
+	}
+
+	tab = newPageTab(t, navigateResponder(
+		`{"frameId": "frame-1", "loaderId": "loader-1"}`,
+		`{"method": "Network.requestWillBeSent", "params": {"requestId": "loader-1", "loaderId": "loader-1", "frameId": "frame-1"}}`,
+		`{"method": "Network.loadingFailed", "params": {"requestId": "loader-1", "errorText": "net::ERR_CONNECTION_RESET"}}`,
+	))
+	_, err = tab.Goto(context.Background(), "https://example.com/", WaitLoad)
+	if nil == err {
+		t.Fatalf("Expected error, received nil")
+	}
+	if !strings.Contains(err.Error(), "net::ERR_CONNECTION_RESET") {
+		t.Errorf("Expected the error text, received '%s'", err.Error())
+	}
+}
+
+func TestTabGotoNetworkIdle(t *testing.T) {
+	idleTime := NetworkIdleTime
+	NetworkIdleTime = 100 * time.Millisecond
+	defer func() { NetworkIdleTime = idleTime }()
+
+	tab := newPageTab(t, navigateResponder(
+		`{"frameId": "frame-1", "loaderId": "loader-1"}`,
+		`{"method": "Network.requestWillBeSent", "params": {"requestId": "loader-1", "loaderId": "loader-1", "frameId": "frame-1"}}`,
+		`{"method": "Network.loadingFinished", "params": {"requestId": "loader-1"}}`,
+		`{"method": "Page.lifecycleEvent", "params": {"frameId": "frame-1", "loaderId": "loader-1", "name": "load"}}`,
+		`{"method": "Network.requestWillBeSent", "params": {"requestId": "image-1", "loaderId": "loader-1", "frameId": "frame-1"}}`,
+	))
+
+	// The image request never finishes.
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	if _, err := tab.Goto(ctx, "https://example.com/", WaitNetworkIdle); nil == err {
+		t.Fatalf("Expected error, received nil")
+	} else if !strings.Contains(err.Error(), "did not reach networkIdle") {
+		t.Errorf("Expected a timeout, received '%s'", err.Error())
+	}
+
+	tab = newPageTab(t, navigateResponder(
+		`{"frameId": "frame-1", "loaderId": "loader-1"}`,
+		`{"method": "Network.requestWillBeSent", "params": {"requestId": "loader-1", "loaderId": "loader-1", "frameId": "frame-1"}}`,
+		`{"method": "Network.loadingFinished", "params": {"requestId": "loader-1"}}`,
+		`{"method": "Page.lifecycleEvent", "params": {"frameId": "frame-1", "loaderId": "loader-1", "name": "load"}}`,
+		`{"method": "Network.requestWillBeSent", "params": {"requestId": "image-1", "loaderId": "loader-1", "frameId": "frame-1"}}`,
+		`{"method": "Network.loadingFinished", "params": {"requestId": "image-1"}}`,
+	))
+	start := time.Now()
+	if _, err := tab.Goto(context.Background(), "https://example.com/", WaitNetworkIdle); nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("Expected to wait for the network to be idle, returned after %s", elapsed)
+	}
+}
+
+func TestTabGotoSameDocument(t *testing.T) {
+	tab := newPageTab(t, navigateResponder(`{"frameId": "frame-1"}`))
+	response, err := tab.Goto(context.Background(), "https://example.com/#section", WaitLoad)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if nil != response {
+		t.Errorf("Expected nil, received %+v", response)
+	}
+}
@@ -3,7 +3,9 @@ package chrome
 import (
 	"fmt"
 	"net/url"
+	"sync"
 
+	"github.com/mkenney/go-chrome/tot/cdtp/target"
 	"github.com/mkenney/go-chrome/tot/socket"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -29,37 +31,260 @@ func (chrome *Chrome) NewTab(uri string) (*Tab, error) {
 		url:    targetURL,
 	}
 
-	_, err = tab.Chromium().Query(
-		fmt.Sprintf("/json/new?%s", url.QueryEscape(uri)),
-		url.Values{},
-		tab.data,
-	)
+	if chrome.PipeMode() {
+		if err = chrome.createTarget(tab, ""); nil != err {
+			return nil, err
+		}
+	} else {
+		_, err = tab.Chromium().Query(
+			fmt.Sprintf("/json/new?%s", url.QueryEscape(uri)),
+			url.Values{},
+			tab.data,
+		)
+		if nil != err {
+			return nil, errors.Wrap(err, fmt.Sprintf("/new?%s query failed", url.QueryEscape(uri)))
+		}
+	}
+
+	return chrome.openTab(tab)
+}
+
+/*
+newContextTab spawns a new Tab in the specified browser context using the
+Target domain and returns a reference to it.
+*/
+func (chrome *Chrome) newContextTab(uri string, contextID target.BrowserContextID) (*Tab, error) {
+	if "" == uri {
+		uri = "about:blank"
+	}
+	targetURL, err := url.Parse(uri)
 	if nil != err {
-		return nil, errors.Wrap(err, fmt.Sprintf("/new?%s query failed", url.QueryEscape(uri)))
+		return nil, errors.Wrap(err, "invalid URL")
 	}
 
-	websocketURL, err := url.Parse(tab.Data().WebSocketDebuggerURL)
+	tab := &Tab{
+		chrome: chrome,
+		data:   &TabData{},
+		url:    targetURL,
+	}
+	if err = chrome.createTarget(tab, contextID); nil != err {
+		return nil, err
+	}
+
+	return chrome.openTab(tab)
+}
+
+/*
+createTarget creates the target for a new tab using the Target domain instead
+of the /json/new endpoint.
+*/
+func (chrome *Chrome) createTarget(tab *Tab, contextID target.BrowserContextID) error {
+	browser, err := chrome.BrowserSocket()
 	if nil != err {
-		return nil, errors.Wrap(err, fmt.Sprintf("invalid websocket URL '%s'", tab.Data().WebSocketDebuggerURL))
+		return err
 	}
 
-	socket := socket.New(websocketURL)
-	tab.socket = socket
-	tab.protocol = socket
-	chrome.tabs = append(chrome.tabs, tab)
+	result := <-browser.Target().CreateTarget(&target.CreateTargetParams{
+		URL:              tab.URL().String(),
+		BrowserContextID: contextID,
+	})
+	if nil != result.Err {
+		return errors.Wrap(result.Err, "target creation failed")
+	}
+	tab.data.ID = string(result.ID)
+	tab.data.Type = "page"
+	tab.data.URL = tab.URL().String()
+	if !chrome.PipeMode() {
+		tab.data.WebSocketDebuggerURL = chrome.pageWebSocketURL(tab.data.ID)
+	}
+	return nil
+}
+
+/*
+openTab connects a new tab and adds it to the list of open tabs. If the target
+has already been added, e.g. by target discovery, the existing tab is returned.
+*/
+func (chrome *Chrome) openTab(tab *Tab) (*Tab, error) {
+	if err := tab.connect(); nil != err {
+		return nil, err
+	}
+
+	if added := chrome.addTab(tab); added != tab {
+		tab.Socket().Stop()
+		return added, nil
+	}
 
 	return tab, nil
 }
 
+/*
+pageWebSocketURL returns the websocket debugger URL of a target.
+*/
+func (chrome *Chrome) pageWebSocketURL(targetID string) string {
+	return fmt.Sprintf("ws://%s:%d/devtools/page/%s", chrome.Address(), chrome.Port(), targetID)
+}
+
+/*
+RefreshTabs implements Chromium.
+
+Page targets that are not yet known are wrapped in a new Tab, the metadata of
+known tabs is updated and tabs whose targets no longer exist are removed. Other
+targets, such as service workers and iframes, and targets without a
+webSocketDebuggerUrl, such as those already attached to another client, are
+skipped.
+*/
+func (chrome *Chrome) RefreshTabs() error {
+	targets := []*TabData{}
+	if chrome.PipeMode() {
+		var err error
+		if targets, err = chrome.refreshPipeTabs(); nil != err {
+			return err
+		}
+	} else if _, err := chrome.Query("/json/list", url.Values{}, &targets); nil != err {
+		return errors.Wrap(err, "/list query failed")
+	}
+
+	found := make(map[string]bool)
+	for _, data := range targets {
+		if "page" != data.Type {
+			continue
+		}
+		if "" == data.WebSocketDebuggerURL && !chrome.PipeMode() {
+			continue
+		}
+		found[data.ID] = true
+
+		if existing, err := chrome.GetTab(data.ID); nil == err {
+			existing.(*Tab).setData(data)
+			continue
+		}
+
+		targetURL, err := url.Parse(data.URL)
+		if nil != err {
+			return errors.Wrap(err, fmt.Sprintf("invalid URL '%s'", data.URL))
+		}
+		tab := &Tab{
+			chrome: chrome,
+			data:   data,
+			url:    targetURL,
+		}
+		if err := tab.connect(); nil != err {
+			return err
+		}
+		if added := chrome.addTab(tab); added != tab {
+			tab.Socket().Stop()
+		}
+	}
+
+	for _, tab := range chrome.Tabs() {
+		if !found[tab.Data().ID] {
+			chrome.removeTab(tab)
+			tab.Socket().Stop()
+		}
+	}
+
+	return nil
+}
+
+/*
+addTab adds a tab to the list of open tabs. If a tab with the same ID is already
+in the list that tab is returned instead and the list is unchanged.
+*/
+func (chrome *Chrome) addTab(tab *Tab) *Tab {
+	chrome.tabMux.Lock()
+	defer chrome.tabMux.Unlock()
+	return chrome.appendTab(tab)
+}
+
+/*
+appendTab adds a tab to the list of open tabs unless a tab with the same ID is
+already in the list, in which case that tab is returned. The caller must hold
+tabMux.
+*/
+func (chrome *Chrome) appendTab(tab *Tab) *Tab {
+	for _, existing := range chrome.tabs {
+		if existing.Data().ID == tab.Data().ID {
+			return existing
+		}
+	}
+	chrome.tabs = append(chrome.tabs, tab)
+	return tab
+}
+
+/*
+removeTab removes a tab from the list of open tabs, and from its browser
+context if it has one. The list is copied rather than modified in place so
+slices previously returned by Tabs() are unaffected.
+*/
+func (chrome *Chrome) removeTab(tab *Tab) {
+	chrome.tabMux.Lock()
+	tabs := make([]*Tab, 0, len(chrome.tabs))
+	for _, t := range chrome.tabs {
+		if t != tab {
+			tabs = append(tabs, t)
+		}
+	}
+	chrome.tabs = tabs
+	chrome.tabMux.Unlock()
+
+	if nil != tab.browserContext {
+		tab.browserContext.removeTab(tab)
+	}
+}
+
 /*
 Tab is a struct representing an individual Chrome tab
 */
 type Tab struct {
-	chrome   *Chrome
-	data     *TabData
-	protocol socket.Protocoller
-	socket   socket.Socketer
-	url      *url.URL
+	browserContext    *BrowserContext
+	chrome            *Chrome
+	data              *TabData
+	documentMux       sync.Mutex
+	documentRequested bool
+	keyboard          *Keyboard
+	mouse             *Mouse
+	mux               sync.Mutex
+	objectGroup       string
+	protocol          socket.Protocoller
+	socket            socket.Socketer
+	url               *url.URL
+}
+
+/*
+connect opens the websocket connection to the tab's debugger URL. When using
+the pipe transport a flattened session is attached to the tab's target instead.
+*/
+func (tab *Tab) connect() error {
+	if tab.Chromium().PipeMode() {
+		browser, err := tab.Chromium().BrowserSocket()
+		if nil != err {
+			return err
+		}
+		session, err := browser.AttachSession(target.ID(tab.Data().ID))
+		if nil != err {
+			return errors.Wrap(err, fmt.Sprintf("could not attach to target %s", tab.Data().ID))
+		}
+		tab.socket = session
+		tab.protocol = session
+		return nil
+	}
+
+	websocketURL, err := url.Parse(tab.Data().WebSocketDebuggerURL)
+	if nil != err {
+		return errors.Wrap(err, fmt.Sprintf("invalid websocket URL '%s'", tab.Data().WebSocketDebuggerURL))
+	}
+
+	socket := socket.New(websocketURL)
+	tab.socket = socket
+	tab.protocol = socket
+	return nil
+}
+
+/*
+BrowserContext implements Tabber.
+*/
+func (tab *Tab) BrowserContext() *BrowserContext {
+	return tab.browserContext
 }
 
 /*
@@ -76,23 +301,71 @@ func (tab *Tab) Close() (interface{}, error) {
 	var err error
 	var result interface{}
 
-	_, err = tab.Chromium().Query(fmt.Sprintf("/json/close/%s", tab.Data().ID), url.Values{}, &result)
+	if tab.Chromium().PipeMode() {
+		result, err = tab.Chromium().closePipeTab(tab)
+	} else {
+		_, err = tab.Chromium().Query(fmt.Sprintf("/json/close/%s", tab.Data().ID), url.Values{}, &result)
+	}
 	log.Debugf("Close result: %s - %s", result, err)
 	if nil != err {
 		log.Warnf("%s: %s", result, err)
 		return nil, errors.Wrap(err, fmt.Sprintf("close/%s query failed", tab.Data().ID))
 	}
 
+	tab.Chromium().removeTab(tab)
+	if nil != tab.Socket() {
+		tab.Socket().Stop()
+	}
+
 	return result, nil
 }
 
 /*
 Data implements Tabber.
+
+The data is replaced rather than modified when the metadata of the target
+changes, the returned struct is never updated.
 */
 func (tab *Tab) Data() *TabData {
+	tab.mux.Lock()
+	defer tab.mux.Unlock()
 	return tab.data
 }
 
+/*
+setData replaces the metadata of the tab.
+*/
+func (tab *Tab) setData(data *TabData) {
+	tab.mux.Lock()
+	tab.data = data
+	tab.mux.Unlock()
+}
+
+/*
+Keyboard implements Tabber.
+*/
+func (tab *Tab) Keyboard() *Keyboard {
+	tab.mux.Lock()
+	defer tab.mux.Unlock()
+	if nil == tab.keyboard {
+		tab.keyboard = newKeyboard(tab)
+	}
+	return tab.keyboard
+}
+
+/*
+Mouse implements Tabber.
+*/
+func (tab *Tab) Mouse() *Mouse {
+	keyboard := tab.Keyboard()
+	tab.mux.Lock()
+	defer tab.mux.Unlock()
+	if nil == tab.mouse {
+		tab.mouse = newMouse(tab, keyboard)
+	}
+	return tab.mouse
+}
+
 /*
 Protocol implements Tabber.
 */
@@ -0,0 +1,277 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/runtime"
+	"github.com/pkg/errors"
+)
+
+/*
+PollInterval is the interval at which WaitForSelector and WaitForFunction
+evaluate their condition in the page.
+*/
+var PollInterval = 100 * time.Millisecond
+
+/*
+SelectorState is the state of the element matching a selector that
+WaitForSelector waits for.
+*/
+type SelectorState int
+
+const (
+	// SelectorAttached waits for an element matching the selector to be in
+	// the document. It is the default.
+	SelectorAttached SelectorState = iota
+
+	// SelectorVisible waits for an element matching the selector to be in the
+	// document and visible. Elements are visible when they have a non-empty
+	// bounding box and their visibility isn't hidden.
+	SelectorVisible
+
+	// SelectorHidden waits for no element matching the selector to be in the
+	// document, or for the matching element to be hidden.
+	SelectorHidden
+)
+
+/*
+String implements Stringer.
+*/
+func (state SelectorState) String() string {
+	switch state {
+	case SelectorAttached:
+		return "attached"
+	case SelectorVisible:
+		return "visible"
+	case SelectorHidden:
+		return "hidden"
+	}
+	return fmt.Sprintf("SelectorState(%d)", int(state))
+}
+
+/*
+elementVisibleFunction returns whether an element is visible.
+*/
+const elementVisibleFunction = `function(element) {
+	const style = window.getComputedStyle(element)
+	const rect = element.getBoundingClientRect()
+	return "hidden" !== style.visibility && rect.width > 0 && rect.height > 0
+}`
+
+/*
+waitForSelectorFunction evaluates a CSS selector state in the page. It returns
+the matching element once the attached or visible state is reached, true once
+the hidden state is reached and null otherwise.
+*/
+const waitForSelectorFunction = `function(selector, state) {
+	const element = document.querySelector(selector)
+	if ("attached" === state) {
+		return element
+	}
+	const visible = element ? (` + elementVisibleFunction + `)(element) : false
+	if ("visible" === state) {
+		return visible ? element : null
+	}
+	return visible ? null : true
+}`
+
+/*
+WaitForSelector waits until an element matching a selector reaches a state or
+the context is done. The condition is polled every PollInterval, so it survives
+navigations. The matching element is returned for the attached and visible
+states, the hidden state returns a nil element.
+
+CSS selectors are evaluated in the page, selectors of other engines query the
+document with the engine on every poll, see SelectorEngine.
+*/
+func (tab *Tab) WaitForSelector(ctx context.Context, selector string, state SelectorState) (*ElementHandle, error) {
+	switch state {
+	case SelectorAttached, SelectorVisible, SelectorHidden:
+	default:
+		return nil, fmt.Errorf("invalid selector state %s", state)
+	}
+	engine, query, err := parseSelector(selector)
+	if nil != err {
+		return nil, err
+	}
+	description := fmt.Sprintf("selector '%s' to be %s", selector, state)
+
+	if CSSSelectorEngine != engine {
+		var element *ElementHandle
+		err = tab.pollGroup(ctx, description, func(group string) (bool, error) {
+			document, err := tab.document(ctx, group)
+			if nil != err {
+				return false, err
+			}
+			match, err := engine.Query(ctx, document, query)
+			if nil != err || nil == match {
+				return SelectorHidden == state, err
+			}
+			if SelectorAttached != state {
+				visible, err := match.isVisible(ctx)
+				if nil != err || visible != (SelectorVisible == state) {
+					return false, err
+				}
+				if SelectorHidden == state {
+					return true, nil
+				}
+			}
+			element, err = tab.keepElement(ctx, match)
+			return nil == err, err
+		})
+		if nil != err {
+			return nil, err
+		}
+		return element, nil
+	}
+
+	expression, err := callExpression(waitForSelectorFunction, query, state.String())
+	if nil != err {
+		return nil, err
+	}
+	object, err := tab.pollExpression(ctx, description, expression, func(object *runtime.RemoteObject) bool {
+		if SelectorHidden == state {
+			return runtime.ObjectType.Boolean == object.Type && true == object.Value
+		}
+		return nil != newElementHandle(tab, object)
+	})
+	if nil != err || SelectorHidden == state {
+		return nil, err
+	}
+	return newElementHandle(tab, object), nil
+}
+
+/*
+WaitForFunction waits until a JavaScript expression evaluates to a truthy value
+or the context is done, and returns the value. The expression is evaluated
+every PollInterval and may return a promise, e.g.
+
+	tab.WaitForFunction(ctx, "document.readyState === 'complete'")
+*/
+func (tab *Tab) WaitForFunction(ctx context.Context, expression string) (*runtime.RemoteObject, error) {
+	return tab.pollExpression(ctx, fmt.Sprintf("'%s' to be truthy", expression), expression, isTruthy)
+}
+
+/*
+poll calls a check function until it returns true or the context is done.
+Errors returned by the check end the wait, checks interrupted by a navigation
+are retried.
+*/
+func (tab *Tab) poll(ctx context.Context, description string, check func() (bool, error)) error {
+	for {
+		done, err := check()
+		switch {
+		case nil != ctx.Err():
+			return errors.Wrap(ctx.Err(), fmt.Sprintf("timed out waiting for %s", description))
+		case nil != err:
+			if !isContextDestroyed(err) {
+				return errors.Wrap(err, fmt.Sprintf("could not wait for %s", description))
+			}
+		case done:
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), fmt.Sprintf("timed out waiting for %s", description))
+		case <-time.After(PollInterval):
+		}
+	}
+}
+
+/*
+pollGroup polls a check function like poll. Each check creates its remote
+objects in a temporary object group that is released after the check, so a long
+wait doesn't accumulate remote objects. Checks copy the objects they return to
+the element group with keepObject.
+*/
+func (tab *Tab) pollGroup(ctx context.Context, description string, check func(group string) (bool, error)) error {
+	group := fmt.Sprintf("go-chrome-poll-%d", atomic.AddUint64(&objectGroupCount, 1))
+	return tab.poll(ctx, description, func() (bool, error) {
+		defer tab.releaseGroup(group)
+		return check(group)
+	})
+}
+
+/*
+pollExpression evaluates an expression until the done function accepts its
+result or the context is done, and returns the accepted result, see pollGroup.
+*/
+func (tab *Tab) pollExpression(ctx context.Context, description, expression string, done func(*runtime.RemoteObject) bool) (*runtime.RemoteObject, error) {
+	var value *runtime.RemoteObject
+	err := tab.pollGroup(ctx, description, func(group string) (bool, error) {
+		result := <-tab.Protocol().Runtime().WithContext(ctx).Evaluate(&runtime.EvaluateParams{
+			AwaitPromise: true,
+			Expression:   expression,
+			ObjectGroup:  group,
+		})
+		switch {
+		case nil != result.Err:
+			return false, result.Err
+		case nil != result.ExceptionDetails:
+			return false, exceptionError(result.ExceptionDetails)
+		case nil == result.Result || !done(result.Result):
+			return false, nil
+		}
+		var err error
+		value, err = tab.keepObject(ctx, result.Result)
+		return nil == err, err
+	})
+	if nil != err {
+		return nil, err
+	}
+	return value, nil
+}
+
+/*
+callExpression returns an expression that calls a JavaScript function with
+JSON encoded arguments.
+*/
+func callExpression(declaration string, args ...interface{}) (string, error) {
+	arguments := make([]string, 0, len(args))
+	for _, arg := range args {
+		data, err := json.Marshal(arg)
+		if nil != err {
+			return "", errors.Wrap(err, "could not encode function argument")
+		}
+		arguments = append(arguments, string(data))
+	}
+	return fmt.Sprintf("(%s)(%s)", declaration, strings.Join(arguments, ", ")), nil
+}
+
+/*
+isContextDestroyed returns whether an evaluation failed because its execution
+context was destroyed by a navigation.
+*/
+func isContextDestroyed(err error) bool {
+	return strings.Contains(err.Error(), "Execution context was destroyed") ||
+		strings.Contains(err.Error(), "Cannot find context")
+}
+
+/*
+isTruthy returns whether a remote object is a truthy JavaScript value.
+*/
+func isTruthy(object *runtime.RemoteObject) bool {
+	switch object.Type {
+	case runtime.ObjectType.Undefined:
+		return false
+	case runtime.ObjectType.Boolean:
+		return true == object.Value
+	case runtime.ObjectType.String:
+		return "" != object.Value
+	case runtime.ObjectType.Number:
+		switch object.UnserializableValue {
+		case runtime.UnserializableValue.NaN, runtime.UnserializableValue.NegZero:
+			return false
+		}
+		return 0.0 != object.Value
+	case runtime.ObjectType.Object:
+		return runtime.ObjectSubtype.Null != object.Subtype
+	}
+	return true
+}
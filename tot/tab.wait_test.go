@@ -0,0 +1,292 @@
+package chrome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mkenney/go-chrome/tot/cdtp/runtime"
+)
+
+/*
+newEvaluateTab returns a tab connected to a test page socket that answers the
+nth Runtime.evaluate command with the nth result, repeating the last result,
+and a function returning the evaluated expressions. Objects kept in the element
+group keep their object ID.
+*/
+func newEvaluateTab(t *testing.T, results ...string) (*Tab, func() []string) {
+	mux := &sync.Mutex{}
+	expressions := []string{}
+	tab := newPageTab(t, func(method string, params json.RawMessage) (string, []string) {
+		if "Runtime.callFunctionOn" == method {
+			return keptObjectResponse(params, ""), nil
+		}
+		if "Runtime.evaluate" != method {
+			return "", nil
+		}
+		evaluate := &runtime.EvaluateParams{}
+		json.Unmarshal(params, evaluate)
+		mux.Lock()
+		defer mux.Unlock()
+		expressions = append(expressions, evaluate.Expression)
+		if len(expressions) > len(results) {
+			return results[len(results)-1], nil
+		}
+		return results[len(expressions)-1], nil
+	})
+	return tab, func() []string {
+		mux.Lock()
+		defer mux.Unlock()
+		return append([]string{}, expressions...)
+	}
+}
+
+/*
+keptObjectResponse returns the response to a call of keepObjectFunction, a node
+with the object ID of the call prefixed with prefix.
+*/
+func keptObjectResponse(params json.RawMessage, prefix string) string {
+	call := &runtime.CallFunctionOnParams{}
+	json.Unmarshal(params, call)
+	return fmt.Sprintf(`{"result": {"type": "object", "subtype": "node", "objectId": "%s%s"}}`, prefix, call.ObjectID)
+}
+
+func TestTabWaitForSelector(t *testing.T) {
+	interval := PollInterval
+	PollInterval = 10 * time.Millisecond
+	defer func() { PollInterval = interval }()
+
+	tab, expressions := newEvaluateTab(t,
+		`{"result": {"type": "object", "subtype": "null", "value": null}}`,
+		`{"result": {"type": "object", "subtype": "null", "value": null}}`,
+		`{"result": {"type": "object", "subtype": "node", "objectId": "element-1"}}`,
+	)
+	element, err := tab.WaitForSelector(context.Background(), `a[href="/home"]`, SelectorVisible)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if nil == element || "element-1" != element.ObjectID() {
+		t.Fatalf("Expected element-1, received %v", element)
+	}
+	if 3 != len(expressions()) {
+		t.Errorf("Expected 3 polls, received %d", len(expressions()))
+	}
+	if !strings.HasSuffix(expressions()[0], `)("a[href=\"/home\"]", "visible")`) {
+		t.Errorf("Expected the selector and state arguments, received '%s'", expressions()[0])
+	}
+
+	tab, _ = newEvaluateTab(t,
+		`{"result": {"type": "object", "subtype": "null", "value": null}}`,
+		`{"result": {"type": "boolean", "value": true}}`,
+	)
+	element, err = tab.WaitForSelector(context.Background(), ".spinner", SelectorHidden)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if nil != element {
+		t.Errorf("Expected nil, received %v", element)
+	}
+
+	if _, err := tab.WaitForSelector(context.Background(), ".spinner", SelectorState(7)); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
+
+func TestTabWaitForSelectorEngine(t *testing.T) {
+	interval := PollInterval
+	PollInterval = 10 * time.Millisecond
+	defer func() { PollInterval = interval }()
+
+	mux := &sync.Mutex{}
+	queries := 0
+	visible := false
+	tab := newPageTab(t, func(method string, params json.RawMessage) (string, []string) {
+		call := &runtime.CallFunctionOnParams{}
+		json.Unmarshal(params, call)
+		mux.Lock()
+		defer mux.Unlock()
+		switch {
+		case "Runtime.evaluate" == method:
+			return `{"result": {"type": "object", "subtype": "node", "objectId": "document-1"}}`, nil
+		case "Runtime.callFunctionOn" != method:
+			return "", nil
+		case keepObjectFunction == call.FunctionDeclaration:
+			return keptObjectResponse(params, ""), nil
+		case strings.Contains(call.FunctionDeclaration, "createTreeWalker"):
+			queries++
+			if queries < 3 {
+				return `{"result": {"type": "object", "subtype": "null", "value": null}}`, nil
+			}
+			return `{"result": {"type": "object", "subtype": "node", "objectId": "element-1"}}`, nil
+		case strings.Contains(call.FunctionDeclaration, "getComputedStyle"):
+			visible = !visible
+			return fmt.Sprintf(`{"result": {"type": "boolean", "value": %v}}`, visible), nil
+		}
+		return `{"result": {"type": "undefined"}}`, nil
+	})
+
+	element, err := tab.WaitForSelector(context.Background(), "text=Ready", SelectorVisible)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if nil == element || "element-1" != element.ObjectID() {
+		t.Fatalf("Expected element-1, received %v", element)
+	}
+	if 3 != queries {
+		t.Errorf("Expected 3 queries, received %d", queries)
+	}
+
+	element, err = tab.WaitForSelector(context.Background(), "text=Ready", SelectorHidden)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if nil != element {
+		t.Errorf("Expected nil, received %v", element)
+	}
+	if 4 != queries {
+		t.Errorf("Expected 4 queries, received %d", queries)
+	}
+
+	if _, err := tab.WaitForSelector(context.Background(), "missing=Ready", SelectorAttached); nil == err {
+		t.Errorf("Expected error, received nil")
+	}
+}
+
+func TestTabWaitForSelectorObjectGroups(t *testing.T) {
+	interval := PollInterval
+	PollInterval = 10 * time.Millisecond
+	defer func() { PollInterval = interval }()
+
+	mux := &sync.Mutex{}
+	commands := []pageCommand{}
+	tab := newPageTab(t, func(method string, raw json.RawMessage) (string, []string) {
+		params := map[string]interface{}{}
+		json.Unmarshal(raw, &params)
+		mux.Lock()
+		defer mux.Unlock()
+		commands = append(commands, pageCommand{Method: method, Params: params})
+		switch method {
+		case "Runtime.evaluate":
+			if len(commands) < 5 {
+				return `{"result": {"type": "object", "subtype": "null", "value": null}}`, nil
+			}
+			return `{"result": {"type": "object", "subtype": "node", "objectId": "element-1"}}`, nil
+		case "Runtime.callFunctionOn":
+			return keptObjectResponse(raw, "kept-"), nil
+		}
+		return "", nil
+	})
+
+	element, err := tab.WaitForSelector(context.Background(), "#ready", SelectorAttached)
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if nil == element || "kept-element-1" != element.ObjectID() {
+		t.Fatalf("Expected kept-element-1, received %v", element)
+	}
+
+	mux.Lock()
+	defer mux.Unlock()
+	group := commands[0].Params["objectGroup"]
+	if !strings.HasPrefix(fmt.Sprint(group), "go-chrome-poll-") {
+		t.Errorf("Expected a temporary object group, received %v", group)
+	}
+	releases := 0
+	for _, command := range commands {
+		switch command.Method {
+		case "Runtime.evaluate", "Runtime.releaseObjectGroup":
+			if group != command.Params["objectGroup"] {
+				t.Errorf("Expected the temporary object group, received %v", command.Params)
+			}
+			if "Runtime.releaseObjectGroup" == command.Method {
+				releases++
+			}
+		case "Runtime.callFunctionOn":
+			if tab.elementGroup() != command.Params["objectGroup"] || "element-1" != command.Params["objectId"] {
+				t.Errorf("Expected element-1 to be kept in the element group, received %v", command.Params)
+			}
+		}
+	}
+	if 3 != releases {
+		t.Errorf("Expected the temporary object group to be released after every poll, received %d releases", releases)
+	}
+}
+
+func TestTabWaitForSelectorTimeout(t *testing.T) {
+	interval := PollInterval
+	PollInterval = 10 * time.Millisecond
+	defer func() { PollInterval = interval }()
+
+	tab, _ := newEvaluateTab(t, `{"result": {"type": "object", "subtype": "null", "value": null}}`)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := tab.WaitForSelector(ctx, "#missing", SelectorAttached)
+	if nil == err {
+		t.Fatalf("Expected error, received nil")
+	}
+	if !strings.Contains(err.Error(), "timed out waiting for selector '#missing' to be attached") {
+		t.Errorf("Expected a timeout, received '%s'", err.Error())
+	}
+
+	tab, expressions := newEvaluateTab(t, `{"result": {"type": "object"}, "exceptionDetails": {"text": "Uncaught", "exception": {"type": "object", "description": "SyntaxError: '!!' is not a valid selector"}}}`)
+	_, err = tab.WaitForSelector(context.Background(), "!!", SelectorAttached)
+	if nil == err || !strings.Contains(err.Error(), "is not a valid selector") {
+		t.Errorf("Expected the exception, received %v", err)
+	}
+	if 1 != len(expressions()) {
+		t.Errorf("Expected a single evaluation, received %d", len(expressions()))
+	}
+}
+
+func TestTabWaitForFunction(t *testing.T) {
+	interval := PollInterval
+	PollInterval = 10 * time.Millisecond
+	defer func() { PollInterval = interval }()
+
+	tab, expressions := newEvaluateTab(t,
+		`{"result": {"type": "number", "value": 0}}`,
+		`{"result": {"type": "string", "value": ""}}`,
+		`{"result": {"type": "undefined"}}`,
+		`{"result": {"type": "string", "value": "ready"}}`,
+	)
+	value, err := tab.WaitForFunction(context.Background(), "window.status")
+	if nil != err {
+		t.Fatalf("Expected nil, received error: '%s'", err.Error())
+	}
+	if "ready" != value.Value {
+		t.Errorf("Expected 'ready', received %v", value.Value)
+	}
+	if 4 != len(expressions()) || "window.status" != expressions()[3] {
+		t.Errorf("Expected 4 evaluations of 'window.status', received %v", expressions())
+	}
+}
+
+func TestIsTruthy(t *testing.T) {
+	tests := map[string]bool{
+		`{"type": "undefined"}`:                                          false,
+		`{"type": "boolean", "value": false}`:                            false,
+		`{"type": "boolean", "value": true}`:                             true,
+		`{"type": "number", "value": 0}`:                                 false,
+		`{"type": "number", "value": 2}`:                                 true,
+		`{"type": "number", "unserializableValue": "NaN"}`:               false,
+		`{"type": "number", "unserializableValue": "Infinity"}`:          true,
+		`{"type": "string", "value": ""}`:                                false,
+		`{"type": "string", "value": "0"}`:                               true,
+		`{"type": "object", "subtype": "null", "value": null}`:           false,
+		`{"type": "object", "subtype": "node", "objectId": "element-1"}`: true,
+		`{"type": "function", "objectId": "function-1"}`:                 true,
+	}
+	for data, expected := range tests {
+		object := &runtime.RemoteObject{}
+		if err := json.Unmarshal([]byte(data), object); nil != err {
+			t.Fatalf("Expected nil, received error: '%s'", err.Error())
+		}
+		if expected != isTruthy(object) {
+			t.Errorf("%s: expected %v", data, expected)
+		}
+	}
+}